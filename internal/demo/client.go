@@ -0,0 +1,40 @@
+package demo
+
+import (
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"time"
+)
+
+// Client serves requests from the in-process fake GitHub API. It satisfies the
+// HttpClient interfaces of the github and version repositories.
+type Client struct {
+	server *Server
+
+	// latency simulates the network, so that loading states are visible
+	latency time.Duration
+}
+
+// NewClient returns a client backed by a freshly seeded fake GitHub API.
+func NewClient() *Client {
+	return &Client{
+		server:  NewServer(),
+		latency: 150 * time.Millisecond,
+	}
+}
+
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	if c.latency > 0 {
+		jitter := time.Duration(rand.Int63n(int64(c.latency)))
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(c.latency/2 + jitter):
+		}
+	}
+
+	recorder := httptest.NewRecorder()
+	c.server.ServeHTTP(recorder, req)
+	return recorder.Result(), nil
+}
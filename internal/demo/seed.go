@@ -0,0 +1,552 @@
+package demo
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"time"
+
+	gr "github.com/termkit/gama/internal/github/repository"
+	"gopkg.in/yaml.v3"
+)
+
+const demoOwner = "gama-demo"
+
+const deployWorkflow = `name: Deploy
+on:
+  push:
+    branches: [main]
+  workflow_dispatch:
+    inputs:
+      environment:
+        description: 'Target environment'
+        type: environment
+        required: true
+      version:
+        description: 'Version to deploy, type "fail" to simulate a failing run'
+        type: string
+        required: true
+      replicas:
+        description: 'Number of replicas'
+        type: number
+        default: 3
+      dry_run:
+        description: 'Only print the plan'
+        type: boolean
+        default: false
+      region:
+        description: 'Region'
+        type: choice
+        options:
+          - eu-west-1
+          - us-east-1
+          - ap-southeast-2
+        default: eu-west-1
+      components:
+        description: 'Component versions'
+        default: '{"frontend": {"ref": "main", "replicas": 2}, "backend": {"ref": "main", "canary": false}, "worker": "stable", "regions": ["eu-west-1"]}'
+
+permissions:
+  contents: read
+  deployments: write
+
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - run: make build VERSION=${{ inputs.version }}
+  test:
+    runs-on: ubuntu-latest
+    needs: build
+    steps:
+      - uses: actions/checkout@v4
+      - run: make test
+  deploy:
+    runs-on: ubuntu-latest
+    needs: [build, test]
+    environment: ${{ inputs.environment }}
+    steps:
+      - uses: actions/checkout@v4
+      - run: ./deploy.sh --replicas ${{ inputs.replicas }} --region ${{ inputs.region }}
+`
+
+// canaryDeployWorkflow is the deploy workflow on the feature branch, it gained
+// a canary input and is still a work in progress with mistakes for the linter.
+const canaryDeployWorkflow = `name: Deploy
+on:
+  workflow_dispatch:
+    inputs:
+      version:
+        description: 'Version to deploy'
+        required: true
+      canary_percentage:
+        description: 'Traffic sent to the canary'
+        type: number
+        default: 10
+
+jobs:
+  deploy:
+    runs-on: ubuntu-latest
+    needs: smoke-test
+    steps:
+      - uses: actions/checkout@v4
+      - run: ./deploy.sh --canary ${{ inputs.canary_percentage }} --version ${{ inputs.version }
+`
+
+const ciWorkflow = `name: CI
+on: [push, pull_request]
+
+jobs:
+  lint:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - run: make lint
+  test:
+    runs-on: ${{ matrix.os }}
+    strategy:
+      matrix:
+        os: [ubuntu-latest, macos-latest]
+        node: [18, 20]
+        include:
+          - os: ubuntu-latest
+            node: 22
+            experimental: true
+        exclude:
+          - os: macos-latest
+            node: 18
+    steps:
+      - uses: actions/checkout@v4
+      - uses: actions/setup-node@v4
+        with:
+          node-version: ${{ matrix.node }}
+      - run: npm test
+`
+
+const releaseWorkflow = `name: Release
+on: workflow_dispatch
+
+permissions:
+  contents: write
+
+jobs:
+  release:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - run: make release
+`
+
+const migrateWorkflow = `name: Database Migration
+on:
+  workflow_dispatch:
+    inputs:
+      database:
+        description: 'Database to migrate'
+        type: choice
+        required: true
+        options:
+          - users
+          - orders
+          - analytics
+      steps:
+        description: 'Number of migration steps, 0 for all'
+        type: number
+        default: 0
+      confirm:
+        description: 'I have a backup'
+        type: boolean
+        required: true
+
+jobs:
+  migrate:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - run: ./migrate.sh ${{ inputs.database }} ${{ inputs.steps }}
+`
+
+const nightlyWorkflow = `name: Nightly
+on:
+  schedule:
+    - cron: '0 3 * * *'
+  workflow_dispatch:
+
+jobs:
+  nightly:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - run: make nightly
+`
+
+const prTargetWorkflow = `name: PR Preview
+on:
+  pull_request_target:
+    types: [opened, synchronize]
+
+permissions:
+  pull-requests: write
+
+jobs:
+  preview:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+        with:
+          ref: ${{ github.event.pull_request.head.sha }}
+      - run: echo "Building ${{ github.event.pull_request.title }}"
+      - uses: some-org/preview-action@main
+`
+
+const driftWorkflow = `name: Drift detection
+on:
+  repository_dispatch:
+  schedule:
+    - cron: '0 6 * * *'
+
+jobs:
+  detect:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - run: terraform plan -detailed-exitcode
+  report:
+    needs: detect
+    if: failure()
+    uses: gama-demo/infra/.github/workflows/notify.yml@main
+    with:
+      channel: '#ops'
+`
+
+const terraformWorkflow = `name: Terraform
+on:
+  repository_dispatch:
+    types: [terraform-plan, terraform-apply]
+  workflow_dispatch:
+    inputs:
+      workspace:
+        description: 'Terraform workspace'
+        type: choice
+        options: [staging, production]
+        default: staging
+      plan_only:
+        description: 'Skip apply'
+        type: boolean
+        default: true
+      targets:
+        description: 'Comma separated resource targets'
+        type: string
+
+permissions:
+  contents: read
+  id-token: write
+
+jobs:
+  plan:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - run: terraform plan -out plan.tfplan
+  apply:
+    runs-on: ubuntu-latest
+    needs: plan
+    if: ${{ !inputs.plan_only }}
+    steps:
+      - run: terraform apply plan.tfplan
+  notify:
+    needs: [plan, apply]
+    uses: ./.github/workflows/notify.yml
+    with:
+      message: Terraform finished
+    secrets: inherit
+`
+
+const notifyWorkflow = `name: Notify
+on:
+  workflow_call:
+    inputs:
+      message:
+        description: 'Message to send'
+        type: string
+        required: true
+    secrets:
+      SLACK_WEBHOOK:
+        required: false
+    outputs:
+      sent_at:
+        description: 'When the message was sent'
+        value: ${{ jobs.notify.outputs.sent_at }}
+
+jobs:
+  notify:
+    runs-on: ubuntu-latest
+    outputs:
+      sent_at: ${{ steps.send.outputs.sent_at }}
+    steps:
+      - id: send
+        run: echo "sent_at=$(date)" >> "$GITHUB_OUTPUT"
+`
+
+const mobileBuildWorkflow = `name: Mobile Build
+on:
+  workflow_dispatch:
+    inputs:
+      platforms:
+        description: 'Platforms to build, as a JSON list'
+        default: '["ios", "android"]'
+      build_type:
+        description: 'Build type'
+        type: choice
+        options: [debug, release]
+        default: debug
+
+jobs:
+  build:
+    runs-on: macos-latest
+    strategy:
+      fail-fast: false
+      matrix:
+        platform: ${{ fromJSON(inputs.platforms) }}
+        flavor: [free, pro]
+    steps:
+      - uses: actions/checkout@v4
+      - run: ./build.sh ${{ matrix.platform }} ${{ matrix.flavor }} ${{ inputs.build_type }}
+  publish:
+    runs-on: ubuntu-latest
+    needs: build
+    steps:
+      - run: ./publish.sh
+`
+
+type seedRun struct {
+	workflow   string
+	title      string
+	branch     string
+	event      string
+	age        time.Duration // how long ago the run was created
+	duration   time.Duration
+	status     string // fixed status, empty for completed runs
+	conclusion string
+}
+
+type seedRepository struct {
+	name         string
+	description  string
+	private      bool
+	stars        int
+	updated      time.Duration // how long ago the repository was updated
+	environments []string
+	files        map[string]map[string]string
+	runs         []seedRun
+}
+
+func (s *Server) seed() {
+	now := s.now()
+
+	seedRepositories := []seedRepository{
+		{
+			name:         "web-app",
+			description:  "Customer facing web application",
+			stars:        128,
+			updated:      10 * time.Minute,
+			environments: []string{"staging", "production", "preview"},
+			files: map[string]map[string]string{
+				"main": {
+					".github/workflows/deploy.yml":  deployWorkflow,
+					".github/workflows/ci.yml":      ciWorkflow,
+					".github/workflows/release.yml": releaseWorkflow,
+				},
+				"feature/canary": {
+					".github/workflows/deploy.yml": canaryDeployWorkflow,
+					".github/workflows/ci.yml":     ciWorkflow,
+				},
+			},
+			runs: []seedRun{
+				{workflow: "deploy.yml", title: "Deploy v2.4.1", branch: "main", event: "workflow_dispatch", age: 2 * time.Minute, duration: 45 * time.Minute, status: "in_progress"},
+				{workflow: "deploy.yml", title: "Deploy v2.4.1 to production", branch: "main", event: "workflow_dispatch", age: 5 * time.Minute, status: "waiting"},
+				{workflow: "ci.yml", title: "Add dark mode toggle", branch: "main", event: "push", age: 1 * time.Minute, status: "queued"},
+				{workflow: "ci.yml", title: "Fix checkout button alignment", branch: "main", event: "push", age: 3 * time.Hour, duration: 6 * time.Minute, conclusion: "success"},
+				{workflow: "ci.yml", title: "Bump react to 18.3", branch: "main", event: "pull_request", age: 5 * time.Hour, duration: 9 * time.Minute, conclusion: "failure"},
+				{workflow: "deploy.yml", title: "Deploy v2.4.0", branch: "main", event: "workflow_dispatch", age: 26 * time.Hour, duration: 14 * time.Minute, conclusion: "success"},
+				{workflow: "release.yml", title: "Release", branch: "main", event: "workflow_dispatch", age: 27 * time.Hour, duration: 90 * time.Second, conclusion: "cancelled"},
+				{workflow: "ci.yml", title: "Canary routing", branch: "feature/canary", event: "push", age: 2 * time.Hour, duration: 7 * time.Minute, conclusion: "success"},
+			},
+		},
+		{
+			name:        "api-service",
+			description: "REST API backing the web application",
+			private:     true,
+			stars:       42,
+			updated:     2 * time.Hour,
+			files: map[string]map[string]string{
+				"main": {
+					".github/workflows/migrate.yml":    migrateWorkflow,
+					".github/workflows/nightly.yml":    nightlyWorkflow,
+					".github/workflows/pr-preview.yml": prTargetWorkflow,
+					".github/workflows/release.yml":    releaseWorkflow,
+				},
+			},
+			runs: []seedRun{
+				{workflow: "nightly.yml", title: "Nightly", branch: "main", event: "schedule", age: 8 * time.Hour, duration: 32 * time.Minute, conclusion: "timed_out"},
+				{workflow: "migrate.yml", title: "Database Migration", branch: "main", event: "workflow_dispatch", age: 30 * time.Hour, duration: 4 * time.Minute, conclusion: "success"},
+				{workflow: "pr-preview.yml", title: "Add pagination to /orders", branch: "main", event: "pull_request_target", age: 50 * time.Hour, duration: 0, conclusion: "skipped"},
+				{workflow: "nightly.yml", title: "Nightly", branch: "main", event: "schedule", age: 32 * time.Hour, duration: 29 * time.Minute, conclusion: "success"},
+			},
+		},
+		{
+			name:        "infra",
+			description: "Terraform for all environments",
+			private:     true,
+			stars:       7,
+			updated:     26 * time.Hour,
+			files: map[string]map[string]string{
+				"main": {
+					".github/workflows/terraform.yml": terraformWorkflow,
+					".github/workflows/notify.yml":    notifyWorkflow,
+					".github/workflows/drift.yml":     driftWorkflow,
+				},
+			},
+			runs: []seedRun{
+				{workflow: "terraform.yml", title: "terraform-plan", branch: "main", event: "repository_dispatch", age: 4 * time.Hour, duration: 3 * time.Minute, conclusion: "success"},
+				{workflow: "terraform.yml", title: "Terraform", branch: "main", event: "workflow_dispatch", age: 29 * time.Hour, duration: 11 * time.Minute, conclusion: "action_required"},
+			},
+		},
+		{
+			name:        "mobile-app",
+			description: "iOS and Android clients",
+			stars:       64,
+			updated:     3 * 24 * time.Hour,
+			files: map[string]map[string]string{
+				"main": {
+					".github/workflows/build.yml":   mobileBuildWorkflow,
+					".github/workflows/release.yml": releaseWorkflow,
+				},
+			},
+			runs: []seedRun{
+				{workflow: "build.yml", title: "Mobile Build", branch: "main", event: "workflow_dispatch", age: 3 * 24 * time.Hour, duration: 48 * time.Minute, conclusion: "failure"},
+			},
+		},
+		{
+			name:        "docs",
+			description: "Documentation site, without any workflow",
+			stars:       3,
+			updated:     12 * 24 * time.Hour,
+			files: map[string]map[string]string{
+				"main": {
+					"README.md": "# Docs\n",
+				},
+			},
+		},
+	}
+
+	var repositoryID, workflowID int64 = 100, 500
+	for _, seedRepo := range seedRepositories {
+		repositoryID++
+		fullName := demoOwner + "/" + seedRepo.name
+
+		visibility := "public"
+		if seedRepo.private {
+			visibility = "private"
+		}
+
+		repo := &repository{
+			info: gr.GithubRepository{
+				Id:              int(repositoryID),
+				Name:            seedRepo.name,
+				FullName:        fullName,
+				Private:         seedRepo.private,
+				Description:     seedRepo.description,
+				StargazersCount: seedRepo.stars,
+				DefaultBranch:   "main",
+				Visibility:      visibility,
+				UpdatedAt:       now.Add(-seedRepo.updated),
+				PushedAt:        now.Add(-seedRepo.updated),
+				CreatedAt:       now.Add(-365 * 24 * time.Hour),
+			},
+			environments: seedRepo.environments,
+			files:        seedRepo.files,
+		}
+		repo.info.Permissions.Admin = true
+		repo.info.Permissions.Push = true
+		repo.info.Permissions.Pull = true
+
+		for _, branch := range []string{"main", "feature/canary"} {
+			if _, ok := seedRepo.files[branch]; ok {
+				repo.branches = append(repo.branches, branch)
+			}
+		}
+
+		// Workflows are registered from the default branch, like GitHub does
+		for _, file := range sortedKeys(seedRepo.files["main"]) {
+			if path.Dir(file) != ".github/workflows" {
+				continue
+			}
+			workflowID++
+			repo.workflows = append(repo.workflows, gr.Workflow{
+				ID:        workflowID,
+				Name:      workflowName(seedRepo.files["main"][file], file),
+				Path:      file,
+				State:     "active",
+				UpdatedAt: now.Add(-seedRepo.updated),
+				Url:       fmt.Sprintf("https://api.github.com/repos/%s/actions/workflows/%d", fullName, workflowID),
+				HtmlUrl:   fmt.Sprintf("https://github.com/%s/blob/main/%s", fullName, file),
+			})
+		}
+
+		for _, seedRun := range seedRepo.runs {
+			s.nextRunID++
+			workflow := repo.workflowByPath(".github/workflows/" + seedRun.workflow)
+			createdAt := now.Add(-seedRun.age)
+
+			r := &run{
+				WorkflowRun: gr.WorkflowRun{
+					ID:              s.nextRunID,
+					WorkflowID:      workflow.ID,
+					Name:            workflow.Name,
+					DisplayTitle:    seedRun.title,
+					Actor:           gr.Actor{Id: 1, Login: s.login},
+					TriggeringActor: gr.Actor{Id: 1, Login: s.login},
+					CreatedAt:       createdAt,
+					UpdatedAt:       createdAt.Add(seedRun.duration),
+					HeadBranch:      seedRun.branch,
+					RunAttempt:      1,
+					Path:            workflow.Path,
+					Event:           seedRun.event,
+					HTMLURL:         fmt.Sprintf("https://github.com/%s/actions/runs/%d", fullName, s.nextRunID),
+					LogsURL:         fmt.Sprintf("https://api.github.com/repos/%s/actions/runs/%d/logs", fullName, s.nextRunID),
+				},
+				runFor:      seedRun.duration,
+				conclusion:  seedRun.conclusion,
+				fixedStatus: seedRun.status,
+			}
+			if seedRun.status == "" {
+				r.fixedStatus = "completed"
+			}
+			repo.runs = append(repo.runs, r)
+		}
+
+		s.repositories = append(s.repositories, repo)
+	}
+}
+
+// workflowName returns the name of the workflow, GitHub falls back to the file path.
+func workflowName(content string, file string) string {
+	var workflow struct {
+		Name string `yaml:"name"`
+	}
+	if err := yaml.Unmarshal([]byte(content), &workflow); err != nil || workflow.Name == "" {
+		return file
+	}
+	return workflow.Name
+}
+
+func sortedKeys(files map[string]string) []string {
+	keys := make([]string, 0, len(files))
+	for key := range files {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,560 @@
+package demo
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	gr "github.com/termkit/gama/internal/github/repository"
+	pw "github.com/termkit/gama/pkg/workflow"
+	py "github.com/termkit/gama/pkg/yaml"
+)
+
+// Server is a fake GitHub REST API implementing the endpoints used by gama.
+// Dispatched and re-run workflows progress from queued to completed over time.
+type Server struct {
+	mu  sync.Mutex
+	now func() time.Time
+
+	login        string
+	repositories []*repository
+	nextRunID    int64
+}
+
+type repository struct {
+	info         gr.GithubRepository
+	branches     []string
+	environments []string
+	files        map[string]map[string]string // branch -> file path -> content
+	workflows    []gr.Workflow
+	runs         []*run
+}
+
+type run struct {
+	gr.WorkflowRun
+
+	// queuedFor and runFor drive the simulated progress of the run
+	queuedFor  time.Duration
+	runFor     time.Duration
+	conclusion string
+
+	// fixedStatus pins the status of seeded runs, e.g. "waiting" for approvals
+	fixedStatus string
+}
+
+const (
+	demoLogin       = "octocat-demo"
+	demoLatestTag   = "v1.0.0"
+	dispatchQueued  = 3 * time.Second
+	dispatchRunning = 20 * time.Second
+)
+
+// NewServer returns a fake GitHub API seeded with demo repositories.
+func NewServer() *Server {
+	return newServer(time.Now)
+}
+
+func newServer(now func() time.Time) *Server {
+	s := &Server{
+		now:       now,
+		login:     demoLogin,
+		nextRunID: 9000,
+	}
+	s.seed()
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// GitHub Enterprise Server APIs are prefixed with /api/v3
+	requestPath := strings.TrimPrefix(req.URL.Path, "/api/v3")
+	segments := strings.Split(strings.Trim(requestPath, "/"), "/")
+
+	switch {
+	case requestPath == "/user" && req.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, gr.Actor{Id: 1, Login: s.login})
+	case requestPath == "/user/repos" && req.Method == http.MethodGet:
+		s.listRepositories(w, req)
+	case requestPath == "/repos/termkit/gama/releases/latest":
+		writeJSON(w, http.StatusOK, map[string]string{"tag_name": demoLatestTag})
+	case len(segments) >= 3 && segments[0] == "repos":
+		repo := s.findRepository(segments[1] + "/" + segments[2])
+		if repo == nil {
+			writeError(w, http.StatusNotFound, "Not Found")
+			return
+		}
+		s.serveRepository(w, req, repo, segments[3:])
+	default:
+		writeError(w, http.StatusNotFound, "Not Found")
+	}
+}
+
+func (s *Server) serveRepository(w http.ResponseWriter, req *http.Request, repo *repository, segments []string) {
+	route := strings.Join(segments, "/")
+
+	switch {
+	case route == "" && req.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, repo.info)
+	case route == "branches" && req.Method == http.MethodGet:
+		var branches []gr.GithubBranch
+		for _, branch := range repo.branches {
+			branches = append(branches, gr.GithubBranch{Name: branch})
+		}
+		writeJSON(w, http.StatusOK, branches)
+	case route == "environments" && req.Method == http.MethodGet:
+		environments := []gr.Environment{}
+		for i, name := range repo.environments {
+			environments = append(environments, gr.Environment{
+				ID:      int64(repo.info.Id*10 + i),
+				Name:    name,
+				HtmlUrl: fmt.Sprintf("https://github.com/%s/deployments/%s", repo.info.FullName, name),
+			})
+		}
+		writeJSON(w, http.StatusOK, map[string]any{
+			"total_count":  len(environments),
+			"environments": environments,
+		})
+	case len(segments) >= 1 && segments[0] == "contents" && req.Method == http.MethodGet:
+		s.getContents(w, req, repo, strings.Join(segments[1:], "/"))
+	case route == "actions/workflows" && req.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, map[string]any{
+			"total_count": len(repo.workflows),
+			"workflows":   repo.workflows,
+		})
+	case len(segments) == 4 && segments[0] == "actions" && segments[1] == "workflows" && segments[3] == "dispatches" && req.Method == http.MethodPost:
+		s.dispatchWorkflow(w, req, repo, segments[2])
+	case route == "dispatches" && req.Method == http.MethodPost:
+		s.dispatchRepositoryEvent(w, req, repo)
+	case route == "actions/runs" && req.Method == http.MethodGet:
+		s.listRuns(w, req, repo)
+	case len(segments) >= 3 && segments[0] == "actions" && segments[1] == "runs":
+		runID, err := strconv.ParseInt(segments[2], 10, 64)
+		if err != nil {
+			writeError(w, http.StatusNotFound, "Not Found")
+			return
+		}
+		s.serveRun(w, req, repo, runID, strings.Join(segments[3:], "/"))
+	default:
+		writeError(w, http.StatusNotFound, "Not Found")
+	}
+}
+
+func (s *Server) serveRun(w http.ResponseWriter, req *http.Request, repo *repository, runID int64, action string) {
+	idx := slices.IndexFunc(repo.runs, func(r *run) bool { return r.ID == runID })
+	if idx < 0 {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	r := repo.runs[idx]
+	now := s.now()
+
+	switch {
+	case action == "" && req.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, r.snapshot(now))
+	case action == "jobs" && req.Method == http.MethodGet:
+		jobs := r.jobs(repo.files[r.HeadBranch][r.Path], now)
+		perPage, page := pagination(req)
+		start := min((page-1)*perPage, len(jobs))
+		end := min(start+perPage, len(jobs))
+		writeJSON(w, http.StatusOK, gr.WorkflowJobs{
+			TotalCount: int64(len(jobs)),
+			Jobs:       jobs[start:end],
+		})
+	case action == "logs" && req.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, gr.GithubWorkflowRunLogs{
+			TotalSize: 2048,
+			Url:       r.LogsURL,
+			Download:  r.LogsURL,
+		})
+	case (action == "rerun" || action == "rerun-failed-jobs") && req.Method == http.MethodPost:
+		if r.snapshot(now).Status != "completed" {
+			writeError(w, http.StatusForbidden, "This workflow run is not completed")
+			return
+		}
+		r.restart(now)
+		w.WriteHeader(http.StatusCreated)
+	case action == "cancel" && req.Method == http.MethodPost:
+		if r.snapshot(now).Status == "completed" {
+			writeError(w, http.StatusConflict, "Cannot cancel a workflow run that is completed.")
+			return
+		}
+		r.fixedStatus = "completed"
+		r.conclusion = "cancelled"
+		r.UpdatedAt = now
+		w.WriteHeader(http.StatusAccepted)
+	default:
+		writeError(w, http.StatusNotFound, "Not Found")
+	}
+}
+
+func (s *Server) listRepositories(w http.ResponseWriter, req *http.Request) {
+	perPage, page := pagination(req)
+
+	var repositories []gr.GithubRepository
+	for _, repo := range s.repositories {
+		repositories = append(repositories, repo.info)
+	}
+
+	start := min((page-1)*perPage, len(repositories))
+	end := min(start+perPage, len(repositories))
+	writeJSON(w, http.StatusOK, repositories[start:end])
+}
+
+func (s *Server) getContents(w http.ResponseWriter, req *http.Request, repo *repository, filePath string) {
+	ref := req.URL.Query().Get("ref")
+	if ref == "" {
+		ref = repo.info.DefaultBranch
+	}
+
+	files, ok := repo.files[ref]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("No commit found for the ref %s", ref))
+		return
+	}
+
+	if content, ok := files[filePath]; ok {
+		writeJSON(w, http.StatusOK, map[string]any{
+			"type":     "file",
+			"name":     path.Base(filePath),
+			"path":     filePath,
+			"encoding": "base64",
+			"content":  base64.StdEncoding.EncodeToString([]byte(content)),
+		})
+		return
+	}
+
+	// directory listing
+	var entries []map[string]any
+	for name := range files {
+		if path.Dir(name) == filePath {
+			entries = append(entries, map[string]any{
+				"type": "file",
+				"name": path.Base(name),
+				"path": name,
+			})
+		}
+	}
+	if len(entries) == 0 {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	slices.SortFunc(entries, func(a, b map[string]any) int {
+		return strings.Compare(a["path"].(string), b["path"].(string))
+	})
+	writeJSON(w, http.StatusOK, entries)
+}
+
+func (s *Server) dispatchWorkflow(w http.ResponseWriter, req *http.Request, repo *repository, workflowID string) {
+	var payload struct {
+		Ref    string         `json:"ref"`
+		Inputs map[string]any `json:"inputs"`
+	}
+	if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+		writeError(w, http.StatusBadRequest, "Problems parsing JSON")
+		return
+	}
+
+	files, ok := repo.files[payload.Ref]
+	if !ok {
+		writeError(w, http.StatusUnprocessableEntity, fmt.Sprintf("No ref found for: %s", payload.Ref))
+		return
+	}
+
+	workflowPath := ".github/workflows/" + workflowID
+	content, ok := files[workflowPath]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	if !strings.Contains(content, "workflow_dispatch") {
+		writeError(w, http.StatusUnprocessableEntity, "Workflow does not have 'workflow_dispatch' trigger")
+		return
+	}
+
+	conclusion := "success"
+	for _, value := range payload.Inputs {
+		if fmt.Sprint(value) == "fail" {
+			conclusion = "failure"
+		}
+	}
+
+	s.startRun(repo, workflowPath, payload.Ref, "workflow_dispatch", "", conclusion)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) dispatchRepositoryEvent(w http.ResponseWriter, req *http.Request, repo *repository) {
+	var payload struct {
+		EventType     string         `json:"event_type"`
+		ClientPayload map[string]any `json:"client_payload"`
+	}
+	if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+		writeError(w, http.StatusBadRequest, "Problems parsing JSON")
+		return
+	}
+	if payload.EventType == "" {
+		writeError(w, http.StatusUnprocessableEntity, "Invalid request.\n\n\"event_type\" wasn't supplied.")
+		return
+	}
+	if len(payload.ClientPayload) > 10 {
+		writeError(w, http.StatusUnprocessableEntity, "Invalid request.\n\nNo more than 10 properties are allowed; client_payload has more.")
+		return
+	}
+
+	conclusion := "success"
+	for _, value := range payload.ClientPayload {
+		if fmt.Sprint(value) == "fail" {
+			conclusion = "failure"
+		}
+	}
+
+	// Like GitHub, the event runs the listening workflows of the default branch
+	branch := repo.info.DefaultBranch
+	for _, workflowPath := range sortedKeys(repo.files[branch]) {
+		workflowContent, err := py.UnmarshalWorkflowContent([]byte(repo.files[branch][workflowPath]))
+		if err != nil {
+			continue
+		}
+		trigger, ok := workflowContent.On.Get("repository_dispatch")
+		if !ok || (len(trigger.Types) > 0 && !slices.Contains(trigger.Types, payload.EventType)) {
+			continue
+		}
+		s.startRun(repo, workflowPath, branch, "repository_dispatch", payload.EventType, conclusion)
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// startRun queues a run of the workflow, which progresses with the clock of the server.
+func (s *Server) startRun(repo *repository, workflowPath string, branch string, event string, title string, conclusion string) {
+	workflow := repo.workflowByPath(workflowPath)
+	if title == "" {
+		title = workflow.Name
+	}
+
+	now := s.now()
+	s.nextRunID++
+	repo.runs = append(repo.runs, &run{
+		WorkflowRun: gr.WorkflowRun{
+			ID:              s.nextRunID,
+			WorkflowID:      workflow.ID,
+			Name:            workflow.Name,
+			DisplayTitle:    title,
+			Actor:           gr.Actor{Id: 1, Login: s.login},
+			TriggeringActor: gr.Actor{Id: 1, Login: s.login},
+			CreatedAt:       now,
+			UpdatedAt:       now,
+			HeadBranch:      branch,
+			RunAttempt:      1,
+			Path:            workflowPath,
+			Event:           event,
+			HTMLURL:         fmt.Sprintf("https://github.com/%s/actions/runs/%d", repo.info.FullName, s.nextRunID),
+			LogsURL:         fmt.Sprintf("https://api.github.com/repos/%s/actions/runs/%d/logs", repo.info.FullName, s.nextRunID),
+		},
+		queuedFor:  dispatchQueued,
+		runFor:     dispatchRunning,
+		conclusion: conclusion,
+	})
+}
+
+func (s *Server) listRuns(w http.ResponseWriter, req *http.Request, repo *repository) {
+	query := req.URL.Query()
+	now := s.now()
+
+	var runs []gr.WorkflowRun
+	for _, r := range repo.runs {
+		if branch := query.Get("branch"); branch != "" && r.HeadBranch != branch {
+			continue
+		}
+		if event := query.Get("event"); event != "" && r.Event != event {
+			continue
+		}
+		runs = append(runs, r.snapshot(now))
+	}
+
+	slices.SortFunc(runs, func(a, b gr.WorkflowRun) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+
+	writeJSON(w, http.StatusOK, gr.WorkflowRuns{
+		TotalCount:   int64(len(runs)),
+		WorkflowRuns: runs,
+	})
+}
+
+func (s *Server) findRepository(fullName string) *repository {
+	for _, repo := range s.repositories {
+		if strings.EqualFold(repo.info.FullName, fullName) {
+			return repo
+		}
+	}
+	return nil
+}
+
+func (r *repository) workflowByPath(workflowPath string) gr.Workflow {
+	for _, workflow := range r.workflows {
+		if workflow.Path == workflowPath {
+			return workflow
+		}
+	}
+	return gr.Workflow{Name: path.Base(workflowPath), Path: workflowPath}
+}
+
+// snapshot returns the run as GitHub would report it at the given time.
+func (r *run) snapshot(now time.Time) gr.WorkflowRun {
+	snapshot := r.WorkflowRun
+
+	elapsed := now.Sub(r.CreatedAt)
+	switch {
+	case r.fixedStatus == "completed":
+		snapshot.Status = "completed"
+		snapshot.Conclusion = r.conclusion
+	case r.fixedStatus != "":
+		snapshot.Status = r.fixedStatus
+		snapshot.UpdatedAt = now
+	case elapsed < r.queuedFor:
+		snapshot.Status = "queued"
+		snapshot.UpdatedAt = now
+	case elapsed < r.queuedFor+r.runFor:
+		snapshot.Status = "in_progress"
+		snapshot.UpdatedAt = now
+	default:
+		snapshot.Status = "completed"
+		snapshot.Conclusion = r.conclusion
+		snapshot.UpdatedAt = r.CreatedAt.Add(r.queuedFor + r.runFor)
+	}
+
+	return snapshot
+}
+
+// jobs returns the jobs of the run at the given time. Jobs run layer by layer
+// of the graph of the workflow, a failing run fails a job in the middle of
+// the graph and skips the jobs needing it.
+func (r *run) jobs(workflow string, now time.Time) []gr.WorkflowJob {
+	graph, err := pw.NewGraph([]byte(workflow))
+	if err != nil || len(graph.Jobs) == 0 {
+		return []gr.WorkflowJob{}
+	}
+	snapshot := r.snapshot(now)
+
+	var failed *pw.GraphJob
+	if snapshot.Status == "completed" && snapshot.Conclusion != "success" && snapshot.Conclusion != "skipped" {
+		middle := graph.Layers[(len(graph.Layers)-1)/2]
+		failed = middle[len(middle)-1]
+	}
+
+	// Layer of the jobs in progress, or waiting for an approval in the last one
+	var running int
+	switch {
+	case snapshot.Status == "in_progress" && r.runFor > 0:
+		progress := float64(now.Sub(r.CreatedAt)-r.queuedFor) / float64(r.runFor)
+		running = min(int(progress*float64(len(graph.Layers))), len(graph.Layers)-1)
+	case snapshot.Status == "waiting":
+		running = len(graph.Layers) - 1
+	}
+
+	// Matrices are expanded with the default inputs when their job starts
+	names := make(map[string][]string)
+	if expanded, err := pw.ExpandJobs([]byte(workflow), nil); err == nil {
+		for _, job := range expanded {
+			if job.Matrix && job.Err == nil {
+				names[job.ID] = job.Names
+			}
+		}
+	}
+
+	blocked := make(map[*pw.GraphJob]bool)
+	var jobs []gr.WorkflowJob
+	for _, layer := range graph.Layers {
+		for _, job := range layer {
+			workflowJob := gr.WorkflowJob{
+				RunID:     r.ID,
+				Status:    "completed",
+				StartedAt: r.CreatedAt.Add(r.queuedFor),
+			}
+
+			switch {
+			case snapshot.Status == "completed" && (job == failed || snapshot.Conclusion == "skipped"):
+				workflowJob.Conclusion = snapshot.Conclusion
+			case slices.ContainsFunc(job.Needs, func(need *pw.GraphJob) bool { return need == failed || blocked[need] }):
+				workflowJob.Conclusion = "skipped"
+				blocked[job] = true
+			case snapshot.Status == "completed" || job.Layer < running:
+				workflowJob.Conclusion = "success"
+			case snapshot.Status == "in_progress" && job.Layer == running:
+				workflowJob.Status = "in_progress"
+			case snapshot.Status == "waiting" && job.Layer == running:
+				workflowJob.Status = "waiting" // for the approval of an environment
+			default:
+				workflowJob.Status = "queued"
+				workflowJob.StartedAt = time.Time{}
+			}
+			if workflowJob.Status == "completed" {
+				workflowJob.CompletedAt = snapshot.UpdatedAt
+			}
+
+			jobNames := names[job.ID]
+			if len(jobNames) == 0 || workflowJob.Conclusion == "skipped" || workflowJob.Status == "queued" {
+				jobNames = []string{job.Name} // not expanded
+			}
+			for i, name := range jobNames {
+				matrixJob := workflowJob
+				matrixJob.ID = r.ID*100 + int64(len(jobs)+1)
+				matrixJob.Name = name
+				matrixJob.HTMLURL = fmt.Sprintf("%s/job/%d", r.HTMLURL, matrixJob.ID)
+				if job == failed && i < len(jobNames)-1 {
+					matrixJob.Conclusion = "success" // only the last combination of a matrix fails
+				}
+				jobs = append(jobs, matrixJob)
+			}
+		}
+	}
+	return jobs
+}
+
+// restart re-runs a completed run, it succeeds this time.
+func (r *run) restart(now time.Time) {
+	r.CreatedAt = now
+	r.UpdatedAt = now
+	r.RunAttempt++
+	r.fixedStatus = ""
+	r.queuedFor = dispatchQueued
+	r.runFor = dispatchRunning
+	r.conclusion = "success"
+}
+
+func pagination(req *http.Request) (perPage int, page int) {
+	perPage, err := strconv.Atoi(req.URL.Query().Get("per_page"))
+	if err != nil || perPage <= 0 {
+		perPage = 30
+	}
+	page, err = strconv.Atoi(req.URL.Query().Get("page"))
+	if err != nil || page <= 0 {
+		page = 1
+	}
+	return perPage, page
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-RateLimit-Limit", "5000")
+	w.Header().Set("X-RateLimit-Remaining", "4999")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{
+		"message":           message,
+		"documentation_url": "https://docs.github.com/rest",
+	})
+}
@@ -0,0 +1,235 @@
+package demo
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	gr "github.com/termkit/gama/internal/github/repository"
+	gu "github.com/termkit/gama/internal/github/usecase"
+	pkgconcurrency "github.com/termkit/gama/pkg/concurrency"
+	pkgconfig "github.com/termkit/gama/pkg/config"
+	pw "github.com/termkit/gama/pkg/workflow"
+)
+
+func newTestRepository(t *testing.T) (*gr.Repo, *time.Time) {
+	t.Helper()
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	server := newServer(func() time.Time { return now })
+
+	return gr.New(pkgconfig.DemoConfig(), &Client{server: server}, nil), &now
+}
+
+func TestServer_ListRepositories(t *testing.T) {
+	repo, _ := newTestRepository(t)
+
+	// Two per page, the pages are joined in the order of the seed
+	repositories, err := repo.ListRepositories(context.Background(), 2)
+	assert.NoError(t, err)
+
+	var names []string
+	for _, repository := range repositories {
+		names = append(names, repository.FullName)
+	}
+	assert.Equal(t, []string{
+		"gama-demo/web-app",
+		"gama-demo/api-service",
+		"gama-demo/infra",
+		"gama-demo/mobile-app",
+		"gama-demo/docs",
+	}, names)
+}
+
+func TestServer_TriggerableWorkflowsPerBranch(t *testing.T) {
+	repo, _ := newTestRepository(t)
+	ctx := context.Background()
+
+	paths := func(workflows []gr.Workflow) []string {
+		var result []string
+		for _, workflow := range workflows {
+			result = append(result, workflow.Path)
+		}
+		return result
+	}
+
+	triggerable, err := repo.GetTriggerableWorkflows(ctx, "gama-demo/web-app", "")
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{".github/workflows/deploy.yml", ".github/workflows/release.yml"}, paths(triggerable))
+
+	// release.yml does not exist on the feature branch
+	triggerable, err = repo.GetTriggerableWorkflows(ctx, "gama-demo/web-app", "feature/canary")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{".github/workflows/deploy.yml"}, paths(triggerable))
+
+	triggerable, err = repo.GetTriggerableWorkflows(ctx, "gama-demo/docs", "main")
+	assert.NoError(t, err)
+	assert.Empty(t, triggerable)
+
+	githubUseCase := gu.New(repo, nil, nil)
+	_, err = githubUseCase.TriggerWorkflow(ctx, gu.TriggerWorkflowInput{
+		Repository:   "gama-demo/web-app",
+		Branch:       "feature/canary",
+		WorkflowFile: ".github/workflows/release.yml",
+	})
+	assert.ErrorContains(t, err, "does not exist on feature/canary")
+}
+
+func TestServer_DispatchProgress(t *testing.T) {
+	repo, now := newTestRepository(t)
+	ctx := context.Background()
+
+	err := repo.TriggerWorkflow(ctx, "gama-demo/api-service", "main", ".github/workflows/nightly.yml", nil)
+	assert.NoError(t, err)
+
+	latestRun := func() gr.WorkflowRun {
+		runs, err := repo.ListWorkflowRuns(ctx, "gama-demo/api-service", "main")
+		assert.NoError(t, err)
+		return runs.WorkflowRuns[0]
+	}
+
+	run := latestRun()
+	assert.Equal(t, "workflow_dispatch", run.Event)
+	assert.Equal(t, "queued", run.Status)
+
+	*now = now.Add(dispatchQueued + time.Second)
+	assert.Equal(t, "in_progress", latestRun().Status)
+
+	*now = now.Add(dispatchRunning)
+	run = latestRun()
+	assert.Equal(t, "completed", run.Status)
+	assert.Equal(t, "success", run.Conclusion)
+
+	assert.NoError(t, repo.ReRunWorkflow(ctx, "gama-demo/api-service", run.ID))
+	assert.Equal(t, "queued", latestRun().Status)
+	assert.Equal(t, 2, latestRun().RunAttempt)
+}
+
+func TestServer_DispatchErrors(t *testing.T) {
+	repo, _ := newTestRepository(t)
+	ctx := context.Background()
+
+	err := repo.TriggerWorkflow(ctx, "gama-demo/web-app", "no-such-branch", ".github/workflows/deploy.yml", nil)
+	assert.Error(t, err)
+
+	err = repo.TriggerWorkflow(ctx, "gama-demo/web-app", "main", ".github/workflows/ci.yml", nil)
+	assert.Error(t, err)
+}
+
+func TestServer_StreamRepositories(t *testing.T) {
+	repo, _ := newTestRepository(t)
+	githubUseCase := gu.New(repo, pkgconcurrency.New(pkgconfig.Concurrency{Workers: 2}), nil)
+
+	var progress []gu.StreamRepositoriesProgress
+	err := githubUseCase.StreamRepositories(context.Background(), gu.ListRepositoriesInput{}, func(p gu.StreamRepositoriesProgress) {
+		progress = append(progress, p)
+	})
+	assert.NoError(t, err)
+
+	assert.Len(t, progress, 5)
+	for i, p := range progress {
+		assert.Equal(t, i+1, p.Done)
+		assert.Equal(t, 5, p.Total)
+	}
+}
+
+func TestServer_EnvironmentInputs(t *testing.T) {
+	repo, _ := newTestRepository(t)
+	githubUseCase := gu.New(repo, nil, nil)
+
+	output, err := githubUseCase.InspectWorkflow(context.Background(), gu.InspectWorkflowInput{
+		Repository:   "gama-demo/web-app",
+		Branch:       "main",
+		WorkflowFile: ".github/workflows/deploy.yml",
+	})
+	assert.NoError(t, err)
+
+	idx := slices.IndexFunc(output.Workflow.Choices, func(choice pw.PrettyChoice) bool { return choice.Key == "environment" })
+	if assert.GreaterOrEqual(t, idx, 0) {
+		assert.Equal(t, []string{"staging", "production", "preview"}, output.Workflow.Choices[idx].Values)
+	}
+}
+
+func TestServer_AuthenticatedUser(t *testing.T) {
+	repo, _ := newTestRepository(t)
+
+	user, err := repo.GetAuthenticatedUser(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, demoLogin, user.Login)
+}
+
+func TestServer_RepositoryDispatch(t *testing.T) {
+	repo, _ := newTestRepository(t)
+	ctx := context.Background()
+
+	countDispatchedRuns := func() map[string]int {
+		runs, err := repo.ListWorkflowRuns(ctx, "gama-demo/infra", "main")
+		assert.NoError(t, err)
+		counts := map[string]int{}
+		for _, run := range runs.WorkflowRuns {
+			if run.Event == "repository_dispatch" {
+				counts[run.Path]++
+			}
+		}
+		return counts
+	}
+	before := countDispatchedRuns()
+
+	err := repo.DispatchRepositoryEvent(ctx, "gama-demo/infra", "terraform-plan", map[string]any{"stack": "network"})
+	assert.NoError(t, err)
+
+	// Both the listener of terraform-plan and the listener of any event run
+	after := countDispatchedRuns()
+	assert.Equal(t, before[".github/workflows/drift.yml"]+1, after[".github/workflows/drift.yml"])
+	assert.Equal(t, before[".github/workflows/terraform.yml"]+1, after[".github/workflows/terraform.yml"])
+
+	err = repo.DispatchRepositoryEvent(ctx, "gama-demo/infra", "", nil)
+	assert.Error(t, err)
+}
+
+func TestServer_GetWorkflowFile(t *testing.T) {
+	repo, _ := newTestRepository(t)
+	ctx := context.Background()
+
+	content, err := repo.InspectWorkflowContent(ctx, "gama-demo/web-app", "feature/canary", ".github/workflows/deploy.yml")
+	assert.NoError(t, err)
+	assert.Equal(t, canaryDeployWorkflow, string(content))
+
+	_, err = repo.InspectWorkflowContent(ctx, "gama-demo/web-app", "feature/canary", ".github/workflows/release.yml")
+	assert.True(t, gr.IsNotFound(err))
+}
+
+func TestServer_ListWorkflowJobs(t *testing.T) {
+	repo, _ := newTestRepository(t)
+	ctx := context.Background()
+
+	// The failed build of the mobile app skipped the publish job
+	runs, err := repo.ListWorkflowRuns(ctx, "gama-demo/mobile-app", "main")
+	assert.NoError(t, err)
+	failed := runs.WorkflowRuns[slices.IndexFunc(runs.WorkflowRuns, func(run gr.WorkflowRun) bool { return run.Conclusion == "failure" })]
+
+	jobs, err := repo.ListWorkflowJobs(ctx, "gama-demo/mobile-app", failed.ID)
+	assert.NoError(t, err)
+	var conclusions []string
+	for _, job := range jobs {
+		assert.Equal(t, failed.ID, job.RunID, job.Name)
+		conclusions = append(conclusions, job.Name+"="+job.Conclusion)
+	}
+	assert.Equal(t, []string{
+		"build (ios, free)=success",
+		"build (ios, pro)=success",
+		"build (android, free)=success",
+		"build (android, pro)=failure",
+		"publish=skipped",
+	}, conclusions)
+}
+
+func TestServer_ListWorkflowFiles(t *testing.T) {
+	repo, _ := newTestRepository(t)
+
+	files, err := repo.ListWorkflowFiles(context.Background(), "gama-demo/infra", "main")
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{".github/workflows/drift.yml", ".github/workflows/notify.yml", ".github/workflows/terraform.yml"}, files)
+}
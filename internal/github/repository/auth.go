@@ -0,0 +1,257 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	pkgconfig "github.com/termkit/gama/pkg/config"
+)
+
+// TokenSource provides the token that is sent in the Authorization header.
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// staticTokenSource always returns the same token, e.g. a personal access token.
+type staticTokenSource string
+
+func (s staticTokenSource) Token(_ context.Context) (string, error) {
+	return string(s), nil
+}
+
+const (
+	// appJWTLifetime is the lifetime of the JWT used to request installation tokens.
+	// GitHub rejects JWTs that are valid for more than 10 minutes.
+	appJWTLifetime = 9 * time.Minute
+
+	// appJWTClockDrift backdates the JWT to tolerate clock drift between us and GitHub.
+	appJWTClockDrift = 60 * time.Second
+
+	// appTokenRefreshMargin is how long before expiry an installation token is refreshed.
+	appTokenRefreshMargin = 5 * time.Minute
+)
+
+// appTokenSource mints installation tokens for a GitHub App and caches them until
+// they are about to expire.
+type appTokenSource struct {
+	client         HttpClient
+	apiURL         string
+	appID          int64
+	installationID int64
+	privateKeyPath string
+	now            func() time.Time
+
+	mu         sync.Mutex
+	privateKey *rsa.PrivateKey
+	token      string
+	expiresAt  time.Time
+}
+
+func newAppTokenSource(client HttpClient, apiURL string, app pkgconfig.GithubApp) *appTokenSource {
+	return &appTokenSource{
+		client:         client,
+		apiURL:         apiURL,
+		appID:          app.ID,
+		installationID: app.InstallationID,
+		privateKeyPath: app.PrivateKeyPath,
+		now:            time.Now,
+	}
+}
+
+func (s *appTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && s.now().Add(appTokenRefreshMargin).Before(s.expiresAt) {
+		return s.token, nil
+	}
+
+	jwt, err := s.jwtLocked()
+	if err != nil {
+		return "", err
+	}
+
+	token, expiresAt, err := s.exchange(ctx, jwt)
+	if err != nil {
+		return "", err
+	}
+
+	s.token = token
+	s.expiresAt = expiresAt
+	return s.token, nil
+}
+
+// jwtLocked signs a JWT of the app, loading the private key on first use. s.mu must be held.
+func (s *appTokenSource) jwtLocked() (string, error) {
+	if s.privateKey == nil {
+		key, err := loadPrivateKey(s.privateKeyPath)
+		if err != nil {
+			return "", err
+		}
+		s.privateKey = key
+	}
+
+	jwt, err := signAppJWT(s.privateKey, s.appID, s.now())
+	if err != nil {
+		return "", fmt.Errorf("failed to sign github app jwt: %w", err)
+	}
+	return jwt, nil
+}
+
+// App returns the GitHub App the installation tokens act as, with the login of
+// its bot user, e.g. my-app[bot]. Installation tokens cannot read /user, so the
+// app is read from /app with a JWT.
+func (s *appTokenSource) App(ctx context.Context) (*Actor, error) {
+	s.mu.Lock()
+	jwt, err := s.jwtLocked()
+	s.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.apiURL+"/app", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Authorization", "Bearer "+jwt)
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("failed to get github app: %s", resp.Status)
+	}
+
+	var app struct {
+		ID   int64  `json:"id"`
+		Slug string `json:"slug"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&app); err != nil {
+		return nil, err
+	}
+	if app.Slug == "" {
+		return nil, errors.New("failed to get github app: empty slug")
+	}
+
+	return &Actor{Id: app.ID, Login: app.Slug + "[bot]"}, nil
+}
+
+// exchange trades the app JWT for an installation access token.
+func (s *appTokenSource) exchange(ctx context.Context, jwt string) (string, time.Time, error) {
+	reqURL := s.apiURL + "/app/installations/" + strconv.FormatInt(s.installationID, 10) + "/access_tokens"
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, reqURL, bytes.NewBuffer(nil))
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Authorization", "Bearer "+jwt)
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var errorResponse struct {
+			Message string `json:"message"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&errorResponse); err != nil {
+			return "", time.Time{}, fmt.Errorf("failed to get installation token: %s", resp.Status)
+		}
+		return "", time.Time{}, fmt.Errorf("failed to get installation token: %s", errorResponse.Message)
+	}
+
+	var installationToken struct {
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&installationToken); err != nil {
+		return "", time.Time{}, err
+	}
+	if installationToken.Token == "" {
+		return "", time.Time{}, errors.New("failed to get installation token: empty token")
+	}
+
+	return installationToken.Token, installationToken.ExpiresAt, nil
+}
+
+// signAppJWT creates the RS256 signed JWT that authenticates as the GitHub App itself.
+func signAppJWT(key *rsa.PrivateKey, appID int64, now time.Time) (string, error) {
+	header, err := json.Marshal(map[string]string{
+		"alg": "RS256",
+		"typ": "JWT",
+	})
+	if err != nil {
+		return "", err
+	}
+
+	claims, err := json.Marshal(map[string]any{
+		"iat": now.Add(-appJWTClockDrift).Unix(),
+		"exp": now.Add(appJWTLifetime).Unix(),
+		"iss": strconv.FormatInt(appID, 10),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+
+	hashed := sha256.Sum256([]byte(unsigned))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, hashed[:])
+	if err != nil {
+		return "", err
+	}
+
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// loadPrivateKey reads a PEM encoded RSA private key in PKCS#1 or PKCS#8 form.
+func loadPrivateKey(path string) (*rsa.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read github app private key: %w", err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("failed to decode github app private key: no PEM block found")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse github app private key: %w", err)
+	}
+
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("github app private key is not an RSA key")
+	}
+	return key, nil
+}
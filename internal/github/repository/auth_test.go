@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	pkgconfig "github.com/termkit/gama/pkg/config"
+)
+
+type fakeClient func(req *http.Request) (*http.Response, error)
+
+func (f fakeClient) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func jsonResponse(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Status:     http.StatusText(status),
+		Header:     make(http.Header),
+		Body:       io.NopCloser(bytes.NewBufferString(body)),
+	}
+}
+
+func writeTestKey(t *testing.T) string {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyPath := filepath.Join(t.TempDir(), "app.pem")
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	if err := os.WriteFile(keyPath, keyPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return keyPath
+}
+
+func TestAppTokenSource_Token(t *testing.T) {
+	keyPath := writeTestKey(t)
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	var exchanges int
+	client := fakeClient(func(req *http.Request) (*http.Response, error) {
+		exchanges++
+		if req.URL.Path != "/app/installations/42/access_tokens" {
+			t.Errorf("unexpected path %s", req.URL.Path)
+		}
+
+		jwt := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+		parts := strings.Split(jwt, ".")
+		if len(parts) != 3 {
+			t.Fatalf("expected a JWT with 3 parts, got %d", len(parts))
+		}
+		claimsJSON, err := base64.RawURLEncoding.DecodeString(parts[1])
+		if err != nil {
+			t.Fatal(err)
+		}
+		var claims struct {
+			Iss string `json:"iss"`
+			Exp int64  `json:"exp"`
+		}
+		if err := json.Unmarshal(claimsJSON, &claims); err != nil {
+			t.Fatal(err)
+		}
+		if claims.Iss != "7" {
+			t.Errorf("expected issuer 7, got %s", claims.Iss)
+		}
+
+		expiresAt := now.Add(time.Hour).Format(time.RFC3339)
+		return jsonResponse(http.StatusCreated, fmt.Sprintf(`{"token":"ghs_%d","expires_at":%q}`, exchanges, expiresAt)), nil
+	})
+
+	source := newAppTokenSource(client, "https://api.example.com", pkgconfig.GithubApp{
+		ID:             7,
+		InstallationID: 42,
+		PrivateKeyPath: keyPath,
+	})
+	source.now = func() time.Time { return now }
+
+	token, err := source.Token(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != "ghs_1" {
+		t.Errorf("expected ghs_1, got %s", token)
+	}
+
+	// cached while far from expiry
+	token, _ = source.Token(context.Background())
+	if token != "ghs_1" || exchanges != 1 {
+		t.Errorf("expected cached token, got %s after %d exchanges", token, exchanges)
+	}
+
+	// refreshed when close to expiry
+	now = now.Add(56 * time.Minute)
+	token, _ = source.Token(context.Background())
+	if token != "ghs_2" {
+		t.Errorf("expected refreshed token ghs_2, got %s", token)
+	}
+}
+
+func TestAppTokenSource_TokenError(t *testing.T) {
+	keyPath := writeTestKey(t)
+
+	client := fakeClient(func(req *http.Request) (*http.Response, error) {
+		return jsonResponse(http.StatusUnauthorized, `{"message":"A JSON web token could not be decoded"}`), nil
+	})
+
+	source := newAppTokenSource(client, "https://api.example.com", pkgconfig.GithubApp{
+		ID:             7,
+		InstallationID: 42,
+		PrivateKeyPath: keyPath,
+	})
+
+	_, err := source.Token(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "could not be decoded") {
+		t.Errorf("expected token exchange error, got %v", err)
+	}
+}
+
+func TestAppTokenSource_App(t *testing.T) {
+	keyPath := writeTestKey(t)
+
+	client := fakeClient(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Path != "/app" {
+			t.Errorf("unexpected path %s", req.URL.Path)
+		}
+		if !strings.HasPrefix(req.Header.Get("Authorization"), "Bearer ey") {
+			t.Errorf("expected a JWT, got %q", req.Header.Get("Authorization"))
+		}
+		return jsonResponse(http.StatusOK, `{"id":7,"slug":"deploy-bot"}`), nil
+	})
+
+	repo := New(&pkgconfig.Config{Github: pkgconfig.Github{
+		APIURL:           "https://api.example.com",
+		CredentialSource: pkgconfig.CredentialSourceGithubApp,
+		App:              pkgconfig.GithubApp{ID: 7, InstallationID: 42, PrivateKeyPath: keyPath},
+	}}, client, nil)
+
+	user, err := repo.GetAuthenticatedUser(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.Login != "deploy-bot[bot]" || user.Id != 7 {
+		t.Errorf("expected deploy-bot[bot] with ID 7, got %s with ID %d", user.Login, user.Id)
+	}
+
+	// the configured app ID identifies the app when it cannot be read
+	repo.app.client = fakeClient(func(req *http.Request) (*http.Response, error) {
+		return jsonResponse(http.StatusUnauthorized, `{"message":"Bad credentials"}`), nil
+	})
+	user, err = repo.GetAuthenticatedUser(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.Login != "app/7" {
+		t.Errorf("expected app/7, got %s", user.Login)
+	}
+}
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"errors"
+	"net/http"
+)
+
+// APIError is returned when GitHub answers with a non-2xx status.
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return e.Message
+}
+
+// IsNotFound reports whether err is a 404 returned by GitHub.
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
@@ -5,15 +5,26 @@ import (
 )
 
 type Repository interface {
+	CredentialSource() string
+	Profile() string
+	APIURL() string
+	WebURL() string
 	TestConnection(ctx context.Context) error
+	GetAuthenticatedUser(ctx context.Context) (*Actor, error)
 	ListRepositories(ctx context.Context, limit int) ([]GithubRepository, error)
 	GetRepository(ctx context.Context, repository string) (*GithubRepository, error)
 	ListBranches(ctx context.Context, repository string) ([]GithubBranch, error)
 	ListWorkflowRuns(ctx context.Context, repository string, branch string) (*WorkflowRuns, error)
-	TriggerWorkflow(ctx context.Context, repository string, branch string, workflowName string, workflow any) error
+	TriggerWorkflow(ctx context.Context, repository string, branch string, workflowName string, inputs map[string]any) error
+	WorkflowDispatchRequest(repository string, branch string, workflowName string, inputs map[string]any) (*DispatchRequest, error)
+	DispatchRepositoryEvent(ctx context.Context, repository string, eventType string, clientPayload map[string]any) error
 	GetWorkflows(ctx context.Context, repository string) ([]Workflow, error)
-	GetTriggerableWorkflows(ctx context.Context, repository string) ([]Workflow, error)
+	ListEnvironments(ctx context.Context, repository string) ([]Environment, error)
+	GetTriggerableWorkflows(ctx context.Context, repository string, branch string) ([]Workflow, error)
+	ListWorkflowFiles(ctx context.Context, repository string, branch string) ([]string, error)
 	InspectWorkflowContent(ctx context.Context, repository string, branch string, workflowFile string) ([]byte, error)
+	GetWorkflowRun(ctx context.Context, repository string, runId int64) (*WorkflowRun, error)
+	ListWorkflowJobs(ctx context.Context, repository string, runId int64) ([]WorkflowJob, error)
 	GetWorkflowRunLogs(ctx context.Context, repository string, runId int64) (GithubWorkflowRunLogs, error)
 	ReRunFailedJobs(ctx context.Context, repository string, runId int64) error
 	ReRunWorkflow(ctx context.Context, repository string, runId int64) error
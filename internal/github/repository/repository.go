@@ -5,46 +5,89 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
-	"errors"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"path"
+	"slices"
 	"strconv"
-	"time"
 
+	pkgconcurrency "github.com/termkit/gama/pkg/concurrency"
 	pkgconfig "github.com/termkit/gama/pkg/config"
-	"gopkg.in/yaml.v3"
+	py "github.com/termkit/gama/pkg/yaml"
 )
 
 type Repo struct {
 	Client HttpClient
 
-	githubToken string
+	// limiter bounds the fan-out of repository pages and workflow files
+	limiter *pkgconcurrency.Limiter
+
+	profile          string
+	apiURL           string
+	webURL           string
+	tokenSource      TokenSource
+	credentialSource pkgconfig.CredentialSource
+
+	// isInstallation is true when authenticated as a GitHub App installation,
+	// which cannot use the /user endpoints.
+	isInstallation bool
+
+	// app mints the installation tokens, nil unless isInstallation.
+	app   *appTokenSource
+	appID int64
 }
 
-var githubAPIURL = "https://api.github.com"
+func New(cfg *pkgconfig.Config, client HttpClient, limiter *pkgconcurrency.Limiter) *Repo {
+	apiURL := cfg.Github.APIURL
+	if apiURL == "" {
+		apiURL = pkgconfig.DefaultAPIURL
+	}
 
-func New(cfg *pkgconfig.Config) *Repo {
-	return &Repo{
-		Client: &http.Client{
-			Timeout: 20 * time.Second,
-		},
-		githubToken: cfg.Github.Token,
+	repo := &Repo{
+		Client:           client,
+		limiter:          limiter,
+		profile:          cfg.Profile,
+		apiURL:           apiURL,
+		webURL:           cfg.Github.WebURL(),
+		tokenSource:      staticTokenSource(cfg.Github.Token),
+		credentialSource: cfg.Github.CredentialSource,
 	}
+
+	if cfg.Github.CredentialSource == pkgconfig.CredentialSourceGithubApp {
+		repo.app = newAppTokenSource(client, apiURL, cfg.Github.App)
+		repo.appID = cfg.Github.App.ID
+		repo.tokenSource = repo.app
+		repo.isInstallation = true
+	}
+
+	return repo
+}
+
+// CredentialSource describes where the token used by the repository comes from.
+func (r *Repo) CredentialSource() string {
+	return r.credentialSource.String()
+}
+
+// Profile is the name of the config profile the repository was created with.
+func (r *Repo) Profile() string {
+	return r.profile
+}
+
+// APIURL is the base URL of the REST API, e.g. https://api.github.com.
+func (r *Repo) APIURL() string {
+	return r.apiURL
+}
+
+// WebURL is the base URL of the GitHub web interface, e.g. https://github.com.
+func (r *Repo) WebURL() string {
+	return r.webURL
 }
 
 func (r *Repo) TestConnection(ctx context.Context) error {
 	// List repositories for the authenticated user
-	var repositories []GithubRepository
-	err := r.do(ctx, nil, &repositories, requestOptions{
-		method:      http.MethodGet,
-		path:        githubAPIURL + "/user/repos",
-		contentType: "application/json",
-		queryParams: map[string]string{
-			"visibility": "all",
-		},
-	})
+	_, err := r.listRepositoriesPage(ctx, 1, 1)
 	if err != nil {
 		return err
 	}
@@ -52,42 +95,77 @@ func (r *Repo) TestConnection(ctx context.Context) error {
 	return nil
 }
 
+// GetAuthenticatedUser returns the user the token belongs to. For GitHub App
+// installation tokens it is the bot user of the app, or the configured app ID
+// when the app cannot be read.
+func (r *Repo) GetAuthenticatedUser(ctx context.Context) (*Actor, error) {
+	if r.isInstallation {
+		app, err := r.app.App(ctx)
+		if err != nil {
+			slog.Warn("github app cannot be read", "error", err)
+			return &Actor{Id: r.appID, Login: "app/" + strconv.FormatInt(r.appID, 10)}, nil
+		}
+		return app, nil
+	}
+
+	var user Actor
+	err := r.do(ctx, nil, &user, requestOptions{
+		method: http.MethodGet,
+		path:   r.apiURL + "/user",
+		accept: "application/vnd.github+json",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
 func (r *Repo) ListRepositories(ctx context.Context, limit int) ([]GithubRepository, error) {
 	if limit <= 0 || limit > 100 {
 		limit = 100
 	}
 
-	resultsChan := make(chan []GithubRepository)
-	errChan := make(chan error)
-
-	for page := 1; page <= 5; page++ {
-		go r.workerListRepositories(ctx, limit, page, resultsChan, errChan)
+	pages, err := pkgconcurrency.Map(ctx, r.limiter, []int{1, 2, 3, 4, 5}, func(ctx context.Context, page int) ([]GithubRepository, error) {
+		return r.listRepositoriesPage(ctx, limit, page)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	var repositories []GithubRepository
-	var repoErr error
-
-	for range make([]int, 5) {
-		select {
-		case err := <-errChan:
-			repoErr = errors.Join(err)
-		case res := <-resultsChan:
-			repositories = append(repositories, res...)
-		}
-	}
-
-	if repoErr != nil {
-		return nil, repoErr
+	for _, page := range pages {
+		repositories = append(repositories, page...)
 	}
 
 	return repositories, nil
 }
 
-func (r *Repo) workerListRepositories(ctx context.Context, limit int, page int, results chan<- []GithubRepository, errs chan<- error) {
+func (r *Repo) listRepositoriesPage(ctx context.Context, limit int, page int) ([]GithubRepository, error) {
+	if r.isInstallation {
+		// Installation tokens can only list the repositories the app is installed on
+		var installationRepositories struct {
+			Repositories []GithubRepository `json:"repositories"`
+		}
+		err := r.do(ctx, nil, &installationRepositories, requestOptions{
+			method:      http.MethodGet,
+			path:        r.apiURL + "/installation/repositories",
+			contentType: "application/json",
+			queryParams: map[string]string{
+				"per_page": strconv.Itoa(limit),
+				"page":     strconv.Itoa(page),
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		return installationRepositories.Repositories, nil
+	}
+
 	var repositories []GithubRepository
 	err := r.do(ctx, nil, &repositories, requestOptions{
 		method:      http.MethodGet,
-		path:        githubAPIURL + "/user/repos",
+		path:        r.apiURL + "/user/repos",
 		contentType: "application/json",
 		queryParams: map[string]string{
 			"visibility": "all",
@@ -98,11 +176,10 @@ func (r *Repo) workerListRepositories(ctx context.Context, limit int, page int,
 		},
 	})
 	if err != nil {
-		errs <- err
-		return
+		return nil, err
 	}
 
-	results <- repositories
+	return repositories, nil
 }
 
 func (r *Repo) ListBranches(ctx context.Context, repository string) ([]GithubBranch, error) {
@@ -110,7 +187,7 @@ func (r *Repo) ListBranches(ctx context.Context, repository string) ([]GithubBra
 	var branches any
 	err := r.do(ctx, nil, &branches, requestOptions{
 		method:      http.MethodGet,
-		path:        githubAPIURL + "/repos/" + repository + "/branches",
+		path:        r.apiURL + "/repos/" + repository + "/branches",
 		contentType: "application/json",
 	})
 	if err != nil {
@@ -124,7 +201,7 @@ func (r *Repo) GetRepository(ctx context.Context, repository string) (*GithubRep
 	var repo GithubRepository
 	err := r.do(ctx, nil, &repo, requestOptions{
 		method:      http.MethodGet,
-		path:        githubAPIURL + "/repos/" + repository,
+		path:        r.apiURL + "/repos/" + repository,
 		contentType: "application/json",
 	})
 	if err != nil {
@@ -139,7 +216,7 @@ func (r *Repo) ListWorkflowRuns(ctx context.Context, repository string, branch s
 	var workflowRuns WorkflowRuns
 	err := r.do(ctx, nil, &workflowRuns, requestOptions{
 		method:      http.MethodGet,
-		path:        githubAPIURL + "/repos/" + repository + "/actions/runs",
+		path:        r.apiURL + "/repos/" + repository + "/actions/runs",
 		contentType: "application/json",
 		queryParams: map[string]string{
 			"branch": branch,
@@ -152,14 +229,64 @@ func (r *Repo) ListWorkflowRuns(ctx context.Context, repository string, branch s
 	return &workflowRuns, nil
 }
 
-func (r *Repo) TriggerWorkflow(ctx context.Context, repository string, branch string, workflowName string, workflow any) error {
-	var payload = fmt.Sprintf(`{"ref": "%s", "inputs": %s}`, branch, workflow)
+func (r *Repo) TriggerWorkflow(ctx context.Context, repository string, branch string, workflowName string, inputs map[string]any) error {
+	payload, options := r.workflowDispatch(repository, branch, workflowName, inputs)
 
 	// Trigger a workflow for the given repository and branch
+	err := r.do(ctx, payload, nil, options)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// WorkflowDispatchRequest returns the request TriggerWorkflow sends, without sending it.
+func (r *Repo) WorkflowDispatchRequest(repository string, branch string, workflowName string, inputs map[string]any) (*DispatchRequest, error) {
+	payload, options := r.workflowDispatch(repository, branch, workflowName, inputs)
+
+	body, err := json.Marshal(payload) // like do
+	if err != nil {
+		return nil, err
+	}
+
+	return &DispatchRequest{
+		Method:      options.method,
+		URL:         options.path,
+		Accept:      options.accept,
+		ContentType: options.contentType,
+		APIVersion:  apiVersion,
+		Body:        body,
+	}, nil
+}
+
+func (r *Repo) workflowDispatch(repository string, branch string, workflowName string, inputs map[string]any) (DispatchPayload, requestOptions) {
+	payload := DispatchPayload{
+		Ref:    branch,
+		Inputs: inputs,
+	}
+
+	return payload, requestOptions{
+		method:      http.MethodPost,
+		path:        r.apiURL + "/repos/" + repository + "/actions/workflows/" + path.Base(workflowName) + "/dispatches",
+		contentType: "application/json",
+		accept:      "application/vnd.github+json",
+	}
+}
+
+// DispatchRepositoryEvent sends a repository_dispatch event, which runs the
+// workflows of the default branch that listen to the event type.
+func (r *Repo) DispatchRepositoryEvent(ctx context.Context, repository string, eventType string, clientPayload map[string]any) error {
+	payload := RepositoryDispatchPayload{
+		EventType:     eventType,
+		ClientPayload: clientPayload,
+	}
+
 	err := r.do(ctx, payload, nil, requestOptions{
-		method: http.MethodPost,
-		path:   githubAPIURL + "/repos/" + repository + "/actions/workflows/" + path.Base(workflowName) + "/dispatches",
-		accept: "application/vnd.github+json",
+		method:      http.MethodPost,
+		path:        r.apiURL + "/repos/" + repository + "/dispatches",
+		contentType: "application/json",
+		accept:      "application/vnd.github+json",
 	})
 	if err != nil {
 		return err
@@ -173,7 +300,7 @@ func (r *Repo) GetWorkflows(ctx context.Context, repository string) ([]Workflow,
 	var githubWorkflow githubWorkflow
 	err := r.do(ctx, nil, &githubWorkflow, requestOptions{
 		method:      http.MethodGet,
-		path:        githubAPIURL + "/repos/" + repository + "/actions/workflows",
+		path:        r.apiURL + "/repos/" + repository + "/actions/workflows",
 		contentType: "application/json",
 	})
 	if err != nil {
@@ -183,69 +310,110 @@ func (r *Repo) GetWorkflows(ctx context.Context, repository string) ([]Workflow,
 	return githubWorkflow.Workflows, nil
 }
 
-func (r *Repo) GetTriggerableWorkflows(ctx context.Context, repository string) ([]Workflow, error) {
-	// Get a workflow run for the given repository and runId
-	var workflows githubWorkflow
-	err := r.do(ctx, nil, &workflows, requestOptions{
+func (r *Repo) ListEnvironments(ctx context.Context, repository string) ([]Environment, error) {
+	// List deployment environments for the given repository
+	var environments struct {
+		TotalCount   int64         `json:"total_count"`
+		Environments []Environment `json:"environments"`
+	}
+	err := r.do(ctx, nil, &environments, requestOptions{
 		method:      http.MethodGet,
-		path:        githubAPIURL + "/repos/" + repository + "/actions/workflows",
+		path:        r.apiURL + "/repos/" + repository + "/environments",
 		contentType: "application/json",
+		queryParams: map[string]string{
+			"per_page": "100",
+		},
 	})
+	if IsNotFound(err) {
+		return nil, nil // environments are not available for this repository
+	} else if err != nil {
+		return nil, err
+	}
+
+	return environments.Environments, nil
+}
+
+func (r *Repo) GetTriggerableWorkflows(ctx context.Context, repository string, branch string) ([]Workflow, error) {
+	// Registered workflows carry the id, name and urls of the workflow files
+	registered, err := r.GetWorkflows(ctx, repository)
 	if err != nil {
 		return nil, err
 	}
 
-	// Create a buffered channel for results and errors
-	results := make(chan *Workflow, len(workflows.Workflows))
-	errs := make(chan error, len(workflows.Workflows))
+	// The files on the branch decide which workflows are triggerable there
+	files, err := r.ListWorkflowFiles(ctx, repository, branch)
+	if err != nil {
+		return nil, err
+	}
 
 	// Filter workflows to only include those that are dispatchable and manually triggerable
-	for _, workflow := range workflows.Workflows {
-		go r.workerGetTriggerableWorkflows(ctx, repository, workflow, results, errs)
-	}
+	triggerable, err := pkgconcurrency.Map(ctx, r.limiter, files, func(ctx context.Context, file string) (*Workflow, error) {
+		workflow := Workflow{Name: file, Path: file}
+		if idx := slices.IndexFunc(registered, func(w Workflow) bool { return w.Path == file }); idx >= 0 {
+			workflow = registered[idx]
+		}
+		return r.getTriggerableWorkflow(ctx, repository, branch, workflow)
+	})
 
-	// Collect the results and errors
 	var result []Workflow
-	var resultErrs []error
-	for range workflows.Workflows {
-		select {
-		case res := <-results:
-			// append only triggerable (dispatch) workflows
-			if res != nil {
-				result = append(result, *res)
-			}
-		case err := <-errs:
-			resultErrs = append(resultErrs, err)
+	for _, workflow := range triggerable {
+		// append only triggerable (dispatch) workflows
+		if workflow != nil {
+			result = append(result, *workflow)
 		}
 	}
 
-	return result, errors.Join(resultErrs...)
+	return result, err
 }
 
-func (r *Repo) workerGetTriggerableWorkflows(ctx context.Context, repository string, workflow Workflow, results chan<- *Workflow, errs chan<- error) {
+// ListWorkflowFiles returns the paths of the workflow files on the branch, the
+// default branch when branch is empty.
+func (r *Repo) ListWorkflowFiles(ctx context.Context, repository string, branch string) ([]string, error) {
+	var entries []struct {
+		Type string `json:"type"`
+		Path string `json:"path"`
+	}
+	err := r.do(ctx, nil, &entries, requestOptions{
+		method:      http.MethodGet,
+		path:        r.apiURL + "/repos/" + repository + "/contents/" + workflowsDirectory,
+		contentType: "application/json",
+		queryParams: refQuery(branch),
+	})
+	if IsNotFound(err) {
+		return nil, nil // no workflows on this branch
+	} else if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, entry := range entries {
+		if entry.Type == "file" && (path.Ext(entry.Path) == ".yml" || path.Ext(entry.Path) == ".yaml") {
+			files = append(files, entry.Path)
+		}
+	}
+	return files, nil
+}
+
+// getTriggerableWorkflow returns the workflow if it has a workflow_dispatch trigger, nil otherwise.
+func (r *Repo) getTriggerableWorkflow(ctx context.Context, repository string, branch string, workflow Workflow) (*Workflow, error) {
 	// Get the workflow file content
-	fileContent, err := r.getWorkflowFile(ctx, repository, workflow.Path)
+	fileContent, err := r.getWorkflowFile(ctx, repository, workflow.Path, branch)
 	if err != nil {
-		errs <- err
-		return
+		return nil, err
 	}
 
 	// Parse the workflow file content as YAML
-	var wfFile workflowFile
-	err = yaml.Unmarshal([]byte(fileContent), &wfFile)
+	workflowContent, err := py.UnmarshalWorkflowContent([]byte(fileContent))
 	if err != nil {
-		errs <- err
-		return
+		return nil, fmt.Errorf("%s: %w", workflow.Path, err)
 	}
 
-	var dispatchWorkflow *Workflow
-
-	// Check if the workflow file content has a "workflow_dispatch" key
-	if _, ok := wfFile.On["workflow_dispatch"]; ok {
-		dispatchWorkflow = &workflow
+	// Check if the workflow can be triggered manually
+	if workflowContent.On.Has("workflow_dispatch") {
+		return &workflow, nil
 	}
 
-	results <- dispatchWorkflow
+	return nil, nil
 }
 
 func (r *Repo) InspectWorkflowContent(ctx context.Context, repository string, branch string, workflowFile string) ([]byte, error) {
@@ -253,11 +421,9 @@ func (r *Repo) InspectWorkflowContent(ctx context.Context, repository string, br
 	var githubFile githubFile
 	err := r.do(ctx, nil, &githubFile, requestOptions{
 		method:      http.MethodGet,
-		path:        githubAPIURL + "/repos/" + repository + "/contents/" + workflowFile,
+		path:        r.apiURL + "/repos/" + repository + "/contents/" + workflowFile,
 		contentType: "application/vnd.github.VERSION.raw",
-		queryParams: map[string]string{
-			"ref": branch,
-		},
+		queryParams: refQuery(branch),
 	})
 	if err != nil {
 		return nil, err
@@ -272,28 +438,56 @@ func (r *Repo) InspectWorkflowContent(ctx context.Context, repository string, br
 	return decodedContent, nil
 }
 
-//func (r *Repo) GetWorkflowRun(ctx context.Context, repository string, runId int64) (GithubWorkflowRun, error) {
-//	// Get a workflow run for the given repository and runId
-//	var workflowRun GithubWorkflowRun
-//	err := r.do(ctx, nil, &workflowRun, requestOptions{
-//		method:      http.MethodGet,
-//		path:        githubAPIURL + "/repos/" + repository + "/actions/runs/" + strconv.FormatInt(runId, 10),
-//		contentType: "application/json",
-//	})
-//	if err != nil {
-//		return GithubWorkflowRun{}, err
-//	}
-//
-//	return workflowRun, nil
-//}
-
-func (r *Repo) getWorkflowFile(ctx context.Context, repository string, path string) (string, error) {
+func (r *Repo) GetWorkflowRun(ctx context.Context, repository string, runId int64) (*WorkflowRun, error) {
+	// Get a workflow run for the given repository and runId
+	var workflowRun WorkflowRun
+	err := r.do(ctx, nil, &workflowRun, requestOptions{
+		method:      http.MethodGet,
+		path:        r.apiURL + "/repos/" + repository + "/actions/runs/" + strconv.FormatInt(runId, 10),
+		contentType: "application/json",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &workflowRun, nil
+}
+
+func (r *Repo) ListWorkflowJobs(ctx context.Context, repository string, runId int64) ([]WorkflowJob, error) {
+	// List the jobs of the latest attempt of a workflow run, page by page as a
+	// matrix alone can have up to 256 jobs
+	var jobs []WorkflowJob
+	for page := 1; ; page++ {
+		var workflowJobs WorkflowJobs
+		err := r.do(ctx, nil, &workflowJobs, requestOptions{
+			method:      http.MethodGet,
+			path:        r.apiURL + "/repos/" + repository + "/actions/runs/" + strconv.FormatInt(runId, 10) + "/jobs",
+			contentType: "application/json",
+			queryParams: map[string]string{
+				"filter":   "latest",
+				"per_page": "100",
+				"page":     strconv.Itoa(page),
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		jobs = append(jobs, workflowJobs.Jobs...)
+		if len(workflowJobs.Jobs) == 0 || int64(len(jobs)) >= workflowJobs.TotalCount {
+			return jobs, nil
+		}
+	}
+}
+
+func (r *Repo) getWorkflowFile(ctx context.Context, repository string, path string, branch string) (string, error) {
 	// Get the content of the workflow file
 	var githubFile githubFile
 	err := r.do(ctx, nil, &githubFile, requestOptions{
 		method:      http.MethodGet,
-		path:        githubAPIURL + "/repos/" + repository + "/contents/" + path,
+		path:        r.apiURL + "/repos/" + repository + "/contents/" + path,
 		contentType: "application/vnd.github.VERSION.raw",
+		queryParams: refQuery(branch),
 	})
 	if err != nil {
 		return "", err
@@ -313,7 +507,7 @@ func (r *Repo) GetWorkflowRunLogs(ctx context.Context, repository string, runId
 	var workflowRunLogs GithubWorkflowRunLogs
 	err := r.do(ctx, nil, &workflowRunLogs, requestOptions{
 		method:      http.MethodGet,
-		path:        githubAPIURL + "/repos/" + repository + "/actions/runs/" + strconv.FormatInt(runId, 10) + "/logs",
+		path:        r.apiURL + "/repos/" + repository + "/actions/runs/" + strconv.FormatInt(runId, 10) + "/logs",
 		contentType: "application/json",
 	})
 	if err != nil {
@@ -327,7 +521,7 @@ func (r *Repo) ReRunFailedJobs(ctx context.Context, repository string, runId int
 	// Re-run failed jobs for a given workflow run
 	err := r.do(ctx, nil, nil, requestOptions{
 		method:      http.MethodPost,
-		path:        githubAPIURL + "/repos/" + repository + "/actions/runs/" + strconv.FormatInt(runId, 10) + "/rerun-failed-jobs",
+		path:        r.apiURL + "/repos/" + repository + "/actions/runs/" + strconv.FormatInt(runId, 10) + "/rerun-failed-jobs",
 		contentType: "application/json",
 	})
 	if err != nil {
@@ -341,7 +535,7 @@ func (r *Repo) ReRunWorkflow(ctx context.Context, repository string, runId int64
 	// Re-run a given workflow run
 	err := r.do(ctx, nil, nil, requestOptions{
 		method:      http.MethodPost,
-		path:        githubAPIURL + "/repos/" + repository + "/actions/runs/" + strconv.FormatInt(runId, 10) + "/rerun",
+		path:        r.apiURL + "/repos/" + repository + "/actions/runs/" + strconv.FormatInt(runId, 10) + "/rerun",
 		contentType: "application/json",
 	})
 	if err != nil {
@@ -355,7 +549,7 @@ func (r *Repo) CancelWorkflow(ctx context.Context, repository string, runId int6
 	// Cancel a given workflow run
 	err := r.do(ctx, nil, nil, requestOptions{
 		method:      http.MethodPost,
-		path:        githubAPIURL + "/repos/" + repository + "/actions/runs/" + strconv.FormatInt(runId, 10) + "/cancel",
+		path:        r.apiURL + "/repos/" + repository + "/actions/runs/" + strconv.FormatInt(runId, 10) + "/cancel",
 		contentType: "application/json",
 	})
 	if err != nil {
@@ -400,14 +594,19 @@ func (r *Repo) do(ctx context.Context, requestBody any, responseBody any, reques
 		return err
 	}
 
-	if requestOptions.contentType == "" {
+	if requestOptions.contentType != "" {
 		req.Header.Set("Content-Type", requestOptions.contentType)
 	}
-	if requestOptions.accept == "" {
+	if requestOptions.accept != "" {
 		req.Header.Set("Accept", requestOptions.accept)
 	}
-	req.Header.Set("Authorization", "Bearer "+r.githubToken)
-	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	token, err := r.tokenSource.Token(ctx)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("X-GitHub-Api-Version", apiVersion)
 	req = req.WithContext(ctx)
 
 	// Perform the HTTP request using the injected client
@@ -427,7 +626,7 @@ func (r *Repo) do(ctx context.Context, requestBody any, responseBody any, reques
 			return err
 		}
 
-		return errors.New(errorResponse.Message)
+		return &APIError{StatusCode: resp.StatusCode, Message: errorResponse.Message}
 	}
 
 	// Decode the response body
@@ -441,6 +640,14 @@ func (r *Repo) do(ctx context.Context, requestBody any, responseBody any, reques
 	return nil
 }
 
+// refQuery selects a branch in the contents API, GitHub uses the default branch without it.
+func refQuery(branch string) map[string]string {
+	if branch == "" {
+		return nil
+	}
+	return map[string]string{"ref": branch}
+}
+
 type requestOptions struct {
 	method      string
 	path        string
@@ -449,15 +656,16 @@ type requestOptions struct {
 	queryParams map[string]string
 }
 
+const workflowsDirectory = ".github/workflows"
+
+// apiVersion is the REST API version every request asks for.
+const apiVersion = "2022-11-28"
+
 type githubWorkflow struct {
 	TotalCount int64      `json:"total_count"`
 	Workflows  []Workflow `json:"workflows"`
 }
 
-type workflowFile struct {
-	On map[string]interface{} `yaml:"on"`
-}
-
 type githubFile struct {
 	Content string `json:"content"`
 }
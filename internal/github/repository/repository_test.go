@@ -4,9 +4,14 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
+	"io"
+	"net/http"
 	"testing"
 
+	pkgconcurrency "github.com/termkit/gama/pkg/concurrency"
 	pkgconfig "github.com/termkit/gama/pkg/config"
+	pkghttpclient "github.com/termkit/gama/pkg/httpclient"
 )
 
 func newRepo(ctx context.Context) *Repo {
@@ -14,8 +19,17 @@ func newRepo(ctx context.Context) *Repo {
 	if err != nil {
 		panic(err)
 	}
+	cfg, err = cfg.WithProfile(cfg.DefaultProfile)
+	if err != nil {
+		panic(err)
+	}
 
-	repo := New(cfg)
+	client, err := pkghttpclient.New(cfg.Network)
+	if err != nil {
+		panic(err)
+	}
+
+	repo := New(cfg, client, pkgconcurrency.New(cfg.Concurrency))
 	return repo
 }
 
@@ -97,10 +111,133 @@ func TestRepo_GetTriggerableWorkflows(t *testing.T) {
 
 	repo := newRepo(ctx)
 
-	workflows, err := repo.GetTriggerableWorkflows(ctx, "canack/tc")
+	workflows, err := repo.GetTriggerableWorkflows(ctx, "canack/tc", "")
 	if err != nil {
 		t.Error(err)
 	}
 
 	t.Log(workflows)
 }
+
+func TestRepo_TriggerWorkflow(t *testing.T) {
+	var body []byte
+	client := fakeClient(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Path != "/repos/owner/repo/actions/workflows/deploy.yml/dispatches" {
+			t.Errorf("unexpected path %s", req.URL.Path)
+		}
+		if contentType := req.Header.Get("Content-Type"); contentType != "application/json" {
+			t.Errorf("unexpected content type %q", contentType)
+		}
+
+		var err error
+		body, err = io.ReadAll(req.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return jsonResponse(http.StatusNoContent, ""), nil
+	})
+
+	repo := New(&pkgconfig.Config{}, client, nil)
+	err := repo.TriggerWorkflow(context.Background(), "owner/repo", `feat/"quoted"`, ".github/workflows/deploy.yml", map[string]any{
+		"version":  `v1 "beta"\n`,
+		"replicas": json.Number("3"),
+		"dry_run":  true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var payload struct {
+		Ref    string         `json:"ref"`
+		Inputs map[string]any `json:"inputs"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		t.Fatalf("body is not valid JSON: %v: %s", err, body)
+	}
+	if payload.Ref != `feat/"quoted"` {
+		t.Errorf("unexpected ref %q", payload.Ref)
+	}
+	if payload.Inputs["version"] != `v1 "beta"\n` {
+		t.Errorf("unexpected version %v", payload.Inputs["version"])
+	}
+	if payload.Inputs["replicas"] != float64(3) {
+		t.Errorf("replicas should be a number, got %#v", payload.Inputs["replicas"])
+	}
+	if payload.Inputs["dry_run"] != true {
+		t.Errorf("dry_run should be a boolean, got %#v", payload.Inputs["dry_run"])
+	}
+}
+
+func TestRepo_WorkflowDispatchRequest(t *testing.T) {
+	var sent *http.Request
+	var sentBody []byte
+	client := fakeClient(func(req *http.Request) (*http.Response, error) {
+		sent = req
+		var err error
+		sentBody, err = io.ReadAll(req.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return jsonResponse(http.StatusNoContent, ""), nil
+	})
+
+	inputs := map[string]any{"version": "<v1 & v2>", "replicas": json.Number("3")}
+	repo := New(&pkgconfig.Config{}, client, nil)
+	if err := repo.TriggerWorkflow(context.Background(), "owner/repo", "main", ".github/workflows/deploy.yml", inputs); err != nil {
+		t.Fatal(err)
+	}
+
+	// The previewed request is the one TriggerWorkflow sends
+	request, err := repo.WorkflowDispatchRequest("owner/repo", "main", ".github/workflows/deploy.yml", inputs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if request.Method != sent.Method || request.URL != sent.URL.String() {
+		t.Errorf("previewed %s %s, sent %s %s", request.Method, request.URL, sent.Method, sent.URL)
+	}
+	if string(request.Body) != string(sentBody) {
+		t.Errorf("previewed body %s, sent %s", request.Body, sentBody)
+	}
+	for header, value := range map[string]string{
+		"Accept":               request.Accept,
+		"Content-Type":         request.ContentType,
+		"X-GitHub-Api-Version": request.APIVersion,
+	} {
+		if sent.Header.Get(header) != value {
+			t.Errorf("previewed %s %q, sent %q", header, value, sent.Header.Get(header))
+		}
+	}
+}
+
+func TestRepo_ListWorkflowJobsPages(t *testing.T) {
+	var pages []string
+	client := fakeClient(func(req *http.Request) (*http.Response, error) {
+		page := req.URL.Query().Get("page")
+		pages = append(pages, page)
+
+		var jobs []WorkflowJob
+		switch page {
+		case "1", "2":
+			jobs = make([]WorkflowJob, 100)
+		case "3":
+			jobs = make([]WorkflowJob, 56)
+		}
+		body, err := json.Marshal(WorkflowJobs{TotalCount: 256, Jobs: jobs})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return jsonResponse(http.StatusOK, string(body)), nil
+	})
+
+	repo := New(&pkgconfig.Config{}, client, nil)
+	jobs, err := repo.ListWorkflowJobs(context.Background(), "owner/repo", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jobs) != 256 {
+		t.Errorf("expected 256 jobs, got %d", len(jobs))
+	}
+	if len(pages) != 3 || pages[2] != "3" {
+		t.Errorf("expected pages 1 to 3, got %v", pages)
+	}
+}
@@ -70,6 +70,34 @@ type GithubBranch struct {
 	Name string `json:"name"`
 }
 
+// DispatchPayload is the body of a workflow_dispatch request.
+type DispatchPayload struct {
+	Ref    string         `json:"ref"`
+	Inputs map[string]any `json:"inputs,omitempty"`
+}
+
+// DispatchRequest is a workflow_dispatch request as it is sent, the token aside.
+type DispatchRequest struct {
+	Method      string
+	URL         string
+	Accept      string
+	ContentType string
+	APIVersion  string
+	Body        []byte
+}
+
+// RepositoryDispatchPayload is the body of a repository_dispatch request.
+type RepositoryDispatchPayload struct {
+	EventType     string         `json:"event_type"`
+	ClientPayload map[string]any `json:"client_payload,omitempty"`
+}
+
+type Environment struct {
+	ID      int64  `json:"id"`
+	Name    string `json:"name"`
+	HtmlUrl string `json:"html_url"`
+}
+
 type Workflow struct {
 	ID        int64     `json:"id"`
 	Name      string    `json:"name"`
@@ -97,6 +125,7 @@ type WorkflowRun struct {
 	UpdatedAt       time.Time `json:"updated_at"`
 	Conclusion      string    `json:"conclusion"`
 	HeadBranch      string    `json:"head_branch"`
+	HeadSHA         string    `json:"head_sha"`
 
 	RunAttempt    int    `json:"run_attempt"`
 	CheckSuiteURL string `json:"check_suite_url"`
@@ -110,6 +139,22 @@ type WorkflowRun struct {
 	ArtifactsURL  string `json:"artifacts_url"`
 }
 
+type WorkflowJobs struct {
+	TotalCount int64         `json:"total_count"`
+	Jobs       []WorkflowJob `json:"jobs"`
+}
+
+type WorkflowJob struct {
+	ID          int64     `json:"id"`
+	RunID       int64     `json:"run_id"`
+	Name        string    `json:"name"`
+	Status      string    `json:"status"`
+	Conclusion  string    `json:"conclusion"`
+	StartedAt   time.Time `json:"started_at"`
+	CompletedAt time.Time `json:"completed_at"`
+	HTMLURL     string    `json:"html_url"`
+}
+
 type Actor struct {
 	Id        int64  `json:"id"`
 	Login     string `json:"login"`
@@ -0,0 +1,108 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	gr "github.com/termkit/gama/internal/github/repository"
+	pkgaudit "github.com/termkit/gama/pkg/audit"
+)
+
+// unknownLogin is recorded when the authenticated user cannot be fetched.
+const unknownLogin = "unknown"
+
+// auditor records the mutating calls of the use case. Every method that
+// changes something on GitHub must record itself through it.
+type auditor struct {
+	log              *pkgaudit.Log
+	githubRepository gr.Repository
+	host             string
+	now              func() time.Time
+
+	mu    sync.Mutex
+	login string // empty until fetched
+}
+
+func newAuditor(log *pkgaudit.Log, githubRepository gr.Repository) *auditor {
+	if log == nil {
+		return nil
+	}
+
+	host, _ := os.Hostname()
+	return &auditor{
+		log:              log,
+		githubRepository: githubRepository,
+		host:             host,
+		now:              time.Now,
+	}
+}
+
+// record appends the entry with the result of err. Failing to write the log
+// is not an error of the action, which has already happened.
+func (a *auditor) record(ctx context.Context, entry pkgaudit.Entry, err error) {
+	if a == nil {
+		return
+	}
+
+	entry.Time = a.now().UTC()
+	entry.Login = a.authenticatedLogin(ctx)
+	entry.Host = a.host
+	entry.Profile = a.githubRepository.Profile()
+	entry.APIURL = a.githubRepository.APIURL()
+	entry.Result = pkgaudit.ResultSuccess
+	if err != nil {
+		entry.Result = pkgaudit.ResultFailure
+		entry.Error = err.Error()
+	}
+
+	if err := a.log.Append(entry); err != nil {
+		slog.Warn("audit entry cannot be written", "action", entry.Action, "error", err)
+	}
+}
+
+// recordRun records an action on a workflow run, with the branch and workflow of the run.
+func (a *auditor) recordRun(ctx context.Context, action string, repository string, runID int64, err error) {
+	if a == nil {
+		return
+	}
+
+	entry := pkgaudit.Entry{
+		Action:     action,
+		Repository: repository,
+		RunID:      runID,
+	}
+	if run, runErr := a.githubRepository.GetWorkflowRun(ctx, repository, runID); runErr == nil {
+		entry.Ref = run.HeadBranch
+		entry.Workflow = run.Path
+	}
+
+	a.record(ctx, entry, err)
+}
+
+// authenticatedLogin returns the login the actions are done as. It is fetched
+// once, a failure included, and without holding the lock so that concurrent
+// actions are not serialised behind the request.
+// authenticatedLogin returns the login of the authenticated user, fetched
+// once. A failed fetch records unknownLogin for the entry and is tried again
+// by the next one.
+func (a *auditor) authenticatedLogin(ctx context.Context) string {
+	a.mu.Lock()
+	login := a.login
+	a.mu.Unlock()
+	if login != "" {
+		return login
+	}
+
+	user, err := a.githubRepository.GetAuthenticatedUser(ctx)
+	if err != nil || user.Login == "" {
+		return unknownLogin
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.login = user.Login
+	return a.login
+}
@@ -0,0 +1,108 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	gr "github.com/termkit/gama/internal/github/repository"
+	pkgaudit "github.com/termkit/gama/pkg/audit"
+)
+
+func TestUseCase_AuditLog(t *testing.T) {
+	repo := newStubRepository(map[string]string{
+		".github/workflows/nightly.yml": dispatchWorkflow,
+		".github/workflows/ci.yml":      pushWorkflow,
+	})
+	repo.user = &gr.Actor{Login: "octocat"}
+	repo.runs = map[string][]gr.WorkflowRun{"owner/repo": {
+		{ID: 7, HeadBranch: "release", Path: ".github/workflows/nightly.yml", Status: "in_progress"},
+	}}
+	ctx := context.Background()
+
+	auditLog := newAuditLog(t)
+	githubUseCase := New(repo, nil, auditLog)
+
+	_, err := githubUseCase.TriggerWorkflow(ctx, TriggerWorkflowInput{
+		Repository:   "owner/repo",
+		Branch:       "main",
+		WorkflowFile: ".github/workflows/nightly.yml",
+		Inputs:       map[string]any{"full": true},
+	})
+	assert.NoError(t, err)
+
+	_, err = githubUseCase.TriggerWorkflow(ctx, TriggerWorkflowInput{
+		Repository:   "owner/repo",
+		Branch:       "main",
+		WorkflowFile: ".github/workflows/ci.yml",
+	})
+	assert.Error(t, err)
+
+	_, err = githubUseCase.CancelWorkflow(ctx, CancelWorkflowInput{Repository: "owner/repo", WorkflowID: 7})
+	assert.NoError(t, err)
+
+	entries, err := auditLog.Read()
+	assert.NoError(t, err)
+	assert.Len(t, entries, 3)
+
+	assert.Equal(t, pkgaudit.ActionTriggerWorkflow, entries[0].Action)
+	assert.Equal(t, "octocat", entries[0].Login)
+	assert.Equal(t, "default", entries[0].Profile)
+	assert.Equal(t, "https://api.github.com", entries[0].APIURL)
+	assert.Equal(t, "main", entries[0].Ref)
+	assert.Equal(t, ".github/workflows/nightly.yml", entries[0].Workflow)
+	assert.Equal(t, map[string]any{"full": true}, entries[0].Inputs)
+	assert.Equal(t, pkgaudit.ResultSuccess, entries[0].Result)
+
+	assert.Equal(t, pkgaudit.ResultFailure, entries[1].Result)
+	assert.Contains(t, entries[1].Error, "no workflow_dispatch trigger")
+
+	// Run actions are recorded with the branch and workflow of the run
+	assert.Equal(t, pkgaudit.ActionCancelWorkflow, entries[2].Action)
+	assert.Equal(t, int64(7), entries[2].RunID)
+	assert.Equal(t, "release", entries[2].Ref)
+	assert.Equal(t, ".github/workflows/nightly.yml", entries[2].Workflow)
+	assert.Equal(t, []string{"owner/repo@main:.github/workflows/nightly.yml"}, repo.triggered)
+}
+
+func TestUseCase_AuditLogin(t *testing.T) {
+	repo := newStubRepository(map[string]string{".github/workflows/run.yml": dispatchWorkflow})
+	auditLog := newAuditLog(t)
+	githubUseCase := New(repo, nil, auditLog)
+
+	trigger := func() {
+		_, err := githubUseCase.TriggerWorkflow(context.Background(), TriggerWorkflowInput{
+			Repository:   "owner/repo",
+			Branch:       "main",
+			WorkflowFile: ".github/workflows/run.yml",
+		})
+		assert.NoError(t, err)
+	}
+
+	// A failure to fetch the login is recorded for the entry only, concurrent actions included
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			trigger()
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 4, repo.userCalls)
+
+	// The next entry fetches the login again, which is then kept
+	repo.user = &gr.Actor{Login: "octocat"}
+	trigger()
+	trigger()
+	assert.Equal(t, 5, repo.userCalls)
+
+	entries, err := auditLog.Read()
+	assert.NoError(t, err)
+	var logins []string
+	for _, entry := range entries {
+		logins = append(logins, entry.Login)
+	}
+	assert.Equal(t, []string{unknownLogin, unknownLogin, unknownLogin, unknownLogin, "octocat", "octocat"}, logins)
+}
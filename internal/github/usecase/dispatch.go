@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	// maxEventTypeLength is the longest event_type GitHub accepts.
+	maxEventTypeLength = 100
+
+	// maxClientPayloadProperties is the number of top-level client_payload properties GitHub accepts.
+	maxClientPayloadProperties = 10
+)
+
+// ParseClientPayload parses the client_payload of a repository_dispatch event,
+// which must be a JSON object. An empty payload is sent without client_payload.
+func ParseClientPayload(data string) (map[string]any, error) {
+	if strings.TrimSpace(data) == "" {
+		return nil, nil
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(data))
+	decoder.UseNumber()
+
+	var payload any
+	if err := decoder.Decode(&payload); err != nil {
+		return nil, fmt.Errorf("client payload is not valid JSON: %w", err)
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, errors.New("client payload must contain a single JSON object")
+	}
+
+	object, ok := payload.(map[string]any)
+	if !ok {
+		return nil, errors.New("client payload must be a JSON object")
+	}
+	if len(object) > maxClientPayloadProperties {
+		return nil, fmt.Errorf("client payload can have at most %d top-level properties, it has %d", maxClientPayloadProperties, len(object))
+	}
+	return object, nil
+}
+
+func validateRepositoryDispatch(eventType string, clientPayload map[string]any) error {
+	if strings.TrimSpace(eventType) == "" {
+		return errors.New("event type cannot be empty")
+	}
+	if len(eventType) > maxEventTypeLength {
+		return fmt.Errorf("event type can be at most %d characters", maxEventTypeLength)
+	}
+	if len(clientPayload) > maxClientPayloadProperties {
+		return fmt.Errorf("client payload can have at most %d top-level properties, it has %d", maxClientPayloadProperties, len(clientPayload))
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	pkgaudit "github.com/termkit/gama/pkg/audit"
+)
+
+func TestParseClientPayload(t *testing.T) {
+	payload, err := ParseClientPayload(`{"stack": "network", "replicas": 3, "nested": {"dry": true}}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]any{
+		"stack":    "network",
+		"replicas": json.Number("3"),
+		"nested":   map[string]any{"dry": true},
+	}
+	if !reflect.DeepEqual(payload, want) {
+		t.Errorf("payload = %v, want %v", payload, want)
+	}
+
+	payload, err = ParseClientPayload("  ")
+	if err != nil || payload != nil {
+		t.Errorf("empty payload = %v, %v, want nil", payload, err)
+	}
+
+	for _, data := range []string{
+		`{"a": 1`,
+		`["a"]`,
+		`{"a": 1} {"b": 2}`,
+		`{"1":1,"2":2,"3":3,"4":4,"5":5,"6":6,"7":7,"8":8,"9":9,"10":10,"11":11}`,
+	} {
+		if _, err := ParseClientPayload(data); err == nil {
+			t.Errorf("ParseClientPayload(%q) succeeded, want an error", data)
+		}
+	}
+}
+
+func TestUseCase_RepositoryDispatch(t *testing.T) {
+	repo := newStubRepository(map[string]string{
+		".github/workflows/terraform.yml": "on:\n  repository_dispatch:\n    types: [terraform-plan, terraform-apply]\njobs: {}\n",
+		".github/workflows/drift.yml":     "on: [repository_dispatch, schedule]\njobs: {}\n",
+		".github/workflows/ci.yml":        pushWorkflow,
+	})
+	ctx := context.Background()
+
+	auditLog := newAuditLog(t)
+	githubUseCase := New(repo, nil, auditLog)
+
+	// Workflows listening to any event are grouped under the empty event type
+	events, err := githubUseCase.GetRepositoryDispatchEvents(ctx, GetRepositoryDispatchEventsInput{Repository: "owner/repo"})
+	assert.NoError(t, err)
+	assert.Equal(t, []RepositoryDispatchEvent{
+		{EventType: "", Workflows: []string{".github/workflows/drift.yml"}},
+		{EventType: "terraform-apply", Workflows: []string{".github/workflows/terraform.yml"}},
+		{EventType: "terraform-plan", Workflows: []string{".github/workflows/terraform.yml"}},
+	}, events.Events)
+
+	_, err = githubUseCase.DispatchRepositoryEvent(ctx, DispatchRepositoryEventInput{
+		Repository:    "owner/repo",
+		EventType:     "terraform-plan",
+		ClientPayload: map[string]any{"stack": "network"},
+	})
+	assert.NoError(t, err)
+
+	// An invalid event is not sent, but recorded
+	_, err = githubUseCase.DispatchRepositoryEvent(ctx, DispatchRepositoryEventInput{Repository: "owner/repo"})
+	assert.Error(t, err)
+	assert.Equal(t, []string{"owner/repo:terraform-plan"}, repo.dispatched)
+
+	entries, err := auditLog.Read()
+	assert.NoError(t, err)
+	if assert.Len(t, entries, 2) {
+		assert.Equal(t, pkgaudit.ActionRepositoryDispatch, entries[0].Action)
+		assert.Equal(t, "terraform-plan", entries[0].EventType)
+		assert.Equal(t, map[string]any{"stack": "network"}, entries[0].Inputs)
+		assert.Equal(t, pkgaudit.ResultSuccess, entries[0].Result)
+
+		assert.Equal(t, pkgaudit.ResultFailure, entries[1].Result)
+		assert.Contains(t, entries[1].Error, "event type")
+	}
+}
@@ -0,0 +1,171 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	gr "github.com/termkit/gama/internal/github/repository"
+	pkgconcurrency "github.com/termkit/gama/pkg/concurrency"
+)
+
+// GitHub doesn't return the run created by a dispatch, it is looked up in the
+// runs of the branch until it shows up.
+var (
+	runLookupAttempts = 5
+	runLookupInterval = 2 * time.Second
+)
+
+func (u useCase) ListWorkflowRepositories(ctx context.Context, input ListWorkflowRepositoriesInput) (*ListWorkflowRepositoriesOutput, error) {
+	repositories, err := u.githubRepository.ListRepositories(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	type match struct {
+		repository GithubRepository
+		found      bool
+	}
+	matches, err := pkgconcurrency.Map(ctx, u.limiter, repositories, func(ctx context.Context, repository gr.GithubRepository) (match, error) {
+		workflows, err := u.githubRepository.GetWorkflows(ctx, repository.FullName)
+		if err != nil {
+			return match{}, err
+		}
+
+		found := slices.ContainsFunc(workflows, func(workflow gr.Workflow) bool {
+			return workflow.Path == input.WorkflowFile
+		})
+		return match{
+			repository: GithubRepository{
+				Name:          repository.FullName,
+				Stars:         repository.StargazersCount,
+				Private:       repository.Private,
+				DefaultBranch: repository.DefaultBranch,
+				LastUpdated:   repository.UpdatedAt,
+			},
+			found: found,
+		}, nil
+	})
+
+	var result []GithubRepository
+	for _, m := range matches {
+		if m.found {
+			result = append(result, m.repository)
+		}
+	}
+	slices.SortFunc(result, func(a, b GithubRepository) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return &ListWorkflowRepositoriesOutput{Repositories: result}, err
+}
+
+// FanOutWorkflow triggers the workflow on every target at once, each dispatch is
+// recorded like TriggerWorkflow. onResult is called as soon as a target is done,
+// by one target at a time. The returned error joins the errors of failed targets.
+func (u useCase) FanOutWorkflow(ctx context.Context, input FanOutWorkflowInput, onResult func(FanOutResult)) (*FanOutWorkflowOutput, error) {
+	results := make([]FanOutResult, len(input.Targets))
+
+	var mu sync.Mutex
+	ctxErr := pkgconcurrency.ForEach(ctx, u.limiter, input.Targets, func(ctx context.Context, i int, target DispatchTarget) {
+		result := u.dispatchTarget(ctx, input, target)
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		results[i] = result
+		if onResult != nil {
+			onResult(result)
+		}
+	})
+
+	var errs []error
+	for _, result := range results {
+		if result.Err != nil {
+			errs = append(errs, result.Err)
+		}
+	}
+
+	return &FanOutWorkflowOutput{Results: results}, errors.Join(append(errs, ctxErr)...)
+}
+
+func (u useCase) dispatchTarget(ctx context.Context, input FanOutWorkflowInput, target DispatchTarget) FanOutResult {
+	result := FanOutResult{Target: target}
+
+	// Runs of the workflow created after this one are the candidates for the dispatched run
+	lastRunID, lookupErr := u.lastWorkflowRunID(ctx, target, input.WorkflowFile)
+
+	_, result.Err = u.TriggerWorkflow(ctx, TriggerWorkflowInput{
+		Repository:   target.Repository,
+		Branch:       target.Branch,
+		WorkflowFile: input.WorkflowFile,
+		Inputs:       input.Inputs,
+	})
+	if result.Err != nil {
+		result.Err = fmt.Errorf("%s@%s: %w", target.Repository, target.Branch, result.Err)
+		return result
+	}
+	if lookupErr != nil {
+		return result
+	}
+
+	for attempt := 0; attempt < runLookupAttempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return result
+			case <-time.After(runLookupInterval):
+			}
+		}
+
+		run, err := u.findDispatchedRun(ctx, target, input.WorkflowFile, lastRunID)
+		if err != nil {
+			return result
+		}
+		if run != nil {
+			result.RunID = run.ID
+			result.RunURL = run.HTMLURL
+			return result
+		}
+	}
+
+	return result
+}
+
+func (u useCase) lastWorkflowRunID(ctx context.Context, target DispatchTarget, workflowFile string) (int64, error) {
+	workflowRuns, err := u.githubRepository.ListWorkflowRuns(ctx, target.Repository, target.Branch)
+	if err != nil {
+		return 0, err
+	}
+
+	var lastRunID int64
+	for _, run := range workflowRuns.WorkflowRuns {
+		if run.Path == workflowFile {
+			lastRunID = max(lastRunID, run.ID)
+		}
+	}
+	return lastRunID, nil
+}
+
+// findDispatchedRun returns the first workflow_dispatch run of the workflow after lastRunID, nil if there is none yet.
+func (u useCase) findDispatchedRun(ctx context.Context, target DispatchTarget, workflowFile string, lastRunID int64) (*gr.WorkflowRun, error) {
+	workflowRuns, err := u.githubRepository.ListWorkflowRuns(ctx, target.Repository, target.Branch)
+	if err != nil {
+		return nil, err
+	}
+
+	var dispatched *gr.WorkflowRun
+	for i, run := range workflowRuns.WorkflowRuns {
+		if run.Path != workflowFile || run.Event != "workflow_dispatch" || run.ID <= lastRunID {
+			continue
+		}
+		if dispatched == nil || run.ID < dispatched.ID {
+			dispatched = &workflowRuns.WorkflowRuns[i]
+		}
+	}
+	return dispatched, nil
+}
@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	gr "github.com/termkit/gama/internal/github/repository"
+	pkgconcurrency "github.com/termkit/gama/pkg/concurrency"
+	pkgconfig "github.com/termkit/gama/pkg/config"
+)
+
+func TestUseCase_FanOutWorkflow(t *testing.T) {
+	lookupInterval := runLookupInterval
+	runLookupInterval = 0
+	defer func() { runLookupInterval = lookupInterval }()
+
+	repo := &stubRepository{
+		repositories: []gr.GithubRepository{
+			{FullName: "owner/web", DefaultBranch: "main"},
+			{FullName: "owner/docs", DefaultBranch: "main"},
+			{FullName: "owner/api", DefaultBranch: "develop"},
+		},
+		files: map[string]map[string]string{
+			"owner/web":  {".github/workflows/release.yml": dispatchWorkflow},
+			"owner/api":  {".github/workflows/release.yml": dispatchWorkflow},
+			"owner/docs": {".github/workflows/ci.yml": pushWorkflow},
+		},
+		runs: map[string][]gr.WorkflowRun{"owner/web": {
+			{ID: 5, HeadBranch: "main", Path: ".github/workflows/release.yml", Event: "workflow_dispatch", Status: "completed"},
+		}},
+		runDelay: 2, // the dispatched runs are missed by the first two lookups
+	}
+	ctx := context.Background()
+
+	auditLog := newAuditLog(t)
+	githubUseCase := New(repo, pkgconcurrency.New(pkgconfig.Concurrency{Workers: 2}), auditLog)
+
+	repositories, err := githubUseCase.ListWorkflowRepositories(ctx, ListWorkflowRepositoriesInput{WorkflowFile: ".github/workflows/release.yml"})
+	assert.NoError(t, err)
+	var names []string
+	for _, repository := range repositories.Repositories {
+		names = append(names, repository.Name)
+	}
+	assert.Equal(t, []string{"owner/api", "owner/web"}, names)
+
+	targets := []DispatchTarget{
+		{Repository: "owner/web", Branch: "main"},
+		{Repository: "owner/api", Branch: "develop"},
+		{Repository: "owner/docs", Branch: "main"},
+	}
+	var reported int
+	output, err := githubUseCase.FanOutWorkflow(ctx, FanOutWorkflowInput{
+		WorkflowFile: ".github/workflows/release.yml",
+		Targets:      targets,
+	}, func(FanOutResult) { reported++ })
+	assert.Error(t, err)
+	assert.Equal(t, 3, reported)
+
+	if assert.Len(t, output.Results, 3) {
+		for i, result := range output.Results {
+			assert.Equal(t, targets[i], result.Target)
+		}
+
+		// The created runs are found, not the run dispatched before
+		for _, result := range output.Results[:2] {
+			assert.NoError(t, result.Err)
+			run, err := repo.GetWorkflowRun(ctx, result.Target.Repository, result.RunID)
+			if assert.NoError(t, err) {
+				assert.Greater(t, run.ID, int64(100))
+				assert.Equal(t, result.Target.Branch, run.HeadBranch)
+				assert.Equal(t, run.HTMLURL, result.RunURL)
+			}
+		}
+
+		assert.ErrorContains(t, output.Results[2].Err, "owner/docs@main")
+		assert.Zero(t, output.Results[2].RunID)
+	}
+
+	entries, err := auditLog.Read()
+	assert.NoError(t, err)
+	assert.Len(t, entries, 3)
+}
@@ -0,0 +1,98 @@
+package usecase
+
+import (
+	"context"
+	"slices"
+
+	gr "github.com/termkit/gama/internal/github/repository"
+	pw "github.com/termkit/gama/pkg/workflow"
+)
+
+// conclusions from the worst to the best, the worst conclusion of the jobs of
+// a matrix is the conclusion of the job of the graph
+var conclusions = []string{"failure", "timed_out", "startup_failure", "cancelled", "action_required", "neutral", "stale", "skipped", "success"}
+
+func (u useCase) GetWorkflowGraph(ctx context.Context, input GetWorkflowGraphInput) (*GetWorkflowGraphOutput, error) {
+	var output GetWorkflowGraphOutput
+	var content []byte
+	var err error
+
+	if input.RunID != 0 {
+		var run *gr.WorkflowRun
+		run, content, err = u.runWorkflowContent(ctx, input.Repository, input.RunID)
+		if err != nil {
+			return nil, err
+		}
+		output.RunStatus = run.Status
+		output.RunConclusion = run.Conclusion
+	} else {
+		content, err = u.githubRepository.InspectWorkflowContent(ctx, input.Repository, input.Branch, input.WorkflowFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	output.Graph, err = pw.NewGraph(content)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.RunID == 0 {
+		return &output, nil
+	}
+
+	jobs, err := u.githubRepository.ListWorkflowJobs(ctx, input.Repository, input.RunID)
+	if err != nil {
+		return nil, err
+	}
+
+	output.Jobs = make(map[string]JobStatus)
+	for _, job := range jobs {
+		graphJob := output.Graph.JobOfRun(job.Name)
+		if graphJob == nil {
+			continue // e.g. a job whose name is an expression
+		}
+		output.Jobs[graphJob.ID] = combineJobStatus(output.Jobs[graphJob.ID], job.Status, job.Conclusion)
+	}
+
+	return &output, nil
+}
+
+// runWorkflowContent returns a run with its workflow file as it was when the run started.
+func (u useCase) runWorkflowContent(ctx context.Context, repository string, runID int64) (*gr.WorkflowRun, []byte, error) {
+	run, err := u.githubRepository.GetWorkflowRun(ctx, repository, runID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ref := run.HeadSHA
+	if ref == "" {
+		ref = run.HeadBranch
+	}
+	content, err := u.githubRepository.InspectWorkflowContent(ctx, repository, ref, run.Path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return run, content, nil
+}
+
+// combineJobStatus adds a job of the run to the status of its job of the graph.
+func combineJobStatus(status JobStatus, jobStatus string, jobConclusion string) JobStatus {
+	status.Jobs++
+	if jobStatus == "completed" {
+		status.Completed++
+		if status.Conclusion == "" || slices.Index(conclusions, jobConclusion) < slices.Index(conclusions, status.Conclusion) {
+			status.Conclusion = jobConclusion
+		}
+	}
+
+	switch {
+	case status.Completed == status.Jobs:
+		status.Status = "completed"
+	case jobStatus == "in_progress" || status.Completed > 0:
+		status.Status = "in_progress" // some jobs of the matrix are done
+	case status.Status == "" || status.Status == "completed":
+		status.Status = jobStatus
+	}
+	return status
+}
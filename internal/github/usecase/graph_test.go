@@ -0,0 +1,91 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	gr "github.com/termkit/gama/internal/github/repository"
+)
+
+const buildWorkflow = `name: Build
+on: workflow_dispatch
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    strategy:
+      matrix:
+        os: [linux, mac]
+  publish:
+    needs: build
+    runs-on: ubuntu-latest
+  deploy:
+    needs: publish
+    runs-on: ubuntu-latest
+    environment: production
+`
+
+func TestUseCase_GetWorkflowGraph(t *testing.T) {
+	repo := newStubRepository(map[string]string{".github/workflows/build.yml": buildWorkflow})
+	repo.runs = map[string][]gr.WorkflowRun{"owner/repo": {
+		{ID: 1, Status: "completed", Conclusion: "failure", HeadSHA: "abc", Path: ".github/workflows/build.yml"},
+		{ID: 2, Status: "in_progress", HeadBranch: "main", Path: ".github/workflows/build.yml"},
+		{ID: 3, Status: "waiting", HeadBranch: "main", Path: ".github/workflows/build.yml"},
+	}}
+	repo.jobs = map[int64][]gr.WorkflowJob{
+		1: {
+			{Name: "build (linux)", Status: "completed", Conclusion: "success"},
+			{Name: "build (mac)", Status: "completed", Conclusion: "failure"},
+			{Name: "publish", Status: "completed", Conclusion: "skipped"},
+			{Name: "deploy", Status: "completed", Conclusion: "skipped"},
+		},
+		2: {
+			{Name: "build (linux)", Status: "completed", Conclusion: "success"},
+			{Name: "build (mac)", Status: "in_progress"},
+		},
+		3: {
+			{Name: "build (linux)", Status: "completed", Conclusion: "success"},
+			{Name: "build (mac)", Status: "completed", Conclusion: "success"},
+			{Name: "publish", Status: "completed", Conclusion: "success"},
+			{Name: "deploy", Status: "waiting"},
+		},
+	}
+	githubUseCase := New(repo, nil, nil)
+	ctx := context.Background()
+
+	output, err := githubUseCase.GetWorkflowGraph(ctx, GetWorkflowGraphInput{
+		Repository:   "owner/repo",
+		Branch:       "main",
+		WorkflowFile: ".github/workflows/build.yml",
+	})
+	assert.NoError(t, err)
+	assert.Len(t, output.Graph.Layers, 3)
+	assert.Empty(t, output.RunStatus)
+	assert.Nil(t, output.Jobs)
+
+	// The worst conclusion of the matrix is the conclusion of its job
+	output, err = githubUseCase.GetWorkflowGraph(ctx, GetWorkflowGraphInput{Repository: "owner/repo", RunID: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, "completed", output.RunStatus)
+	assert.Equal(t, "failure", output.RunConclusion)
+	assert.Equal(t, map[string]JobStatus{
+		"build":   {Status: "completed", Conclusion: "failure", Jobs: 2, Completed: 2},
+		"publish": {Status: "completed", Conclusion: "skipped", Jobs: 1, Completed: 1},
+		"deploy":  {Status: "completed", Conclusion: "skipped", Jobs: 1, Completed: 1},
+	}, output.Jobs)
+
+	// A matrix with some jobs done is in progress, the jobs that didn't start are missing
+	output, err = githubUseCase.GetWorkflowGraph(ctx, GetWorkflowGraphInput{Repository: "owner/repo", RunID: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]JobStatus{
+		"build": {Status: "in_progress", Conclusion: "success", Jobs: 2, Completed: 1},
+	}, output.Jobs)
+
+	// The deployment waits for an approval of the production environment
+	output, err = githubUseCase.GetWorkflowGraph(ctx, GetWorkflowGraphInput{Repository: "owner/repo", RunID: 3})
+	assert.NoError(t, err)
+	assert.Equal(t, JobStatus{Status: "waiting", Jobs: 1}, output.Jobs["deploy"])
+
+	_, err = githubUseCase.GetWorkflowGraph(ctx, GetWorkflowGraphInput{Repository: "owner/repo", RunID: 4})
+	assert.True(t, gr.IsNotFound(err))
+}
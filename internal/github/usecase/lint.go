@@ -0,0 +1,53 @@
+package usecase
+
+import (
+	"context"
+
+	pkgconcurrency "github.com/termkit/gama/pkg/concurrency"
+	pw "github.com/termkit/gama/pkg/workflow"
+)
+
+func (u useCase) LintWorkflows(ctx context.Context, input LintWorkflowsInput) (*LintWorkflowsOutput, error) {
+	results, err := u.checkWorkflows(ctx, input.Repository, input.Branch, input.WorkflowFiles, func(content []byte) ([]pw.Finding, error) {
+		return pw.Lint(content), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &LintWorkflowsOutput{
+		Results: results,
+	}, nil
+}
+
+func (u useCase) GetSecurityReport(ctx context.Context, input GetSecurityReportInput) (*GetSecurityReportOutput, error) {
+	files, err := u.githubRepository.ListWorkflowFiles(ctx, input.Repository, input.Branch)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := u.checkWorkflows(ctx, input.Repository, input.Branch, files, pw.CheckSecurity)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetSecurityReportOutput{
+		Workflows: results,
+	}, nil
+}
+
+// checkWorkflows fetches the workflow files and checks their content, in the order of the files.
+func (u useCase) checkWorkflows(ctx context.Context, repository string, branch string, files []string, check func(content []byte) ([]pw.Finding, error)) ([]WorkflowFindings, error) {
+	return pkgconcurrency.Map(ctx, u.limiter, files, func(ctx context.Context, file string) (WorkflowFindings, error) {
+		content, err := u.githubRepository.InspectWorkflowContent(ctx, repository, branch, file)
+		if ctx.Err() != nil {
+			return WorkflowFindings{}, ctx.Err()
+		} else if err != nil {
+			// A file that cannot be fetched doesn't keep the others from being checked
+			return WorkflowFindings{WorkflowFile: file, Err: err}, nil
+		}
+
+		findings, err := check(content)
+		return WorkflowFindings{WorkflowFile: file, Findings: findings, Err: err}, nil
+	})
+}
@@ -0,0 +1,94 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	gr "github.com/termkit/gama/internal/github/repository"
+	pw "github.com/termkit/gama/pkg/workflow"
+)
+
+const brokenWorkflow = `on: workflow_dispatch
+jobs:
+  deploy:
+    runs-on: ubuntu-latest
+    needs: smoke-test
+    steps:
+      - run: ./deploy.sh --version ${{ inputs.version }
+`
+
+func TestUseCase_LintWorkflows(t *testing.T) {
+	githubUseCase := New(newStubRepository(map[string]string{
+		".github/workflows/deploy.yml": brokenWorkflow,
+		".github/workflows/ci.yml":     pushWorkflow,
+	}), nil, nil)
+
+	output, err := githubUseCase.LintWorkflows(context.Background(), LintWorkflowsInput{
+		Repository:    "owner/repo",
+		Branch:        "main",
+		WorkflowFiles: []string{".github/workflows/deploy.yml", ".github/workflows/ci.yml", ".github/workflows/release.yml"},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, output.Results, 3)
+
+	deploy := output.Results[0]
+	assert.Equal(t, ".github/workflows/deploy.yml", deploy.WorkflowFile)
+	assert.NoError(t, deploy.Err)
+	var rules []string
+	for _, finding := range deploy.Findings {
+		rules = append(rules, finding.Rule)
+	}
+	assert.Equal(t, []string{pw.RuleNeeds, pw.RuleExpression}, rules)
+
+	assert.NoError(t, output.Results[1].Err)
+	assert.Empty(t, output.Results[1].Findings)
+
+	// A file that cannot be fetched doesn't keep the others from being checked
+	assert.Equal(t, ".github/workflows/release.yml", output.Results[2].WorkflowFile)
+	assert.True(t, gr.IsNotFound(output.Results[2].Err))
+}
+
+func TestUseCase_GetSecurityReport(t *testing.T) {
+	githubUseCase := New(newStubRepository(map[string]string{
+		".github/workflows/pr-preview.yml": `on: pull_request_target
+permissions:
+  pull-requests: write
+jobs:
+  preview:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+        with:
+          ref: ${{ github.event.pull_request.head.sha }}
+      - run: echo "Building ${{ github.event.pull_request.title }}"
+`,
+		".github/workflows/ci.yml":     pushWorkflow,
+		".github/workflows/broken.yml": "- push\n",
+	}), nil, nil)
+
+	output, err := githubUseCase.GetSecurityReport(context.Background(), GetSecurityReportInput{
+		Repository: "owner/repo",
+		Branch:     "main",
+	})
+	assert.NoError(t, err)
+
+	// The workflows are in the order of the files, with the findings ordered by position
+	var files []string
+	rules := make(map[string][]string)
+	for _, workflow := range output.Workflows {
+		files = append(files, workflow.WorkflowFile)
+		for _, finding := range workflow.Findings {
+			rules[workflow.WorkflowFile] = append(rules[workflow.WorkflowFile], finding.Rule)
+		}
+	}
+	assert.Equal(t, []string{".github/workflows/broken.yml", ".github/workflows/ci.yml", ".github/workflows/pr-preview.yml"}, files)
+	assert.Error(t, output.Workflows[0].Err)
+	assert.Equal(t, []string{pw.RulePermissions}, rules[".github/workflows/ci.yml"])
+	assert.Equal(t, []string{
+		pw.RulePermissions,
+		pw.RuleUnpinnedAction,
+		pw.RulePullRequestTarget,
+		pw.RuleScriptInjection,
+	}, rules[".github/workflows/pr-preview.yml"])
+}
@@ -0,0 +1,139 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+
+	pw "github.com/termkit/gama/pkg/workflow"
+)
+
+func (u useCase) GetWorkflowJobs(ctx context.Context, input GetWorkflowJobsInput) (*GetWorkflowJobsOutput, error) {
+	content, err := u.githubRepository.InspectWorkflowContent(ctx, input.Repository, input.Branch, input.WorkflowFile)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs, err := pw.ExpandJobs(content, input.Inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetWorkflowJobsOutput{
+		Jobs: jobs,
+	}, nil
+}
+
+func (u useCase) GetRunMatrix(ctx context.Context, input GetRunMatrixInput) (*GetRunMatrixOutput, error) {
+	run, content, err := u.runWorkflowContent(ctx, input.Repository, input.RunID)
+	if err != nil {
+		return nil, err
+	}
+
+	expanded, err := pw.ExpandJobs(content, nil)
+	if err != nil {
+		return nil, err
+	}
+	graph, err := pw.NewGraph(content)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs, err := u.githubRepository.ListWorkflowJobs(ctx, input.Repository, input.RunID)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &GetRunMatrixOutput{RunStatus: run.Status, RunConclusion: run.Conclusion}
+	index := make(map[string]int) // index of the matrices by job ID
+	for _, job := range expanded {
+		if !job.Matrix {
+			continue
+		}
+		matrix := RunMatrix{Job: job.ID, Name: job.Name, Err: job.Err}
+		for i, name := range job.Names {
+			matrix.Cells = append(matrix.Cells, MatrixCell{Values: job.Combinations[i].Values(), Name: name})
+		}
+		index[job.ID] = len(output.Matrices)
+		output.Matrices = append(output.Matrices, matrix)
+	}
+
+	expandedCells := make([]int, len(output.Matrices)) // cells of the expansion, the others come from the run
+	unexpanded := make([]JobStatus, len(output.Matrices))
+	for i, matrix := range output.Matrices {
+		expandedCells[i] = len(matrix.Cells)
+	}
+
+	for _, job := range jobs {
+		// Jobs of a called workflow are named "caller (values) / job"
+		name, _, _ := strings.Cut(job.Name, " / ")
+
+		graphJob := graph.JobOfRun(job.Name)
+		i, ok := -1, false
+		for m := range output.Matrices {
+			if cell := cellByName(output.Matrices[m].Cells, name); cell >= 0 {
+				i, ok = m, true
+				break
+			}
+		}
+		if !ok && graphJob != nil {
+			i, ok = index[graphJob.ID]
+		}
+		if !ok {
+			continue // a job without matrix
+		}
+		matrix := &output.Matrices[i]
+
+		if cell := cellByName(matrix.Cells, name); cell >= 0 {
+			matrix.Cells[cell].Status = combineJobStatus(matrix.Cells[cell].Status, job.Status, job.Conclusion)
+			continue
+		}
+		if name == matrix.Name {
+			// A matrix is expanded when its job starts, a job skipped or not started yet has a single job
+			unexpanded[i] = combineJobStatus(unexpanded[i], job.Status, job.Conclusion)
+			continue
+		}
+		_, values, found := pw.ParseMatrixJobName(name)
+		if !found {
+			values = []string{name}
+		}
+		matrix.Cells = append(matrix.Cells, MatrixCell{Values: values, Name: name, Status: combineJobStatus(JobStatus{}, job.Status, job.Conclusion)})
+	}
+
+	for i := range output.Matrices {
+		matrix := &output.Matrices[i]
+		if len(matrix.Cells) > expandedCells[i] {
+			// The run has jobs the default inputs don't give, it was dispatched
+			// with other inputs: only the combinations of the run are its own
+			var cells []MatrixCell
+			for _, cell := range matrix.Cells {
+				if cell.Status.Jobs > 0 {
+					cells = append(cells, cell)
+				}
+			}
+			matrix.Cells = cells
+			continue
+		}
+		if unexpanded[i].Jobs == 0 {
+			continue
+		}
+		for c := range matrix.Cells {
+			if matrix.Cells[c].Status.Jobs == 0 {
+				matrix.Cells[c].Status = unexpanded[i]
+			}
+		}
+		if len(matrix.Cells) == 0 {
+			matrix.Cells = []MatrixCell{{Values: []string{matrix.Name}, Name: matrix.Name, Status: unexpanded[i]}}
+		}
+	}
+
+	return output, nil
+}
+
+func cellByName(cells []MatrixCell, name string) int {
+	for i, cell := range cells {
+		if cell.Name == name {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,111 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	gr "github.com/termkit/gama/internal/github/repository"
+)
+
+const matrixWorkflow = `on:
+  workflow_dispatch:
+    inputs:
+      platforms:
+        default: '["ios", "android"]'
+jobs:
+  build:
+    strategy:
+      matrix:
+        platform: ${{ fromJSON(inputs.platforms) }}
+        tier: [free, pro]
+  publish:
+    needs: build
+`
+
+func TestUseCase_GetWorkflowJobs(t *testing.T) {
+	githubUseCase := New(newStubRepository(map[string]string{".github/workflows/build.yml": matrixWorkflow}), nil, nil)
+	ctx := context.Background()
+
+	output, err := githubUseCase.GetWorkflowJobs(ctx, GetWorkflowJobsInput{
+		Repository:   "owner/repo",
+		Branch:       "main",
+		WorkflowFile: ".github/workflows/build.yml",
+	})
+	assert.NoError(t, err)
+	assert.Len(t, output.Jobs, 2)
+	assert.Equal(t, []string{"build (ios, free)", "build (ios, pro)", "build (android, free)", "build (android, pro)"}, output.Jobs[0].Names)
+	assert.Equal(t, []string{"publish"}, output.Jobs[1].Names)
+
+	// The platforms are a JSON input
+	output, err = githubUseCase.GetWorkflowJobs(ctx, GetWorkflowJobsInput{
+		Repository:   "owner/repo",
+		Branch:       "main",
+		WorkflowFile: ".github/workflows/build.yml",
+		Inputs:       map[string]any{"platforms": `["web"]`},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"build (web, free)", "build (web, pro)"}, output.Jobs[0].Names)
+
+	_, err = githubUseCase.GetWorkflowJobs(ctx, GetWorkflowJobsInput{Repository: "owner/repo", Branch: "main", WorkflowFile: ".github/workflows/missing.yml"})
+	assert.True(t, gr.IsNotFound(err))
+}
+
+func TestUseCase_GetRunMatrix(t *testing.T) {
+	run := func(id int64, status, conclusion string) gr.WorkflowRun {
+		return gr.WorkflowRun{ID: id, Status: status, Conclusion: conclusion, HeadBranch: "main", Path: ".github/workflows/build.yml"}
+	}
+	repo := newStubRepository(map[string]string{".github/workflows/build.yml": matrixWorkflow})
+	repo.runs = map[string][]gr.WorkflowRun{"owner/repo": {
+		run(1, "completed", "failure"),
+		run(2, "queued", ""),
+		run(3, "completed", "success"),
+	}}
+	repo.jobs = map[int64][]gr.WorkflowJob{
+		1: {
+			{Name: "build (ios, free)", Status: "completed", Conclusion: "success"},
+			{Name: "build (ios, pro)", Status: "completed", Conclusion: "success"},
+			{Name: "build (android, free)", Status: "completed", Conclusion: "success"},
+			{Name: "build (android, pro)", Status: "completed", Conclusion: "failure"},
+			{Name: "publish", Status: "completed", Conclusion: "skipped"},
+		},
+		// The matrix is expanded when its job starts
+		2: {{Name: "build", Status: "queued"}},
+		// Dispatched with other platforms than the default ones
+		3: {
+			{Name: "build (web, free)", Status: "completed", Conclusion: "success"},
+			{Name: "build (web, pro)", Status: "completed", Conclusion: "success"},
+		},
+	}
+	githubUseCase := New(repo, nil, nil)
+	ctx := context.Background()
+
+	conclusions := func(matrix RunMatrix) []string {
+		var conclusions []string
+		for _, cell := range matrix.Cells {
+			conclusions = append(conclusions, strings.Join(cell.Values, ",")+"="+cell.Status.Conclusion)
+		}
+		return conclusions
+	}
+
+	output, err := githubUseCase.GetRunMatrix(ctx, GetRunMatrixInput{Repository: "owner/repo", RunID: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, "failure", output.RunConclusion)
+	assert.Len(t, output.Matrices, 1)
+	assert.Equal(t, "build", output.Matrices[0].Job)
+	assert.NoError(t, output.Matrices[0].Err)
+	assert.Equal(t, []string{"ios,free=success", "ios,pro=success", "android,free=success", "android,pro=failure"}, conclusions(output.Matrices[0]))
+
+	output, err = githubUseCase.GetRunMatrix(ctx, GetRunMatrixInput{Repository: "owner/repo", RunID: 2})
+	assert.NoError(t, err)
+	assert.Len(t, output.Matrices[0].Cells, 4)
+	for _, cell := range output.Matrices[0].Cells {
+		assert.Equal(t, JobStatus{Status: "queued", Jobs: 1}, cell.Status, cell.Name)
+	}
+
+	// Only the combinations of the run are shown
+	output, err = githubUseCase.GetRunMatrix(ctx, GetRunMatrixInput{Repository: "owner/repo", RunID: 3})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"web,free=success", "web,pro=success"}, conclusions(output.Matrices[0]))
+}
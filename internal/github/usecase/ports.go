@@ -4,12 +4,31 @@ import (
 	"context"
 )
 
+// UseCase is the GitHub API of the terminal. Methods that change something on
+// GitHub are recorded in the audit log, see auditor.
 type UseCase interface {
+	CredentialSource() string
+	Profile() string
+	APIURL() string
+	WebURL() string
 	ListRepositories(ctx context.Context, input ListRepositoriesInput) (*ListRepositoriesOutput, error)
+	StreamRepositories(ctx context.Context, input ListRepositoriesInput, onProgress func(StreamRepositoriesProgress)) error
 	GetWorkflowHistory(ctx context.Context, input GetWorkflowHistoryInput) (*GetWorkflowHistoryOutput, error)
 	GetTriggerableWorkflows(ctx context.Context, input GetTriggerableWorkflowsInput) (*GetTriggerableWorkflowsOutput, error)
 	InspectWorkflow(ctx context.Context, input InspectWorkflowInput) (*InspectWorkflowOutput, error)
+	GetWorkflowFile(ctx context.Context, input GetWorkflowFileInput) (*GetWorkflowFileOutput, error)
+	LintWorkflows(ctx context.Context, input LintWorkflowsInput) (*LintWorkflowsOutput, error)
+	GetSecurityReport(ctx context.Context, input GetSecurityReportInput) (*GetSecurityReportOutput, error)
+	GetReusableWorkflows(ctx context.Context, input GetReusableWorkflowsInput) (*GetReusableWorkflowsOutput, error)
+	GetWorkflowGraph(ctx context.Context, input GetWorkflowGraphInput) (*GetWorkflowGraphOutput, error)
+	GetWorkflowJobs(ctx context.Context, input GetWorkflowJobsInput) (*GetWorkflowJobsOutput, error)
+	GetRunMatrix(ctx context.Context, input GetRunMatrixInput) (*GetRunMatrixOutput, error)
 	TriggerWorkflow(ctx context.Context, input TriggerWorkflowInput) (*TriggerWorkflowOutput, error)
+	PreviewTriggerWorkflow(ctx context.Context, input TriggerWorkflowInput) (*PreviewTriggerWorkflowOutput, error)
+	ListWorkflowRepositories(ctx context.Context, input ListWorkflowRepositoriesInput) (*ListWorkflowRepositoriesOutput, error)
+	FanOutWorkflow(ctx context.Context, input FanOutWorkflowInput, onResult func(FanOutResult)) (*FanOutWorkflowOutput, error)
+	GetRepositoryDispatchEvents(ctx context.Context, input GetRepositoryDispatchEventsInput) (*GetRepositoryDispatchEventsOutput, error)
+	DispatchRepositoryEvent(ctx context.Context, input DispatchRepositoryEventInput) (*DispatchRepositoryEventOutput, error)
 	ReRunFailedJobs(ctx context.Context, input ReRunFailedJobsInput) (*ReRunFailedJobsOutput, error)
 	ReRunWorkflow(ctx context.Context, input ReRunWorkflowInput) (*ReRunWorkflowOutput, error)
 	CancelWorkflow(ctx context.Context, input CancelWorkflowInput) (*CancelWorkflowOutput, error)
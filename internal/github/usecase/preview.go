@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// githubHost is the host the GitHub CLI uses when the repository has none.
+const githubHost = "github.com"
+
+// PreviewTriggerWorkflow returns the request TriggerWorkflow would send for the
+// input, and equivalent commands. Nothing is sent.
+func (u useCase) PreviewTriggerWorkflow(ctx context.Context, input TriggerWorkflowInput) (*PreviewTriggerWorkflowOutput, error) {
+	request, err := u.githubRepository.WorkflowDispatchRequest(input.Repository, input.Branch, input.WorkflowFile, input.Inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	ghCommand, err := u.ghWorkflowRunCommand(input)
+	if err != nil {
+		return nil, err
+	}
+
+	curlCommand := strings.Join([]string{
+		"curl -L -X " + request.Method,
+		"  -H " + shellQuote("Accept: "+request.Accept),
+		`  -H "Authorization: Bearer $GITHUB_TOKEN"`,
+		"  -H " + shellQuote("X-GitHub-Api-Version: "+request.APIVersion),
+		"  -H " + shellQuote("Content-Type: "+request.ContentType),
+		"  " + shellQuote(request.URL),
+		"  -d " + shellQuote(string(request.Body)),
+	}, " \\\n")
+
+	return &PreviewTriggerWorkflowOutput{
+		Method:      request.Method,
+		URL:         request.URL,
+		Body:        string(request.Body),
+		GhCommand:   ghCommand,
+		CurlCommand: curlCommand,
+	}, nil
+}
+
+// ghWorkflowRunCommand returns the gh workflow run command of the input. Inputs
+// are passed as JSON on stdin, so their types are kept.
+func (u useCase) ghWorkflowRunCommand(input TriggerWorkflowInput) (string, error) {
+	repository := input.Repository
+	if webURL, err := url.Parse(u.githubRepository.WebURL()); err == nil && webURL.Host != "" && webURL.Host != githubHost {
+		repository = webURL.Host + "/" + repository // gh accepts [HOST/]OWNER/REPO
+	}
+
+	command := fmt.Sprintf("gh workflow run %s --repo %s --ref %s",
+		shellQuote(path.Base(input.WorkflowFile)), shellQuote(repository), shellQuote(input.Branch))
+	if len(input.Inputs) == 0 {
+		return command, nil
+	}
+
+	inputs, err := json.Marshal(input.Inputs)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("echo %s | %s --json", shellQuote(string(inputs)), command), nil
+}
+
+// shellQuote quotes s for POSIX shells when it contains anything but safe characters.
+func shellQuote(s string) string {
+	if s != "" && strings.IndexFunc(s, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("-_./:@=+,", r))
+	}) < 0 {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
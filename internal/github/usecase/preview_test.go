@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/termkit/gama/internal/github/repository"
+	pkgconfig "github.com/termkit/gama/pkg/config"
+)
+
+func TestUseCase_PreviewTriggerWorkflow(t *testing.T) {
+	githubUseCase := New(repository.New(&pkgconfig.Config{}, nil, nil), nil, nil)
+
+	preview, err := githubUseCase.PreviewTriggerWorkflow(context.Background(), TriggerWorkflowInput{
+		Repository:   "owner/repo",
+		Branch:       "release/1.x",
+		WorkflowFile: ".github/workflows/deploy.yml",
+		Inputs:       map[string]any{"note": "it's live", "replicas": json.Number("3")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if preview.URL != "https://api.github.com/repos/owner/repo/actions/workflows/deploy.yml/dispatches" {
+		t.Errorf("unexpected URL %s", preview.URL)
+	}
+	if preview.Body != `{"ref":"release/1.x","inputs":{"note":"it's live","replicas":3}}` {
+		t.Errorf("unexpected body %s", preview.Body)
+	}
+
+	wantGh := `echo '{"note":"it'\''s live","replicas":3}' | gh workflow run deploy.yml --repo owner/repo --ref release/1.x --json`
+	if preview.GhCommand != wantGh {
+		t.Errorf("unexpected gh command\n got: %s\nwant: %s", preview.GhCommand, wantGh)
+	}
+
+	wantCurl := `curl -L -X POST \
+  -H 'Accept: application/vnd.github+json' \
+  -H "Authorization: Bearer $GITHUB_TOKEN" \
+  -H 'X-GitHub-Api-Version: 2022-11-28' \
+  -H 'Content-Type: application/json' \
+  https://api.github.com/repos/owner/repo/actions/workflows/deploy.yml/dispatches \
+  -d '{"ref":"release/1.x","inputs":{"note":"it'\''s live","replicas":3}}'`
+	if preview.CurlCommand != wantCurl {
+		t.Errorf("unexpected curl command\n got: %s\nwant: %s", preview.CurlCommand, wantCurl)
+	}
+}
+
+func TestUseCase_PreviewTriggerWorkflowEnterprise(t *testing.T) {
+	cfg := &pkgconfig.Config{}
+	cfg.Github.APIURL = "https://ghe.example.com/api/v3"
+	githubUseCase := New(repository.New(cfg, nil, nil), nil, nil)
+
+	preview, err := githubUseCase.PreviewTriggerWorkflow(context.Background(), TriggerWorkflowInput{
+		Repository:   "owner/repo",
+		Branch:       "main",
+		WorkflowFile: ".github/workflows/deploy.yml",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := "gh workflow run deploy.yml --repo ghe.example.com/owner/repo --ref main"; preview.GhCommand != want {
+		t.Errorf("unexpected gh command %s, want %s", preview.GhCommand, want)
+	}
+}
@@ -0,0 +1,139 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	pkgconcurrency "github.com/termkit/gama/pkg/concurrency"
+	pw "github.com/termkit/gama/pkg/workflow"
+	py "github.com/termkit/gama/pkg/yaml"
+)
+
+func (u useCase) GetReusableWorkflows(ctx context.Context, input GetReusableWorkflowsInput) (*GetReusableWorkflowsOutput, error) {
+	files, err := u.githubRepository.ListWorkflowFiles(ctx, input.Repository, input.Branch)
+	if err != nil {
+		return nil, err
+	}
+
+	type workflowFile struct {
+		content []byte
+		err     error
+	}
+	contents, err := pkgconcurrency.Map(ctx, u.limiter, files, func(ctx context.Context, file string) (workflowFile, error) {
+		content, err := u.githubRepository.InspectWorkflowContent(ctx, input.Repository, input.Branch, file)
+		if ctx.Err() != nil {
+			return workflowFile{}, ctx.Err()
+		}
+		return workflowFile{content: content, err: err}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var workflows []WorkflowCalls
+	byFile := make(map[string]int)  // index of the workflows by file
+	remote := make(map[string]bool) // workflows of other repositories or refs, by reference
+	for i, file := range files {
+		workflow := WorkflowCalls{WorkflowFile: file, Err: contents[i].err}
+		if workflow.Err == nil {
+			workflow.Name, workflow.Interface, workflow.Err = reusableInterface(contents[i].content)
+		}
+		if workflow.Err == nil {
+			var references []pw.WorkflowReference
+			references, workflow.Err = pw.WorkflowReferences(contents[i].content)
+			for _, reference := range references {
+				workflow.Calls = append(workflow.Calls, WorkflowCall{WorkflowReference: reference})
+				if !reference.Local() {
+					remote[referenceKey(reference)] = true
+				}
+			}
+		}
+
+		byFile[file] = len(workflows)
+		workflows = append(workflows, workflow)
+	}
+
+	// Workflows of other repositories are fetched once, however many jobs call them
+	var remoteKeys []string
+	for key := range remote {
+		remoteKeys = append(remoteKeys, key)
+	}
+	remoteContents, err := pkgconcurrency.Map(ctx, u.limiter, remoteKeys, func(ctx context.Context, key string) (workflowFile, error) {
+		repository, rest, _ := strings.Cut(key, "@")
+		ref, path, _ := strings.Cut(rest, ":")
+		content, err := u.githubRepository.InspectWorkflowContent(ctx, repository, ref, path)
+		if ctx.Err() != nil {
+			return workflowFile{}, ctx.Err()
+		}
+		return workflowFile{content: content, err: err}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	remoteByKey := make(map[string]workflowFile)
+	for i, key := range remoteKeys {
+		remoteByKey[key] = remoteContents[i]
+	}
+
+	for i := range workflows {
+		workflow := &workflows[i]
+		for j := range workflow.Calls {
+			call := &workflow.Calls[j]
+
+			// Calls to a workflow of the branch, written either way
+			if call.Local() || strings.EqualFold(call.Repository, input.Repository) && call.Ref == input.Branch {
+				if index, ok := byFile[call.Path]; ok && !slices.Contains(workflows[index].CalledBy, workflow.WorkflowFile) {
+					workflows[index].CalledBy = append(workflows[index].CalledBy, workflow.WorkflowFile)
+				}
+			}
+
+			var called workflowFile
+			switch {
+			case call.Local():
+				index, ok := byFile[call.Path]
+				if !ok {
+					call.Err = fmt.Errorf("%s does not exist on %s", call.Path, input.Branch)
+					continue
+				}
+				called = contents[index]
+			default:
+				called = remoteByKey[referenceKey(call.WorkflowReference)]
+			}
+			if called.err != nil {
+				call.Err = called.err
+				continue
+			}
+
+			_, call.Interface, call.Err = reusableInterface(called.content)
+			if call.Err == nil && call.Interface == nil {
+				call.Err = fmt.Errorf("%s has no workflow_call trigger, it cannot be called", call.Path)
+			}
+			if call.Err == nil {
+				call.Findings, call.Err = pw.CheckWorkflowCall(contents[i].content, call.Job, *call.Interface)
+			}
+		}
+	}
+
+	return &GetReusableWorkflowsOutput{
+		Workflows: workflows,
+	}, nil
+}
+
+// reusableInterface returns the name of a workflow and its interface, nil when
+// it has no workflow_call trigger.
+func reusableInterface(content []byte) (string, *py.WorkflowCall, error) {
+	workflowContent, err := py.UnmarshalWorkflowContent(content)
+	if err != nil {
+		return "", nil, err
+	}
+	if !workflowContent.On.Has("workflow_call") {
+		return workflowContent.Name, nil, nil
+	}
+	return workflowContent.Name, &workflowContent.On.WorkflowCall, nil
+}
+
+func referenceKey(reference pw.WorkflowReference) string {
+	return reference.Repository + "@" + reference.Ref + ":" + reference.Path
+}
@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	gr "github.com/termkit/gama/internal/github/repository"
+)
+
+const notifyWorkflow = `name: Notify
+on:
+  workflow_call:
+    inputs:
+      message:
+        type: string
+        required: true
+    secrets:
+      SLACK_WEBHOOK:
+        required: false
+    outputs:
+      sent_at:
+        description: 'When the message was sent'
+        value: ${{ jobs.notify.outputs.sent_at }}
+jobs:
+  notify:
+    runs-on: ubuntu-latest
+`
+
+func TestUseCase_GetReusableWorkflows(t *testing.T) {
+	githubUseCase := New(newStubRepository(map[string]string{
+		".github/workflows/notify.yml": notifyWorkflow,
+		".github/workflows/deploy.yml": `on: push
+jobs:
+  notify:
+    uses: ./.github/workflows/notify.yml
+    with:
+      message: Deployed
+    secrets: inherit
+`,
+		// Through the repository, without the message, and a workflow of another repository that doesn't exist
+		".github/workflows/drift.yml": `on: schedule
+jobs:
+  report:
+    uses: owner/repo/.github/workflows/notify.yml@main
+    with:
+      channel: '#ops'
+  shared:
+    uses: owner/shared/.github/workflows/missing.yml@v1
+`,
+	}), nil, nil)
+
+	output, err := githubUseCase.GetReusableWorkflows(context.Background(), GetReusableWorkflowsInput{
+		Repository: "owner/repo",
+		Branch:     "main",
+	})
+	assert.NoError(t, err)
+	assert.Len(t, output.Workflows, 3)
+
+	workflows := make(map[string]WorkflowCalls)
+	for _, workflow := range output.Workflows {
+		assert.NoError(t, workflow.Err)
+		workflows[workflow.WorkflowFile] = workflow
+	}
+
+	notify := workflows[".github/workflows/notify.yml"]
+	assert.Equal(t, "Notify", notify.Name)
+	assert.True(t, notify.Interface.Inputs["message"].Required)
+	assert.Contains(t, notify.Interface.Secrets, "SLACK_WEBHOOK")
+	assert.Equal(t, "When the message was sent", notify.Interface.Outputs["sent_at"].Description)
+	assert.Equal(t, []string{".github/workflows/deploy.yml", ".github/workflows/drift.yml"}, notify.CalledBy)
+
+	deploy := workflows[".github/workflows/deploy.yml"]
+	assert.Nil(t, deploy.Interface)
+	assert.Len(t, deploy.Calls, 1)
+	assert.NoError(t, deploy.Calls[0].Err)
+	assert.Equal(t, "notify", deploy.Calls[0].Job)
+	assert.Empty(t, deploy.Calls[0].Findings)
+
+	drift := workflows[".github/workflows/drift.yml"]
+	assert.Len(t, drift.Calls, 2)
+	assert.Equal(t, "owner/repo", drift.Calls[0].Repository)
+	var messages []string
+	for _, finding := range drift.Calls[0].Findings {
+		messages = append(messages, finding.Message)
+	}
+	assert.Equal(t, []string{`required input "message" is not passed`, `"channel" is not an input of the called workflow`}, messages)
+	assert.True(t, gr.IsNotFound(drift.Calls[1].Err))
+}
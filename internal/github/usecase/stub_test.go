@@ -0,0 +1,201 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+
+	gr "github.com/termkit/gama/internal/github/repository"
+	pkgaudit "github.com/termkit/gama/pkg/audit"
+)
+
+const dispatchWorkflow = `on: workflow_dispatch
+jobs:
+  run:
+    runs-on: ubuntu-latest
+`
+
+const pushWorkflow = `on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+`
+
+// stubRepository is an in-memory gr.Repository. The methods a test relies on
+// are implemented here, calling any other method panics on the nil embedded
+// interface.
+type stubRepository struct {
+	gr.Repository
+
+	mu sync.Mutex
+
+	// user is the authenticated user, GetAuthenticatedUser fails when it is nil.
+	user      *gr.Actor
+	userCalls int
+
+	// files are the workflow files by repository, then by path.
+	files map[string]map[string]string
+
+	repositories []gr.GithubRepository
+
+	// runs are the workflow runs by repository.
+	runs map[string][]gr.WorkflowRun
+
+	// jobs are the jobs of the runs by run ID.
+	jobs map[int64][]gr.WorkflowJob
+
+	// runDelay is the number of times the runs are listed before a dispatched run shows up.
+	runDelay  int
+	hiddenFor map[int64]int
+	nextRunID int64
+
+	triggered  []string // repository@branch:path of the dispatched workflows
+	dispatched []string // repository:event_type of the repository events
+}
+
+// newStubRepository returns a stub with the workflow files of owner/repo by path.
+func newStubRepository(files map[string]string) *stubRepository {
+	return &stubRepository{files: map[string]map[string]string{"owner/repo": files}}
+}
+
+// newAuditLog returns an audit log in a temporary directory of the test.
+func newAuditLog(t *testing.T) *pkgaudit.Log {
+	t.Helper()
+	return pkgaudit.New(filepath.Join(t.TempDir(), "audit.jsonl"))
+}
+
+// paths returns the paths of the files of a repository, sorted.
+func (s *stubRepository) paths(repository string) []string {
+	var paths []string
+	for path := range s.files[repository] {
+		paths = append(paths, path)
+	}
+	slices.Sort(paths)
+	return paths
+}
+
+func notFound() error {
+	return &gr.APIError{StatusCode: http.StatusNotFound, Message: "Not Found"}
+}
+
+func (s *stubRepository) Profile() string {
+	return "default"
+}
+
+func (s *stubRepository) APIURL() string {
+	return "https://api.github.com"
+}
+
+func (s *stubRepository) GetAuthenticatedUser(_ context.Context) (*gr.Actor, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.userCalls++
+	if s.user == nil {
+		return nil, errors.New("Resource not accessible by integration")
+	}
+	return s.user, nil
+}
+
+func (s *stubRepository) GetWorkflows(_ context.Context, repository string) ([]gr.Workflow, error) {
+	var workflows []gr.Workflow
+	for i, path := range s.paths(repository) {
+		workflows = append(workflows, gr.Workflow{ID: int64(i + 1), Path: path})
+	}
+	return workflows, nil
+}
+
+func (s *stubRepository) ListWorkflowFiles(_ context.Context, repository string, _ string) ([]string, error) {
+	return s.paths(repository), nil
+}
+
+func (s *stubRepository) InspectWorkflowContent(_ context.Context, repository string, _ string, workflowFile string) ([]byte, error) {
+	content, ok := s.files[repository][workflowFile]
+	if !ok {
+		return nil, notFound()
+	}
+	return []byte(content), nil
+}
+
+func (s *stubRepository) TriggerWorkflow(_ context.Context, repository string, branch string, workflowName string, _ map[string]any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.triggered = append(s.triggered, repository+"@"+branch+":"+workflowName)
+
+	// The run of the dispatch, numbered after the seeded ones
+	s.nextRunID = max(s.nextRunID, 100) + 1
+	if s.runs == nil {
+		s.runs = make(map[string][]gr.WorkflowRun)
+	}
+	s.runs[repository] = append(s.runs[repository], gr.WorkflowRun{
+		ID:         s.nextRunID,
+		Status:     "queued",
+		HeadBranch: branch,
+		Path:       workflowName,
+		Event:      "workflow_dispatch",
+		HTMLURL:    fmt.Sprintf("https://github.com/%s/actions/runs/%d", repository, s.nextRunID),
+	})
+	if s.runDelay > 0 {
+		if s.hiddenFor == nil {
+			s.hiddenFor = make(map[int64]int)
+		}
+		s.hiddenFor[s.nextRunID] = s.runDelay
+	}
+	return nil
+}
+
+func (s *stubRepository) ListRepositories(_ context.Context, _ int) ([]gr.GithubRepository, error) {
+	return s.repositories, nil
+}
+
+func (s *stubRepository) ListWorkflowRuns(_ context.Context, repository string, branch string) (*gr.WorkflowRuns, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var runs []gr.WorkflowRun
+	for _, run := range s.runs[repository] {
+		if s.hiddenFor[run.ID] > 0 {
+			s.hiddenFor[run.ID]--
+			continue
+		}
+		if run.HeadBranch == branch {
+			runs = append(runs, run)
+		}
+	}
+	return &gr.WorkflowRuns{TotalCount: int64(len(runs)), WorkflowRuns: runs}, nil
+}
+
+func (s *stubRepository) GetWorkflowRun(_ context.Context, repository string, runId int64) (*gr.WorkflowRun, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, run := range s.runs[repository] {
+		if run.ID == runId {
+			return &run, nil
+		}
+	}
+	return nil, notFound()
+}
+
+func (s *stubRepository) ListWorkflowJobs(_ context.Context, _ string, runId int64) ([]gr.WorkflowJob, error) {
+	return s.jobs[runId], nil
+}
+
+func (s *stubRepository) CancelWorkflow(ctx context.Context, repository string, runId int64) error {
+	_, err := s.GetWorkflowRun(ctx, repository, runId)
+	return err
+}
+
+func (s *stubRepository) DispatchRepositoryEvent(_ context.Context, repository string, eventType string, _ map[string]any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.dispatched = append(s.dispatched, repository+":"+eventType)
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	pw "github.com/termkit/gama/pkg/workflow"
+	py "github.com/termkit/gama/pkg/yaml"
 )
 
 type ListRepositoriesInput struct {
@@ -14,6 +15,16 @@ type ListRepositoriesOutput struct {
 	Repositories []GithubRepository
 }
 
+// StreamRepositoriesProgress is reported once for every repository, in the
+// order their workflows have been counted.
+type StreamRepositoriesProgress struct {
+	Repository GithubRepository
+	Err        error // set when the workflows of the repository cannot be listed
+
+	Done  int // repositories reported so far, including this one
+	Total int // repositories being loaded
+}
+
 type GithubRepository struct {
 	Name          string
 	Private       bool
@@ -61,11 +72,155 @@ type InspectWorkflowOutput struct {
 
 // ------------------------------------------------------------
 
+type GetWorkflowFileInput struct {
+	Repository   string
+	Branch       string
+	WorkflowFile string
+}
+
+type GetWorkflowFileOutput struct {
+	Content string // YAML of the workflow as it is on the branch
+}
+
+// ------------------------------------------------------------
+
+type LintWorkflowsInput struct {
+	Repository    string
+	Branch        string
+	WorkflowFiles []string
+}
+
+type LintWorkflowsOutput struct {
+	Results []WorkflowFindings // in the order of WorkflowFiles
+}
+
+// WorkflowFindings holds the findings of a workflow file, Err is set when the
+// file cannot be fetched or checked.
+type WorkflowFindings struct {
+	WorkflowFile string
+	Findings     []pw.Finding
+	Err          error
+}
+
+// ------------------------------------------------------------
+
+type GetSecurityReportInput struct {
+	Repository string
+	Branch     string
+}
+
+type GetSecurityReportOutput struct {
+	Workflows []WorkflowFindings // every workflow file of the branch
+}
+
+// ------------------------------------------------------------
+
+type GetReusableWorkflowsInput struct {
+	Repository string
+	Branch     string
+}
+
+type GetReusableWorkflowsOutput struct {
+	Workflows []WorkflowCalls // every workflow file of the branch
+}
+
+// WorkflowCalls is a workflow file with its interface when it is reusable, the
+// reusable workflows its jobs call and the workflows of the branch calling it.
+// Err is set when the file cannot be fetched or parsed.
+type WorkflowCalls struct {
+	WorkflowFile string
+	Name         string
+	Interface    *py.WorkflowCall // nil when it has no workflow_call trigger
+	Calls        []WorkflowCall
+	CalledBy     []string // workflow files of the branch
+	Err          error
+}
+
+// WorkflowCall is a reusable workflow called by a job, resolved to the called
+// workflow file. Err is set when it cannot be fetched or is not reusable.
+type WorkflowCall struct {
+	pw.WorkflowReference
+	Interface *py.WorkflowCall // of the called workflow
+	Findings  []pw.Finding     // inputs and secrets passed by the job not matching the interface
+	Err       error
+}
+
+// ------------------------------------------------------------
+
+type GetWorkflowGraphInput struct {
+	Repository   string
+	Branch       string
+	WorkflowFile string
+	RunID        int64 // run whose jobs are shown, its workflow file is used instead of Branch and WorkflowFile
+}
+
+type GetWorkflowGraphOutput struct {
+	Graph         *pw.Graph
+	RunStatus     string               // status of the run, empty without RunID
+	RunConclusion string               // conclusion of the run once completed
+	Jobs          map[string]JobStatus // status of the jobs of the run by job ID, a job that didn't start is missing
+}
+
+// JobStatus is the status of a job in a run, combined over the jobs of its
+// matrix and of the reusable workflow it calls.
+type JobStatus struct {
+	Status     string // queued, waiting, in_progress or completed
+	Conclusion string // the worst conclusion once completed, like success, failure, etc.
+	Jobs       int    // jobs of the run
+	Completed  int    // completed jobs of the run
+}
+
+// ------------------------------------------------------------
+
+type GetWorkflowJobsInput struct {
+	Repository   string
+	Branch       string
+	WorkflowFile string
+	Inputs       map[string]any // workflow_dispatch inputs typed by pw.Pretty.ToPayload, missing inputs get their default
+}
+
+type GetWorkflowJobsOutput struct {
+	Jobs []pw.ExpandedJob // in the order of the file, with the jobs of their matrix
+}
+
+// ------------------------------------------------------------
+
+type GetRunMatrixInput struct {
+	Repository string
+	RunID      int64
+}
+
+type GetRunMatrixOutput struct {
+	RunStatus     string
+	RunConclusion string
+	Matrices      []RunMatrix // jobs of the workflow having a matrix, in the order of the file
+}
+
+// RunMatrix is a job with a matrix and the jobs of the run for its
+// combinations. The matrix is expanded with the default inputs, as the inputs
+// of a run are not known. When the run has jobs for other combinations, they
+// come from the names of the jobs of the run.
+type RunMatrix struct {
+	Job   string // job ID
+	Name  string
+	Cells []MatrixCell
+	Err   error // the matrix cannot be expanded, the cells are only those of the run
+}
+
+// MatrixCell is a combination of a matrix with the status of its job in the run.
+type MatrixCell struct {
+	Values []string // values of the combination as in the name of the job
+	Name   string   // name of the job of the run
+	Status JobStatus
+}
+
+// ------------------------------------------------------------
+
 type TriggerWorkflowInput struct {
 	WorkflowFile string
 	Repository   string
 	Branch       string
-	Content      string // workflow content in json format
+	Inputs       map[string]any // workflow_dispatch inputs, typed by pw.Pretty.ToPayload
 }
 
 type TriggerWorkflowOutput struct {
@@ -75,6 +230,78 @@ type TriggerWorkflowOutput struct {
 
 // ------------------------------------------------------------
 
+type PreviewTriggerWorkflowOutput struct {
+	Method      string
+	URL         string
+	Body        string // JSON body, exactly as TriggerWorkflow sends it
+	GhCommand   string // equivalent GitHub CLI command
+	CurlCommand string // equivalent curl command, the token is read from $GITHUB_TOKEN
+}
+
+// ------------------------------------------------------------
+
+type ListWorkflowRepositoriesInput struct {
+	WorkflowFile string
+}
+
+type ListWorkflowRepositoriesOutput struct {
+	Repositories []GithubRepository // repositories having the workflow, sorted by name
+}
+
+// ------------------------------------------------------------
+
+type FanOutWorkflowInput struct {
+	WorkflowFile string
+	Targets      []DispatchTarget
+	Inputs       map[string]any // workflow_dispatch inputs, sent to every target
+}
+
+type FanOutWorkflowOutput struct {
+	Results []FanOutResult // in the order of the targets
+}
+
+// DispatchTarget is a branch of a repository a workflow is dispatched on.
+type DispatchTarget struct {
+	Repository string
+	Branch     string
+}
+
+type FanOutResult struct {
+	Target DispatchTarget
+	RunID  int64  // run created by the dispatch, 0 when it cannot be found yet
+	RunURL string // web page of the run
+	Err    error  // set when the workflow cannot be triggered on the target
+}
+
+// ------------------------------------------------------------
+
+type GetRepositoryDispatchEventsInput struct {
+	Repository string
+}
+
+type GetRepositoryDispatchEventsOutput struct {
+	Events []RepositoryDispatchEvent
+}
+
+// RepositoryDispatchEvent is an event type and the workflows of the default branch it runs.
+type RepositoryDispatchEvent struct {
+	EventType string // empty for workflows that run on every event type
+	Workflows []string
+}
+
+// ------------------------------------------------------------
+
+type DispatchRepositoryEventInput struct {
+	Repository    string
+	EventType     string
+	ClientPayload map[string]any
+}
+
+type DispatchRepositoryEventOutput struct {
+}
+
+// ------------------------------------------------------------
+
 type GetTriggerableWorkflowsInput struct {
 	Repository string
 	Branch     string
@@ -5,49 +5,55 @@ import (
 	"errors"
 	"fmt"
 	"slices"
+	"strings"
+	"sync"
 	"time"
 
 	gr "github.com/termkit/gama/internal/github/repository"
+	pkgaudit "github.com/termkit/gama/pkg/audit"
+	pkgconcurrency "github.com/termkit/gama/pkg/concurrency"
 	pw "github.com/termkit/gama/pkg/workflow"
 	py "github.com/termkit/gama/pkg/yaml"
 )
 
 type useCase struct {
 	githubRepository gr.Repository
+	limiter          *pkgconcurrency.Limiter
+	audit            *auditor
 }
 
-func New(githubRepository gr.Repository) UseCase {
+// New returns the use case, mutating calls are recorded in auditLog unless it is nil.
+func New(githubRepository gr.Repository, limiter *pkgconcurrency.Limiter, auditLog *pkgaudit.Log) UseCase {
 	return &useCase{
 		githubRepository: githubRepository,
+		limiter:          limiter,
+		audit:            newAuditor(auditLog, githubRepository),
 	}
 }
 
-func (u useCase) ListRepositories(ctx context.Context, input ListRepositoriesInput) (*ListRepositoriesOutput, error) {
-	repositories, err := u.githubRepository.ListRepositories(ctx, input.Limit)
-	if err != nil {
-		return nil, err
-	}
+func (u useCase) CredentialSource() string {
+	return u.githubRepository.CredentialSource()
+}
 
-	// Create a buffered channel for results and errors
-	results := make(chan GithubRepository, len(repositories))
-	errs := make(chan error, len(repositories))
+func (u useCase) Profile() string {
+	return u.githubRepository.Profile()
+}
 
-	// Send jobs to the workers
-	for _, repository := range repositories {
-		go u.workerListRepositories(ctx, repository, results, errs)
-	}
+func (u useCase) APIURL() string {
+	return u.githubRepository.APIURL()
+}
 
-	// Collect the results and errors
+func (u useCase) WebURL() string {
+	return u.githubRepository.WebURL()
+}
+
+func (u useCase) ListRepositories(ctx context.Context, input ListRepositoriesInput) (*ListRepositoriesOutput, error) {
 	var result []GithubRepository
-	var resultErrs []error
-	for range repositories {
-		select {
-		case res := <-results:
-			result = append(result, res)
-		case err := <-errs:
-			resultErrs = append(resultErrs, err)
+	err := u.StreamRepositories(ctx, input, func(progress StreamRepositoriesProgress) {
+		if progress.Err == nil {
+			result = append(result, progress.Repository)
 		}
-	}
+	})
 
 	slices.SortFunc(result, func(a, b GithubRepository) int {
 		return int(b.LastUpdated.Unix() - a.LastUpdated.Unix())
@@ -55,14 +61,46 @@ func (u useCase) ListRepositories(ctx context.Context, input ListRepositoriesInp
 
 	return &ListRepositoriesOutput{
 		Repositories: result,
-	}, errors.Join(resultErrs...)
+	}, err
 }
 
-func (u useCase) workerListRepositories(ctx context.Context, repository gr.GithubRepository, results chan<- GithubRepository, errs chan<- error) {
+func (u useCase) StreamRepositories(ctx context.Context, input ListRepositoriesInput, onProgress func(StreamRepositoriesProgress)) error {
+	repositories, err := u.githubRepository.ListRepositories(ctx, input.Limit)
+	if err != nil {
+		return err
+	}
+
+	var mu sync.Mutex
+	var done int
+	var errs []error
+
+	// Count the workflows of every repository, reporting each one as soon as it is ready
+	ctxErr := pkgconcurrency.ForEach(ctx, u.limiter, repositories, func(ctx context.Context, _ int, repository gr.GithubRepository) {
+		result, err := u.getRepositoryWorkflows(ctx, repository)
+
+		// onProgress is called by one worker at a time, so Done never goes backwards
+		mu.Lock()
+		defer mu.Unlock()
+
+		done++
+		if err != nil {
+			errs = append(errs, err)
+		}
+		onProgress(StreamRepositoriesProgress{
+			Repository: result,
+			Err:        err,
+			Done:       done,
+			Total:      len(repositories),
+		})
+	})
+
+	return errors.Join(append(errs, ctxErr)...)
+}
+
+func (u useCase) getRepositoryWorkflows(ctx context.Context, repository gr.GithubRepository) (GithubRepository, error) {
 	getWorkflows, err := u.githubRepository.GetWorkflows(ctx, repository.FullName)
 	if err != nil {
-		errs <- err
-		return
+		return GithubRepository{}, err
 	}
 
 	var workflows []Workflow
@@ -72,14 +110,14 @@ func (u useCase) workerListRepositories(ctx context.Context, repository gr.Githu
 		})
 	}
 
-	results <- GithubRepository{
+	return GithubRepository{
 		Name:          repository.FullName,
 		Stars:         repository.StargazersCount,
 		Private:       repository.Private,
 		DefaultBranch: repository.DefaultBranch,
 		LastUpdated:   repository.UpdatedAt,
 		Workflows:     workflows,
-	}
+	}, nil
 }
 
 func (u useCase) GetWorkflowHistory(ctx context.Context, input GetWorkflowHistoryInput) (*GetWorkflowHistoryOutput, error) {
@@ -118,8 +156,7 @@ func (u useCase) GetWorkflowHistory(ctx context.Context, input GetWorkflowHistor
 }
 
 func (u useCase) GetTriggerableWorkflows(ctx context.Context, input GetTriggerableWorkflowsInput) (*GetTriggerableWorkflowsOutput, error) {
-	// TODO: Add branch option
-	triggerableWorkflows, err := u.githubRepository.GetTriggerableWorkflows(ctx, input.Repository)
+	triggerableWorkflows, err := u.githubRepository.GetTriggerableWorkflows(ctx, input.Repository, input.Branch)
 	if err != nil {
 		return nil, err
 	}
@@ -154,6 +191,19 @@ func (u useCase) InspectWorkflow(ctx context.Context, input InspectWorkflowInput
 		return nil, err
 	}
 
+	if workflow.HasEnvironmentInputs() {
+		environments, err := u.githubRepository.ListEnvironments(ctx, input.Repository)
+		if err != nil {
+			return nil, err
+		}
+
+		var environmentNames []string
+		for _, environment := range environments {
+			environmentNames = append(environmentNames, environment.Name)
+		}
+		workflow.SetEnvironments(environmentNames)
+	}
+
 	pretty := workflow.ToPretty()
 
 	return &InspectWorkflowOutput{
@@ -161,8 +211,47 @@ func (u useCase) InspectWorkflow(ctx context.Context, input InspectWorkflowInput
 	}, nil
 }
 
+func (u useCase) GetWorkflowFile(ctx context.Context, input GetWorkflowFileInput) (*GetWorkflowFileOutput, error) {
+	workflowData, err := u.githubRepository.InspectWorkflowContent(ctx, input.Repository, input.Branch, input.WorkflowFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetWorkflowFileOutput{
+		Content: string(workflowData),
+	}, nil
+}
+
 func (u useCase) TriggerWorkflow(ctx context.Context, input TriggerWorkflowInput) (*TriggerWorkflowOutput, error) {
-	err := u.githubRepository.TriggerWorkflow(ctx, input.Repository, input.Branch, input.WorkflowFile, input.Content)
+	output, err := u.triggerWorkflow(ctx, input)
+	u.audit.record(ctx, pkgaudit.Entry{
+		Action:     pkgaudit.ActionTriggerWorkflow,
+		Repository: input.Repository,
+		Ref:        input.Branch,
+		Workflow:   input.WorkflowFile,
+		Inputs:     input.Inputs,
+	}, err)
+	return output, err
+}
+
+func (u useCase) triggerWorkflow(ctx context.Context, input TriggerWorkflowInput) (*TriggerWorkflowOutput, error) {
+	// Make sure the workflow file exists on the branch it is dispatched on
+	workflowData, err := u.githubRepository.InspectWorkflowContent(ctx, input.Repository, input.Branch, input.WorkflowFile)
+	if gr.IsNotFound(err) {
+		return nil, fmt.Errorf("workflow %s does not exist on %s", input.WorkflowFile, input.Branch)
+	} else if err != nil {
+		return nil, err
+	}
+
+	workflowContent, err := py.UnmarshalWorkflowContent(workflowData)
+	if err != nil {
+		return nil, err
+	}
+	if !workflowContent.On.Has("workflow_dispatch") {
+		return nil, fmt.Errorf("workflow %s has no workflow_dispatch trigger on %s", input.WorkflowFile, input.Branch)
+	}
+
+	err = u.githubRepository.TriggerWorkflow(ctx, input.Repository, input.Branch, input.WorkflowFile, input.Inputs)
 	if err != nil {
 		return nil, err
 	}
@@ -170,22 +259,105 @@ func (u useCase) TriggerWorkflow(ctx context.Context, input TriggerWorkflowInput
 	return &TriggerWorkflowOutput{}, nil
 }
 
+func (u useCase) GetRepositoryDispatchEvents(ctx context.Context, input GetRepositoryDispatchEventsInput) (*GetRepositoryDispatchEventsOutput, error) {
+	workflows, err := u.githubRepository.GetWorkflows(ctx, input.Repository)
+	if err != nil {
+		return nil, err
+	}
+
+	type listener struct {
+		path       string
+		eventTypes []string
+	}
+
+	// repository_dispatch runs the workflows of the default branch only
+	listeners, err := pkgconcurrency.Map(ctx, u.limiter, workflows, func(ctx context.Context, workflow gr.Workflow) (*listener, error) {
+		workflowData, err := u.githubRepository.InspectWorkflowContent(ctx, input.Repository, "", workflow.Path)
+		if gr.IsNotFound(err) {
+			return nil, nil // registered, but deleted from the default branch
+		} else if err != nil {
+			return nil, err
+		}
+
+		workflowContent, err := py.UnmarshalWorkflowContent(workflowData)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", workflow.Path, err)
+		}
+
+		trigger, ok := workflowContent.On.Get("repository_dispatch")
+		if !ok {
+			return nil, nil
+		}
+		return &listener{path: workflow.Path, eventTypes: trigger.Types}, nil
+	})
+
+	// Group the workflows by the event types they listen to
+	eventWorkflows := make(map[string][]string)
+	for _, l := range listeners {
+		if l == nil {
+			continue
+		}
+		if len(l.eventTypes) == 0 {
+			eventWorkflows[""] = append(eventWorkflows[""], l.path)
+		}
+		for _, eventType := range l.eventTypes {
+			eventWorkflows[eventType] = append(eventWorkflows[eventType], l.path)
+		}
+	}
+
+	var events []RepositoryDispatchEvent
+	for eventType, workflowPaths := range eventWorkflows {
+		slices.Sort(workflowPaths)
+		events = append(events, RepositoryDispatchEvent{EventType: eventType, Workflows: workflowPaths})
+	}
+	slices.SortFunc(events, func(a, b RepositoryDispatchEvent) int {
+		return strings.Compare(a.EventType, b.EventType)
+	})
+
+	return &GetRepositoryDispatchEventsOutput{Events: events}, err
+}
+
+func (u useCase) DispatchRepositoryEvent(ctx context.Context, input DispatchRepositoryEventInput) (*DispatchRepositoryEventOutput, error) {
+	err := validateRepositoryDispatch(input.EventType, input.ClientPayload)
+	if err == nil {
+		err = u.githubRepository.DispatchRepositoryEvent(ctx, input.Repository, input.EventType, input.ClientPayload)
+	}
+
+	u.audit.record(ctx, pkgaudit.Entry{
+		Action:     pkgaudit.ActionRepositoryDispatch,
+		Repository: input.Repository,
+		EventType:  input.EventType,
+		Inputs:     input.ClientPayload,
+	}, err)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DispatchRepositoryEventOutput{}, nil
+}
+
 func (u useCase) ReRunFailedJobs(ctx context.Context, input ReRunFailedJobsInput) (*ReRunFailedJobsOutput, error) {
-	if err := u.githubRepository.ReRunFailedJobs(ctx, input.Repository, input.WorkflowID); err != nil {
+	err := u.githubRepository.ReRunFailedJobs(ctx, input.Repository, input.WorkflowID)
+	u.audit.recordRun(ctx, pkgaudit.ActionReRunFailedJobs, input.Repository, input.WorkflowID, err)
+	if err != nil {
 		return nil, err
 	}
 	return &ReRunFailedJobsOutput{}, nil
 }
 
 func (u useCase) ReRunWorkflow(ctx context.Context, input ReRunWorkflowInput) (*ReRunWorkflowOutput, error) {
-	if err := u.githubRepository.ReRunWorkflow(ctx, input.Repository, input.WorkflowID); err != nil {
+	err := u.githubRepository.ReRunWorkflow(ctx, input.Repository, input.WorkflowID)
+	u.audit.recordRun(ctx, pkgaudit.ActionReRunWorkflow, input.Repository, input.WorkflowID, err)
+	if err != nil {
 		return nil, err
 	}
 	return &ReRunWorkflowOutput{}, nil
 }
 
 func (u useCase) CancelWorkflow(ctx context.Context, input CancelWorkflowInput) (*CancelWorkflowOutput, error) {
-	if err := u.githubRepository.CancelWorkflow(ctx, input.Repository, input.WorkflowID); err != nil {
+	err := u.githubRepository.CancelWorkflow(ctx, input.Repository, input.WorkflowID)
+	u.audit.recordRun(ctx, pkgaudit.ActionCancelWorkflow, input.Repository, input.WorkflowID, err)
+	if err != nil {
 		return nil, err
 	}
 	return &CancelWorkflowOutput{}, nil
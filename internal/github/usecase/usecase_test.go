@@ -4,8 +4,11 @@ import (
 	"context"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/termkit/gama/internal/github/repository"
+	pkgconcurrency "github.com/termkit/gama/pkg/concurrency"
 	pkgconfig "github.com/termkit/gama/pkg/config"
+	pkghttpclient "github.com/termkit/gama/pkg/httpclient"
 )
 
 func TestUseCase_ListRepositories(t *testing.T) {
@@ -14,10 +17,20 @@ func TestUseCase_ListRepositories(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
+	cfg, err = cfg.WithProfile(cfg.DefaultProfile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	limiter := pkgconcurrency.New(cfg.Concurrency)
+	client, err := pkghttpclient.New(cfg.Network)
+	if err != nil {
+		t.Fatal(err)
+	}
 
-	githubRepo := repository.New(cfg)
+	githubRepo := repository.New(cfg, client, limiter)
 
-	githubUseCase := New(githubRepo)
+	githubUseCase := New(githubRepo, limiter, nil)
 
 	repositories, err := githubUseCase.ListRepositories(ctx, ListRepositoriesInput{})
 	if err != nil {
@@ -32,10 +45,20 @@ func TestUseCase_InspectWorkflow(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
+	cfg, err = cfg.WithProfile(cfg.DefaultProfile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	limiter := pkgconcurrency.New(cfg.Concurrency)
+	client, err := pkghttpclient.New(cfg.Network)
+	if err != nil {
+		t.Fatal(err)
+	}
 
-	githubRepo := repository.New(cfg)
+	githubRepo := repository.New(cfg, client, limiter)
 
-	githubUseCase := New(githubRepo)
+	githubUseCase := New(githubRepo, limiter, nil)
 
 	workflow, err := githubUseCase.InspectWorkflow(ctx, InspectWorkflowInput{
 		Repository:   "canack/tc",
@@ -53,10 +76,20 @@ func TestUseCase_TriggerWorkflow(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
+	cfg, err = cfg.WithProfile(cfg.DefaultProfile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	limiter := pkgconcurrency.New(cfg.Concurrency)
+	client, err := pkghttpclient.New(cfg.Network)
+	if err != nil {
+		t.Fatal(err)
+	}
 
-	githubRepo := repository.New(cfg)
+	githubRepo := repository.New(cfg, client, limiter)
 
-	githubUseCase := New(githubRepo)
+	githubUseCase := New(githubRepo, limiter, nil)
 
 	workflow, err := githubUseCase.InspectWorkflow(ctx, InspectWorkflowInput{
 		Repository:   "canack/tc",
@@ -70,7 +103,7 @@ func TestUseCase_TriggerWorkflow(t *testing.T) {
 		}
 	}
 
-	workflowJson, err := workflow.Workflow.ToJson()
+	workflowInputs, err := workflow.Workflow.ToPayload()
 	if err != nil {
 		t.Error(err)
 	}
@@ -79,10 +112,29 @@ func TestUseCase_TriggerWorkflow(t *testing.T) {
 		WorkflowFile: ".github/workflows/dispatch_test.yaml",
 		Repository:   "canack/tc",
 		Branch:       "master",
-		Content:      workflowJson,
+		Inputs:       workflowInputs,
 	})
 	if err != nil {
 		t.Error(err)
 	}
 	t.Log(trigger)
 }
+
+func TestUseCase_GetWorkflowFile(t *testing.T) {
+	githubUseCase := New(newStubRepository(map[string]string{".github/workflows/ci.yml": pushWorkflow}), nil, nil)
+
+	output, err := githubUseCase.GetWorkflowFile(context.Background(), GetWorkflowFileInput{
+		Repository:   "owner/repo",
+		Branch:       "main",
+		WorkflowFile: ".github/workflows/ci.yml",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, pushWorkflow, output.Content)
+
+	_, err = githubUseCase.GetWorkflowFile(context.Background(), GetWorkflowFileInput{
+		Repository:   "owner/repo",
+		Branch:       "main",
+		WorkflowFile: ".github/workflows/release.yml",
+	})
+	assert.True(t, repository.IsNotFound(err))
+}
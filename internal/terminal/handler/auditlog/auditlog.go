@@ -0,0 +1,311 @@
+package auditlog
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	gu "github.com/termkit/gama/internal/github/usecase"
+	hdlerror "github.com/termkit/gama/internal/terminal/handler/error"
+	pkgaudit "github.com/termkit/gama/pkg/audit"
+)
+
+type ModelAuditLog struct {
+	// current handler's properties
+	entries       []pkgaudit.Entry // newest first
+	filtered      []pkgaudit.Entry
+	query         string
+	logSize       int64 // size of the log when it was read, the log is read again when it grows
+	confirmIndex  int   // index of the entry waiting for a second enter to be dispatched, -1 if none
+	isDispatching bool
+
+	// audit log, nil when it cannot be written
+	auditLog *pkgaudit.Log
+
+	// use cases
+	githubUseCase gu.UseCase
+
+	// keymap
+	Keys keyMap
+
+	// models
+	Help        help.Model
+	Viewport    *viewport.Model
+	modelError  hdlerror.ModelError
+	filterInput textinput.Model
+	tableAudit  table.Model
+}
+
+var baseStyle = lipgloss.NewStyle().
+	BorderStyle(lipgloss.NormalBorder()).
+	BorderForeground(lipgloss.Color("240"))
+
+func SetupModelAuditLog(githubUseCase gu.UseCase, auditLog *pkgaudit.Log) *ModelAuditLog {
+	tableAudit := table.New(
+		table.WithColumns(tableColumnsAuditLog),
+		table.WithRows([]table.Row{}),
+		table.WithHeight(7),
+	)
+
+	s := table.DefaultStyles()
+	s.Header = s.Header.
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		BorderBottom(true).
+		Bold(false)
+	s.Selected = s.Selected.
+		Foreground(lipgloss.Color("229")).
+		Background(lipgloss.Color("57")).
+		Bold(false)
+	tableAudit.SetStyles(s)
+
+	fi := textinput.New()
+	fi.Prompt = "Filter: "
+	fi.Placeholder = "login, repository, ref, workflow, action or result"
+	fi.CharLimit = 64
+	fi.Focus()
+
+	return &ModelAuditLog{
+		Help:          help.New(),
+		Keys:          keys,
+		githubUseCase: githubUseCase,
+		auditLog:      auditLog,
+		modelError:    hdlerror.SetupModelError(),
+		filterInput:   fi,
+		tableAudit:    tableAudit,
+		logSize:       -1,
+		confirmIndex:  -1,
+	}
+}
+
+// Close stops the background work of the model, it is not usable afterwards.
+// The log is read on update and a confirmed dispatch is left to finish, so
+// there is nothing to cancel.
+func (m *ModelAuditLog) Close() {}
+
+func (m *ModelAuditLog) Init() tea.Cmd {
+	if m.auditLog == nil {
+		m.modelError.SetDefaultMessage("Audit log is not available.")
+	}
+	return textinput.Blink
+}
+
+func (m *ModelAuditLog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if m.auditLog == nil {
+		return m, nil
+	}
+
+	m.readIfChanged()
+
+	var cmd tea.Cmd
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch keyMsg.String() {
+		case "up":
+			m.tableAudit.MoveUp(1)
+			m.confirmIndex = -1
+			return m, nil
+		case "down":
+			m.tableAudit.MoveDown(1)
+			m.confirmIndex = -1
+			return m, nil
+		case "ctrl+r", "ctrl+R":
+			m.logSize = -1
+			m.readIfChanged()
+			return m, nil
+		case "enter":
+			m.dispatchSelected()
+			return m, nil
+		}
+
+		m.filterInput, cmd = m.filterInput.Update(msg)
+		if m.filterInput.Value() != m.query {
+			m.query = m.filterInput.Value()
+			m.confirmIndex = -1
+			m.applyFilter()
+		}
+	}
+
+	return m, cmd
+}
+
+func (m *ModelAuditLog) View() string {
+	termWidth := m.Viewport.Width
+	termHeight := m.Viewport.Height
+
+	var tableWidth int
+	for _, t := range tableColumnsAuditLog {
+		tableWidth += t.Width
+	}
+
+	newTableColumns := tableColumnsAuditLog
+	widthDiff := termWidth - tableWidth
+	if widthDiff > 0 {
+		newTableColumns[6].Width += widthDiff - 21
+		m.tableAudit.SetColumns(newTableColumns)
+		m.tableAudit.SetHeight(termHeight - 19)
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Top,
+		m.filterInput.View(),
+		baseStyle.Render(m.tableAudit.View()))
+}
+
+// readIfChanged reads the log again when its size differs from the last read.
+func (m *ModelAuditLog) readIfChanged() {
+	var size int64
+	if info, err := os.Stat(m.auditLog.Path()); err == nil {
+		size = info.Size()
+	}
+	if size == m.logSize {
+		return
+	}
+
+	entries, err := m.auditLog.Read()
+	if err != nil {
+		m.modelError.SetError(err)
+		m.modelError.SetErrorMessage("Audit log cannot be read")
+		return
+	}
+
+	slices.Reverse(entries)
+	m.entries = entries
+	m.logSize = size
+	m.applyFilter()
+
+	if !m.isDispatching {
+		m.modelError.SetDefaultMessage(fmt.Sprintf("%d entries in %s", len(entries), m.auditLog.Path()))
+	}
+}
+
+func (m *ModelAuditLog) applyFilter() {
+	m.filtered = m.filtered[:0]
+	for _, entry := range m.entries {
+		if entry.Matches(m.query) {
+			m.filtered = append(m.filtered, entry)
+		}
+	}
+
+	var rows []table.Row
+	for i, entry := range m.filtered {
+		workflow := entry.Workflow
+		if entry.Action == pkgaudit.ActionRepositoryDispatch {
+			workflow = entry.EventType
+		}
+		if entry.RunID != 0 {
+			workflow = strings.TrimSpace(fmt.Sprintf("%s #%d", workflow, entry.RunID))
+		}
+		rows = append(rows, table.Row{
+			strconv.Itoa(i + 1),
+			entry.Time.In(time.Local).Format("2006-01-02 15:04:05"),
+			entry.Login,
+			entry.Action,
+			entry.Repository,
+			entry.Ref,
+			workflow,
+			entry.Result,
+		})
+	}
+
+	m.tableAudit.SetRows(rows)
+	if m.tableAudit.Cursor() >= len(rows) {
+		m.tableAudit.SetCursor(max(len(rows)-1, 0))
+	}
+}
+
+// dispatchSelected sends the selected workflow or repository dispatch again, after a second enter.
+func (m *ModelAuditLog) dispatchSelected() {
+	index := m.tableAudit.Cursor()
+	if m.isDispatching || index < 0 || index >= len(m.filtered) {
+		return
+	}
+
+	entry := m.filtered[index]
+	if entry.Action != pkgaudit.ActionTriggerWorkflow && entry.Action != pkgaudit.ActionRepositoryDispatch {
+		m.modelError.SetDefaultMessage("Only triggered workflows and sent events can be dispatched again.")
+		return
+	}
+
+	if !entry.SentWith(m.githubUseCase.Profile(), m.githubUseCase.APIURL()) {
+		m.modelError.SetErrorMessage(fmt.Sprintf("This entry was sent with profile %q to %s, switch to it to dispatch it again.",
+			entry.Profile, entry.APIURL))
+		return
+	}
+
+	if m.confirmIndex != index {
+		m.confirmIndex = index
+		if entry.Action == pkgaudit.ActionRepositoryDispatch {
+			m.modelError.SetDefaultMessage(fmt.Sprintf("Press enter again to send %s to %s with the same client payload.",
+				entry.EventType, entry.Repository))
+		} else {
+			m.modelError.SetDefaultMessage(fmt.Sprintf("Press enter again to trigger %s on %s@%s with the same inputs.",
+				entry.Workflow, entry.Repository, entry.Ref))
+		}
+		return
+	}
+
+	m.confirmIndex = -1
+	m.isDispatching = true
+	go m.dispatch(entry)
+}
+
+func (m *ModelAuditLog) dispatch(entry pkgaudit.Entry) {
+	defer func() {
+		m.isDispatching = false
+		go m.Update(m) // update model
+	}()
+
+	m.modelError.Reset()
+	if entry.Action == pkgaudit.ActionRepositoryDispatch {
+		m.sendEvent(entry)
+		return
+	}
+
+	m.modelError.SetProgressMessage(fmt.Sprintf("[%s@%s]:[%s] Triggering workflow...",
+		entry.Repository, entry.Ref, entry.Workflow))
+
+	_, err := m.githubUseCase.TriggerWorkflow(context.Background(), gu.TriggerWorkflowInput{
+		Repository:   entry.Repository,
+		Branch:       entry.Ref,
+		WorkflowFile: entry.Workflow,
+		Inputs:       entry.Inputs,
+	})
+	if err != nil {
+		m.modelError.SetError(err)
+		m.modelError.SetErrorMessage("Workflow cannot be triggered")
+		return
+	}
+
+	m.modelError.SetSuccessMessage(fmt.Sprintf("[%s@%s]:[%s] Workflow triggered.",
+		entry.Repository, entry.Ref, entry.Workflow))
+}
+
+func (m *ModelAuditLog) sendEvent(entry pkgaudit.Entry) {
+	m.modelError.SetProgressMessage(fmt.Sprintf("[%s] Sending %s event...", entry.Repository, entry.EventType))
+
+	_, err := m.githubUseCase.DispatchRepositoryEvent(context.Background(), gu.DispatchRepositoryEventInput{
+		Repository:    entry.Repository,
+		EventType:     entry.EventType,
+		ClientPayload: entry.Inputs,
+	})
+	if err != nil {
+		m.modelError.SetError(err)
+		m.modelError.SetErrorMessage("Event cannot be sent")
+		return
+	}
+
+	m.modelError.SetSuccessMessage(fmt.Sprintf("[%s] %s event sent.", entry.Repository, entry.EventType))
+}
+
+func (m *ModelAuditLog) ViewStatus() string {
+	return m.modelError.View()
+}
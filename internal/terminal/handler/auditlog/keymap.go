@@ -0,0 +1,48 @@
+package auditlog
+
+import (
+	teakey "github.com/charmbracelet/bubbles/key"
+)
+
+type keyMap struct {
+	TabSwitch teakey.Binding
+	Filter    teakey.Binding
+	Dispatch  teakey.Binding
+	Refresh   teakey.Binding
+}
+
+func (k keyMap) ShortHelp() []teakey.Binding {
+	return []teakey.Binding{k.TabSwitch, k.Filter, k.Refresh, k.Dispatch}
+}
+
+func (k keyMap) FullHelp() [][]teakey.Binding {
+	return [][]teakey.Binding{
+		{k.TabSwitch},
+		{k.Filter},
+		{k.Refresh},
+		{k.Dispatch},
+	}
+}
+
+var keys = keyMap{
+	TabSwitch: teakey.NewBinding(
+		teakey.WithKeys(""), // help-only binding
+		teakey.WithHelp("shift + (← | →)", "switch tab"),
+	),
+	Filter: teakey.NewBinding(
+		teakey.WithKeys(""), // help-only binding
+		teakey.WithHelp("type", "filter entries"),
+	),
+	Refresh: teakey.NewBinding(
+		teakey.WithKeys("ctrl+r", "ctrl+R"),
+		teakey.WithHelp("ctrl+r", "Refresh log"),
+	),
+	Dispatch: teakey.NewBinding(
+		teakey.WithKeys("enter"),
+		teakey.WithHelp("enter", "dispatch again with the same inputs"),
+	),
+}
+
+func (m *ModelAuditLog) ViewHelp() string {
+	return m.Help.View(m.Keys)
+}
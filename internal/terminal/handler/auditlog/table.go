@@ -0,0 +1,16 @@
+package auditlog
+
+import (
+	"github.com/charmbracelet/bubbles/table"
+)
+
+var tableColumnsAuditLog = []table.Column{
+	{Title: "#", Width: 4},
+	{Title: "Time", Width: 19},
+	{Title: "Login", Width: 14},
+	{Title: "Action", Width: 17},
+	{Title: "Repository", Width: 24},
+	{Title: "Ref", Width: 16},
+	{Title: "Workflow", Width: 28},
+	{Title: "Result", Width: 8},
+}
@@ -2,6 +2,7 @@ package error
 
 import (
 	"fmt"
+	"log/slog"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
@@ -49,6 +50,9 @@ func (m *ModelError) SetError(err error) {
 
 func (m *ModelError) SetErrorMessage(errorMessage string) {
 	m.errorMessage = errorMessage
+
+	// every use case error ends up here before it is shown
+	slog.Error(errorMessage, "error", m.err)
 }
 
 func (m *ModelError) SetProgressMessage(message string) {
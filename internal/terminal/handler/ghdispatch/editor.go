@@ -0,0 +1,40 @@
+package ghdispatch
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	hdltypes "github.com/termkit/gama/internal/terminal/handler/types"
+)
+
+// openEditor writes content to a temporary file and opens it in $VISUAL or
+// $EDITOR, the terminal is handed over to the editor until it exits.
+func openEditor(content string) tea.Cmd {
+	file, err := os.CreateTemp("", "gama-client-payload-*.json")
+	if err != nil {
+		return func() tea.Msg { return hdltypes.EditorFinishedMsg{Err: err} }
+	}
+	defer file.Close()
+
+	if _, err := file.WriteString(content + "\n"); err != nil {
+		return func() tea.Msg { return hdltypes.EditorFinishedMsg{Path: file.Name(), Err: err} }
+	}
+
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+
+	// Editors may come with arguments, e.g. "code --wait"
+	args := strings.Fields(editor)
+	cmd := exec.Command(args[0], append(args[1:], file.Name())...)
+
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return hdltypes.EditorFinishedMsg{Path: file.Name(), Err: err}
+	})
+}
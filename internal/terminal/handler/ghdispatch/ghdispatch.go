@@ -0,0 +1,415 @@
+package ghdispatch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	gu "github.com/termkit/gama/internal/github/usecase"
+	hdlerror "github.com/termkit/gama/internal/terminal/handler/error"
+	hdltypes "github.com/termkit/gama/internal/terminal/handler/types"
+)
+
+// anyEventType is shown for workflows that run on every event type.
+const anyEventType = "(any)"
+
+type focus int
+
+const (
+	focusEvents focus = iota
+	focusEventType
+	focusPayload
+	focusSend
+)
+
+type ModelGithubDispatch struct {
+	// current handler's properties
+	syncEventsContext context.Context
+	cancelSyncEvents  context.CancelFunc
+	closeContext      context.Context
+	close             context.CancelFunc
+	lastRepository    string
+	events            []gu.RepositoryDispatchEvent
+	focus             focus
+	payloadError      string // validation error of the payload, shown below it
+	isSending         bool
+
+	// shared properties
+	SelectedRepository *hdltypes.SelectedRepository
+
+	// use cases
+	githubUseCase gu.UseCase
+
+	// keymap
+	Keys keyMap
+
+	// models
+	Help           help.Model
+	Viewport       *viewport.Model
+	modelError     hdlerror.ModelError
+	tableEvents    table.Model
+	eventTypeInput textinput.Model
+	payloadInput   textarea.Model
+}
+
+var baseStyle = lipgloss.NewStyle().
+	BorderStyle(lipgloss.NormalBorder()).
+	BorderForeground(lipgloss.Color("240"))
+
+func SetupModelGithubDispatch(githubUseCase gu.UseCase, selectedRepository *hdltypes.SelectedRepository) *ModelGithubDispatch {
+	tableEvents := table.New(
+		table.WithColumns(tableColumnsEvents),
+		table.WithRows([]table.Row{}),
+		table.WithFocused(true),
+		table.WithHeight(5),
+	)
+
+	s := table.DefaultStyles()
+	s.Header = s.Header.
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		BorderBottom(true).
+		Bold(false)
+	s.Selected = s.Selected.
+		Foreground(lipgloss.Color("229")).
+		Background(lipgloss.Color("57")).
+		Bold(false)
+	tableEvents.SetStyles(s)
+
+	ti := textinput.New()
+	ti.Prompt = "Event type: "
+	ti.Placeholder = "pick one above or type it"
+	ti.CharLimit = 100
+
+	ta := textarea.New()
+	ta.Placeholder = `{"key": "value"}`
+	ta.ShowLineNumbers = true
+	ta.SetValue("{}")
+	ta.SetHeight(6)
+
+	closeContext, closeFunc := context.WithCancel(context.Background())
+
+	return &ModelGithubDispatch{
+		Help:               help.New(),
+		Keys:               keys,
+		githubUseCase:      githubUseCase,
+		SelectedRepository: selectedRepository,
+		modelError:         hdlerror.SetupModelError(),
+		tableEvents:        tableEvents,
+		eventTypeInput:     ti,
+		payloadInput:       ta,
+		syncEventsContext:  context.Background(),
+		cancelSyncEvents:   func() {},
+		closeContext:       closeContext,
+		close:              closeFunc,
+	}
+}
+
+// Close stops the background work of the model, it is not usable afterwards.
+func (m *ModelGithubDispatch) Close() {
+	m.cancelSyncEvents()
+	m.close()
+}
+
+func (m *ModelGithubDispatch) Init() tea.Cmd {
+	m.modelError.SetDefaultMessage("No repository selected.")
+	return textinput.Blink
+}
+
+func (m *ModelGithubDispatch) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if m.SelectedRepository.RepositoryName == "" {
+		return m, nil
+	}
+
+	if m.lastRepository != m.SelectedRepository.RepositoryName {
+		m.cancelSyncEvents() // cancel previous sync
+		m.syncEventsContext, m.cancelSyncEvents = context.WithCancel(m.closeContext)
+		m.lastRepository = m.SelectedRepository.RepositoryName
+
+		go m.syncEvents(m.syncEventsContext)
+	}
+
+	var cmd tea.Cmd
+	switch msg := msg.(type) {
+	case hdltypes.EditorFinishedMsg:
+		m.readEditedPayload(msg)
+		return m, nil
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "tab":
+			m.setFocus((m.focus + 1) % (focusSend + 1))
+			return m, nil
+		case "ctrl+e":
+			return m, openEditor(m.payloadInput.Value())
+		case "ctrl+r", "ctrl+R":
+			go m.syncEvents(m.syncEventsContext)
+			return m, nil
+		case "enter":
+			switch m.focus {
+			case focusEvents:
+				m.useSelectedEventType()
+				return m, nil
+			case focusEventType:
+				m.setFocus(focusPayload)
+				return m, nil
+			case focusSend:
+				m.send()
+				return m, nil
+			}
+		}
+	}
+
+	switch m.focus {
+	case focusEvents:
+		m.tableEvents, cmd = m.tableEvents.Update(msg)
+	case focusEventType:
+		m.eventTypeInput, cmd = m.eventTypeInput.Update(msg)
+	case focusPayload:
+		m.payloadInput, cmd = m.payloadInput.Update(msg)
+		m.validatePayload()
+	}
+
+	return m, cmd
+}
+
+func (m *ModelGithubDispatch) View() string {
+	termWidth := m.Viewport.Width
+
+	var tableWidth int
+	for _, t := range tableColumnsEvents {
+		tableWidth += t.Width
+	}
+
+	newTableColumns := tableColumnsEvents
+	widthDiff := termWidth - tableWidth
+	if widthDiff > 0 {
+		newTableColumns[1].Width += widthDiff - 11
+		m.tableEvents.SetColumns(newTableColumns)
+	}
+	m.payloadInput.SetWidth(*hdltypes.ScreenWidth - 13)
+
+	inputStyle := lipgloss.NewStyle().
+		Border(lipgloss.NormalBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		Padding(0, 1).
+		Width(*hdltypes.ScreenWidth - 9)
+	focusedInputStyle := inputStyle.Copy().BorderForeground(lipgloss.Color("130"))
+
+	styleFor := func(f focus) lipgloss.Style {
+		if m.focus == f {
+			return focusedInputStyle
+		}
+		return inputStyle
+	}
+
+	payload := "Client payload (JSON object)\n" + m.payloadInput.View()
+	if m.payloadError != "" {
+		payload += "\n" + lipgloss.NewStyle().Foreground(lipgloss.Color("9")).Render(m.payloadError)
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Top,
+		baseStyle.Render(m.tableEvents.View()),
+		styleFor(focusEventType).Render(m.eventTypeInput.View()),
+		styleFor(focusPayload).Render(payload),
+		m.sendButton())
+}
+
+func (m *ModelGithubDispatch) syncEvents(ctx context.Context) {
+	m.modelError.Reset()
+	m.modelError.SetProgressMessage(fmt.Sprintf("[%s] Fetching repository_dispatch event types...", m.SelectedRepository.RepositoryName))
+
+	m.tableEvents.SetRows([]table.Row{})
+
+	output, err := m.githubUseCase.GetRepositoryDispatchEvents(ctx, gu.GetRepositoryDispatchEventsInput{
+		Repository: m.SelectedRepository.RepositoryName,
+	})
+	if errors.Is(err, context.Canceled) {
+		return
+	} else if err != nil {
+		m.modelError.SetError(err)
+		m.modelError.SetErrorMessage("Event types cannot be listed")
+		return
+	}
+
+	m.events = output.Events
+
+	var rows []table.Row
+	for _, event := range m.events {
+		eventType := event.EventType
+		if eventType == "" {
+			eventType = anyEventType
+		}
+		rows = append(rows, table.Row{eventType, strings.Join(event.Workflows, ", ")})
+	}
+	m.tableEvents.SetRows(rows)
+	m.tableEvents.SetCursor(0)
+
+	if len(rows) == 0 {
+		m.modelError.SetDefaultMessage(fmt.Sprintf("[%s] No workflow listens to repository_dispatch, any event type can still be sent.", m.SelectedRepository.RepositoryName))
+	} else {
+		m.modelError.SetSuccessMessage(fmt.Sprintf("[%s] Event types fetched.", m.SelectedRepository.RepositoryName))
+	}
+
+	go m.Update(m) // update model
+}
+
+func (m *ModelGithubDispatch) setFocus(f focus) {
+	m.focus = f
+
+	m.tableEvents.Blur()
+	m.eventTypeInput.Blur()
+	m.payloadInput.Blur()
+
+	switch f {
+	case focusEvents:
+		m.tableEvents.Focus()
+	case focusEventType:
+		m.eventTypeInput.Focus()
+	case focusPayload:
+		m.payloadInput.Focus()
+	}
+}
+
+func (m *ModelGithubDispatch) useSelectedEventType() {
+	selectedRow := m.tableEvents.SelectedRow()
+	if len(selectedRow) == 0 {
+		m.setFocus(focusEventType)
+		return
+	}
+
+	if selectedRow[0] != anyEventType {
+		m.eventTypeInput.SetValue(selectedRow[0])
+		m.eventTypeInput.CursorEnd()
+	}
+	m.setFocus(focusEventType)
+}
+
+// validatePayload shows the error of the payload and reports whether it is valid.
+func (m *ModelGithubDispatch) validatePayload() bool {
+	if _, err := gu.ParseClientPayload(m.payloadInput.Value()); err != nil {
+		m.payloadError = err.Error()
+		return false
+	}
+	m.payloadError = ""
+	return true
+}
+
+func (m *ModelGithubDispatch) readEditedPayload(msg hdltypes.EditorFinishedMsg) {
+	if msg.Path != "" {
+		defer os.Remove(msg.Path)
+	}
+	if msg.Err != nil {
+		m.modelError.SetError(msg.Err)
+		m.modelError.SetErrorMessage("Editor cannot be opened, set $EDITOR to your editor")
+		return
+	}
+
+	data, err := os.ReadFile(msg.Path)
+	if err != nil {
+		m.modelError.SetError(err)
+		m.modelError.SetErrorMessage("Edited payload cannot be read")
+		return
+	}
+
+	m.payloadInput.SetValue(strings.TrimSpace(string(data)))
+	m.setFocus(focusPayload)
+	if m.validatePayload() {
+		m.modelError.SetSuccessMessage("Client payload updated.")
+	} else {
+		m.modelError.SetDefaultMessage("Client payload is invalid, see the error below it.")
+	}
+}
+
+func (m *ModelGithubDispatch) send() {
+	if m.isSending {
+		return
+	}
+
+	eventType := strings.TrimSpace(m.eventTypeInput.Value())
+	if eventType == "" {
+		m.modelError.SetError(errors.New("event type cannot be empty"))
+		m.modelError.SetErrorMessage("Pick or type an event type before sending")
+		return
+	}
+
+	clientPayload, err := gu.ParseClientPayload(m.payloadInput.Value())
+	if err != nil {
+		m.payloadError = err.Error()
+		m.modelError.SetError(err)
+		m.modelError.SetErrorMessage("Fix the client payload before sending")
+		return
+	}
+
+	m.isSending = true
+	go func() {
+		defer func() {
+			m.isSending = false
+			go m.Update(m) // update model
+		}()
+
+		repository := m.SelectedRepository.RepositoryName
+		m.modelError.Reset()
+		m.modelError.SetProgressMessage(fmt.Sprintf("[%s] Sending %s event...", repository, eventType))
+
+		_, err := m.githubUseCase.DispatchRepositoryEvent(context.Background(), gu.DispatchRepositoryEventInput{
+			Repository:    repository,
+			EventType:     eventType,
+			ClientPayload: clientPayload,
+		})
+		if err != nil {
+			m.modelError.SetError(err)
+			m.modelError.SetErrorMessage("Event cannot be sent")
+			return
+		}
+
+		m.modelError.SetSuccessMessage(fmt.Sprintf("[%s] %s event sent, %s.", repository, eventType, m.listeningWorkflows(eventType)))
+	}()
+}
+
+// listeningWorkflows describes the workflows the event type runs.
+func (m *ModelGithubDispatch) listeningWorkflows(eventType string) string {
+	var count int
+	for _, event := range m.events {
+		if event.EventType == eventType || event.EventType == "" {
+			count += len(event.Workflows)
+		}
+	}
+
+	switch count {
+	case 0:
+		return "no known workflow listens to it"
+	case 1:
+		return "1 workflow listens to it"
+	}
+	return fmt.Sprintf("%d workflows listen to it", count)
+}
+
+func (m *ModelGithubDispatch) sendButton() string {
+	button := lipgloss.NewStyle().
+		Border(lipgloss.NormalBorder()).
+		BorderForeground(lipgloss.Color("255")).
+		Padding(0, 1).
+		Align(lipgloss.Center)
+
+	if m.focus == focusSend {
+		button = button.Copy().
+			BorderForeground(lipgloss.Color("130")).
+			Foreground(lipgloss.Color("130")).
+			BorderStyle(lipgloss.DoubleBorder())
+	}
+
+	return button.Render("Send")
+}
+
+func (m *ModelGithubDispatch) ViewStatus() string {
+	return m.modelError.View()
+}
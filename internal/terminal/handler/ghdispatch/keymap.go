@@ -0,0 +1,54 @@
+package ghdispatch
+
+import (
+	teakey "github.com/charmbracelet/bubbles/key"
+)
+
+type keyMap struct {
+	TabSwitch  teakey.Binding
+	SwitchPart teakey.Binding
+	Select     teakey.Binding
+	Editor     teakey.Binding
+	Refresh    teakey.Binding
+}
+
+func (k keyMap) ShortHelp() []teakey.Binding {
+	return []teakey.Binding{k.TabSwitch, k.SwitchPart, k.Select, k.Editor, k.Refresh}
+}
+
+func (k keyMap) FullHelp() [][]teakey.Binding {
+	return [][]teakey.Binding{
+		{k.TabSwitch},
+		{k.SwitchPart},
+		{k.Select},
+		{k.Editor},
+		{k.Refresh},
+	}
+}
+
+var keys = keyMap{
+	TabSwitch: teakey.NewBinding(
+		teakey.WithKeys(""), // help-only binding
+		teakey.WithHelp("shift + (← | →)", "switch tab"),
+	),
+	SwitchPart: teakey.NewBinding(
+		teakey.WithKeys("tab"),
+		teakey.WithHelp("tab", "next field"),
+	),
+	Select: teakey.NewBinding(
+		teakey.WithKeys("enter"),
+		teakey.WithHelp("enter", "use event type / send"),
+	),
+	Editor: teakey.NewBinding(
+		teakey.WithKeys("ctrl+e"),
+		teakey.WithHelp("ctrl+e", "edit payload in $EDITOR"),
+	),
+	Refresh: teakey.NewBinding(
+		teakey.WithKeys("ctrl+r", "ctrl+R"),
+		teakey.WithHelp("ctrl+r", "Refresh event types"),
+	),
+}
+
+func (m *ModelGithubDispatch) ViewHelp() string {
+	return m.Help.View(m.Keys)
+}
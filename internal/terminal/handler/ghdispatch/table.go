@@ -0,0 +1,10 @@
+package ghdispatch
+
+import (
+	"github.com/charmbracelet/bubbles/table"
+)
+
+var tableColumnsEvents = []table.Column{
+	{Title: "Event Type", Width: 28},
+	{Title: "Workflows", Width: 56},
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -24,7 +25,12 @@ type ModelGithubRepository struct {
 	// current handler's properties
 	syncRepositoriesContext context.Context
 	cancelSyncRepositories  context.CancelFunc
+	closeContext            context.Context
+	close                   context.CancelFunc
 	tableReady              bool
+	repositories            []gu.GithubRepository // sorted by last update, newest first
+	securityReport          *securityReport       // nil when the security report is not shown
+	reusableWorkflows       *reusableWorkflows    // nil when the reusable workflows are not shown
 
 	// shared properties
 	SelectedRepository *hdltypes.SelectedRepository
@@ -36,10 +42,12 @@ type ModelGithubRepository struct {
 	Keys keyMap
 
 	// models
-	Help                  help.Model
-	Viewport              *viewport.Model
-	tableGithubRepository table.Model
-	modelError            hdlerror.ModelError
+	Help                   help.Model
+	Viewport               *viewport.Model
+	tableGithubRepository  table.Model
+	tableSecurityReport    table.Model
+	tableReusableWorkflows table.Model
+	modelError             hdlerror.ModelError
 
 	modelTabOptions       tea.Model
 	actualModelTabOptions *taboptions.Options
@@ -71,31 +79,63 @@ func SetupModelGithubRepository(githubUseCase gu.UseCase, selectedRepository *hd
 		Bold(false)
 	tableGithubRepository.SetStyles(s)
 
+	tableSecurityReport := table.New(
+		table.WithColumns(tableColumnsSecurityReport),
+		table.WithFocused(true),
+		table.WithHeight(13),
+	)
+	tableSecurityReport.SetStyles(s)
+
+	tableReusableWorkflows := table.New(
+		table.WithColumns(tableColumnsReusableWorkflows),
+		table.WithFocused(true),
+		table.WithHeight(13),
+	)
+	tableReusableWorkflows.SetStyles(s)
+
 	// setup models
 	modelError := hdlerror.SetupModelError()
 	tabOptions := taboptions.NewOptions()
 
+	closeContext, closeFunc := context.WithCancel(context.Background())
+
 	return &ModelGithubRepository{
 		Help:                    help.New(),
 		Keys:                    keys,
 		githubUseCase:           githubUseCase,
 		tableGithubRepository:   tableGithubRepository,
+		tableSecurityReport:     tableSecurityReport,
+		tableReusableWorkflows:  tableReusableWorkflows,
 		modelError:              modelError,
 		SelectedRepository:      selectedRepository,
 		modelTabOptions:         tabOptions,
 		actualModelTabOptions:   tabOptions,
-		syncRepositoriesContext: context.Background(),
+		syncRepositoriesContext: closeContext,
 		cancelSyncRepositories:  func() {},
+		closeContext:            closeContext,
+		close:                   closeFunc,
 	}
 }
 
+// Close stops the background work of the model, it is not usable afterwards.
+func (m *ModelGithubRepository) Close() {
+	m.cancelSyncRepositories()
+	if m.securityReport != nil && m.securityReport.cancel != nil {
+		m.securityReport.cancel()
+	}
+	if m.reusableWorkflows != nil && m.reusableWorkflows.cancel != nil {
+		m.reusableWorkflows.cancel()
+	}
+	m.close()
+}
+
 func (m *ModelGithubRepository) Init() tea.Cmd {
 	go m.syncRepositories(m.syncRepositoriesContext)
 
 	openInBrowser := func() {
 		m.modelError.SetProgressMessage(fmt.Sprintf("Opening in browser..."))
 
-		err := browser.OpenInBrowser(fmt.Sprintf("https://github.com/%s", m.SelectedRepository.RepositoryName))
+		err := browser.OpenInBrowser(fmt.Sprintf("%s/%s", m.githubUseCase.WebURL(), m.SelectedRepository.RepositoryName))
 		if err != nil {
 			m.modelError.SetError(err)
 			m.modelError.SetErrorMessage(fmt.Sprintf("Cannot open in browser: %v", err))
@@ -116,37 +156,73 @@ func (m *ModelGithubRepository) syncRepositories(ctx context.Context) {
 	m.modelError.SetProgressMessage("Fetching repositories...")
 
 	// delete all rows
+	m.repositories = nil
 	m.tableGithubRepository.SetRows([]table.Row{})
 
-	repositories, err := m.githubUseCase.ListRepositories(ctx, gu.ListRepositoriesInput{})
+	var failed int
+	err := m.githubUseCase.StreamRepositories(ctx, gu.ListRepositoriesInput{}, func(progress gu.StreamRepositoriesProgress) {
+		if ctx.Err() != nil {
+			return // a newer sync owns the table
+		}
+
+		if progress.Err != nil {
+			failed++
+		} else {
+			m.addRepository(progress.Repository)
+			m.tableReady = true // rows are navigable while loading continues
+		}
+
+		m.modelError.SetProgressMessage(fmt.Sprintf("Fetching repositories... %d/%d repos", progress.Done, progress.Total))
+		go m.Update(m) // update model
+	})
 	if errors.Is(err, context.Canceled) {
 		return
-	} else if err != nil {
+	} else if err != nil && len(m.repositories) == 0 {
 		m.modelError.SetError(err)
 		m.modelError.SetErrorMessage("Repositories cannot be listed")
 		return
 	}
 
-	if len(repositories.Repositories) == 0 {
+	if len(m.repositories) == 0 {
 		m.actualModelTabOptions.SetStatus(taboptions.OptionNone)
 		m.modelError.SetDefaultMessage("No repositories found")
 		return
 	}
 
-	tableRowsGithubRepository := make([]table.Row, 0, len(repositories.Repositories))
-	for _, repository := range repositories.Repositories {
+	if err != nil {
+		m.modelError.SetError(err)
+		m.modelError.SetErrorMessage(fmt.Sprintf("Workflows of %d repositories cannot be listed", failed))
+	} else {
+		m.modelError.SetSuccessMessage("Repositories fetched")
+	}
+	go m.Update(m) // update model
+}
+
+// addRepository inserts the repository by last update, keeping the cursor on
+// the repository the user had selected.
+func (m *ModelGithubRepository) addRepository(repository gu.GithubRepository) {
+	var selected string
+	if selectedRow := m.tableGithubRepository.SelectedRow(); len(selectedRow) > 0 {
+		selected = selectedRow[0]
+	}
+
+	index, _ := slices.BinarySearchFunc(m.repositories, repository, func(a, b gu.GithubRepository) int {
+		return b.LastUpdated.Compare(a.LastUpdated)
+	})
+	m.repositories = slices.Insert(m.repositories, index, repository)
+
+	tableRowsGithubRepository := make([]table.Row, 0, len(m.repositories))
+	cursor := 0
+	for i, repository := range m.repositories {
+		if repository.Name == selected {
+			cursor = i
+		}
 		tableRowsGithubRepository = append(tableRowsGithubRepository,
 			table.Row{repository.Name, repository.DefaultBranch, strconv.Itoa(repository.Stars), strconv.Itoa(len(repository.Workflows))})
 	}
 
 	m.tableGithubRepository.SetRows(tableRowsGithubRepository)
-
-	// set cursor to 0
-	m.tableGithubRepository.SetCursor(0)
-
-	m.tableReady = true
-	m.modelError.SetSuccessMessage("Repositories fetched")
-	go m.Update(m) // update model
+	m.tableGithubRepository.SetCursor(cursor)
 }
 
 func (m *ModelGithubRepository) handleTableInputs(ctx context.Context) {
@@ -171,11 +247,24 @@ func (m *ModelGithubRepository) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if m.securityReport != nil {
+			return m, m.updateSecurityReport(msg)
+		}
+		if m.reusableWorkflows != nil {
+			return m, m.updateReusableWorkflows(msg)
+		}
+
 		switch {
+		case key.Matches(msg, m.Keys.SecurityReport):
+			m.openSecurityReport()
+			return m, nil
+		case key.Matches(msg, m.Keys.ReusableWorkflows):
+			m.openReusableWorkflows()
+			return m, nil
 		case key.Matches(msg, m.Keys.Refresh):
 			m.tableReady = false       // reset table ready status
 			m.cancelSyncRepositories() // cancel previous sync
-			m.syncRepositoriesContext, m.cancelSyncRepositories = context.WithCancel(context.Background())
+			m.syncRepositoriesContext, m.cancelSyncRepositories = context.WithCancel(m.closeContext)
 			go m.syncRepositories(m.syncRepositoriesContext)
 		}
 	}
@@ -192,6 +281,13 @@ func (m *ModelGithubRepository) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m *ModelGithubRepository) View() string {
+	if m.securityReport != nil {
+		return m.securityReportView()
+	}
+	if m.reusableWorkflows != nil {
+		return m.reusableWorkflowsView()
+	}
+
 	termWidth := m.Viewport.Width
 	termHeight := m.Viewport.Height
 
@@ -5,13 +5,15 @@ import (
 )
 
 type keyMap struct {
-	Refresh   teakey.Binding
-	LaunchTab teakey.Binding
-	TabSwitch teakey.Binding
+	Refresh           teakey.Binding
+	LaunchTab         teakey.Binding
+	TabSwitch         teakey.Binding
+	SecurityReport    teakey.Binding
+	ReusableWorkflows teakey.Binding
 }
 
 func (k keyMap) ShortHelp() []teakey.Binding {
-	return []teakey.Binding{k.TabSwitch, k.Refresh, k.LaunchTab}
+	return []teakey.Binding{k.TabSwitch, k.Refresh, k.LaunchTab, k.SecurityReport, k.ReusableWorkflows}
 }
 
 func (k keyMap) FullHelp() [][]teakey.Binding {
@@ -19,6 +21,7 @@ func (k keyMap) FullHelp() [][]teakey.Binding {
 		{k.TabSwitch},
 		{k.Refresh},
 		{k.LaunchTab},
+		{k.SecurityReport, k.ReusableWorkflows},
 	}
 }
 
@@ -35,6 +38,14 @@ var keys = keyMap{
 		teakey.WithKeys(""), // help-only binding
 		teakey.WithHelp("shift + (← | →)", "switch tab"),
 	),
+	SecurityReport: teakey.NewBinding(
+		teakey.WithKeys("s"),
+		teakey.WithHelp("s", "Security report"),
+	),
+	ReusableWorkflows: teakey.NewBinding(
+		teakey.WithKeys("u"),
+		teakey.WithHelp("u", "Reusable workflows"),
+	),
 }
 
 func (m *ModelGithubRepository) ViewHelp() string {
@@ -0,0 +1,354 @@
+package ghrepository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	gu "github.com/termkit/gama/internal/github/usecase"
+	hdltypes "github.com/termkit/gama/internal/terminal/handler/types"
+	"github.com/termkit/gama/pkg/browser"
+	py "github.com/termkit/gama/pkg/yaml"
+)
+
+// reusableDetailLines is the number of lines of the details of the selected workflow.
+const reusableDetailLines = 6
+
+// reusableWorkflows lists the workflows of a repository with the interface of
+// the reusable ones, the workflows they call and the workflows calling them.
+type reusableWorkflows struct {
+	repository string
+	branch     string
+	workflows  []gu.WorkflowCalls // in the order of the rows
+
+	context context.Context
+	cancel  context.CancelFunc
+}
+
+func (m *ModelGithubRepository) openReusableWorkflows() {
+	selectedRow := m.tableGithubRepository.SelectedRow()
+	if !m.tableReady || len(selectedRow) == 0 {
+		return
+	}
+
+	m.reusableWorkflows = &reusableWorkflows{repository: selectedRow[0], branch: selectedRow[1]}
+	m.syncReusableWorkflows()
+}
+
+func (m *ModelGithubRepository) closeReusableWorkflows() {
+	m.reusableWorkflows.cancel()
+	m.reusableWorkflows = nil
+	m.modelError.Reset()
+}
+
+func (m *ModelGithubRepository) syncReusableWorkflows() {
+	reusable := m.reusableWorkflows
+	if reusable.cancel != nil {
+		reusable.cancel() // cancel previous fetch
+	}
+	reusable.context, reusable.cancel = context.WithCancel(m.closeContext)
+	reusable.workflows = nil
+	m.tableReusableWorkflows.SetRows([]table.Row{})
+
+	go m.fetchReusableWorkflows(reusable.context, reusable)
+}
+
+func (m *ModelGithubRepository) fetchReusableWorkflows(ctx context.Context, reusable *reusableWorkflows) {
+	m.modelError.Reset()
+	m.modelError.SetProgressMessage(fmt.Sprintf("[%s@%s] Resolving reusable workflows...", reusable.repository, reusable.branch))
+
+	output, err := m.githubUseCase.GetReusableWorkflows(ctx, gu.GetReusableWorkflowsInput{
+		Repository: reusable.repository,
+		Branch:     reusable.branch,
+	})
+	if errors.Is(err, context.Canceled) {
+		return
+	} else if err != nil {
+		m.modelError.SetError(err)
+		m.modelError.SetErrorMessage("Reusable workflows cannot be resolved")
+		return
+	}
+
+	// Reusable workflows and the workflows calling them first
+	reusable.workflows = slices.Clone(output.Workflows)
+	slices.SortStableFunc(reusable.workflows, func(a, b gu.WorkflowCalls) int {
+		return reusableRank(a) - reusableRank(b)
+	})
+
+	var rows []table.Row
+	var reusableCount, calls, problems int
+	for _, workflow := range reusable.workflows {
+		if workflow.Interface != nil {
+			reusableCount++
+		}
+		calls += len(workflow.Calls)
+		problems += workflowProblems(workflow)
+
+		rows = append(rows, table.Row{
+			shortWorkflowFile(workflow.WorkflowFile),
+			workflowInterfaceSummary(workflow),
+			workflowCallsSummary(workflow),
+			strings.Join(shortWorkflowFiles(workflow.CalledBy), ", "),
+		})
+	}
+	m.tableReusableWorkflows.SetRows(rows)
+	m.tableReusableWorkflows.SetCursor(0)
+
+	summary := fmt.Sprintf("[%s@%s] %d workflows, %d reusable, %d calls, %d problems.",
+		reusable.repository, reusable.branch, len(reusable.workflows), reusableCount, calls, problems)
+	if len(rows) > 0 {
+		summary += " enter to open the workflow on GitHub, r to resolve again, esc to close"
+	}
+	m.modelError.SetDefaultMessage(summary)
+
+	go m.Update(m) // update model
+}
+
+func reusableRank(workflow gu.WorkflowCalls) int {
+	switch {
+	case workflow.Interface != nil:
+		return 0
+	case len(workflow.Calls) > 0:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// workflowProblems counts the calls of a workflow that cannot be resolved and
+// the findings of the calls, an unreadable workflow is a problem on its own.
+func workflowProblems(workflow gu.WorkflowCalls) int {
+	if workflow.Err != nil {
+		return 1
+	}
+	var problems int
+	for _, call := range workflow.Calls {
+		if call.Err != nil {
+			problems++
+		}
+		problems += len(call.Findings)
+	}
+	return problems
+}
+
+func workflowInterfaceSummary(workflow gu.WorkflowCalls) string {
+	switch {
+	case workflow.Err != nil:
+		return "error"
+	case workflow.Interface == nil:
+		return "-"
+	}
+	return fmt.Sprintf("%s, %s, %s",
+		plural(len(workflow.Interface.Inputs), "input"),
+		plural(len(workflow.Interface.Secrets), "secret"),
+		plural(len(workflow.Interface.Outputs), "output"))
+}
+
+func workflowCallsSummary(workflow gu.WorkflowCalls) string {
+	if len(workflow.Calls) == 0 {
+		return "-"
+	}
+	summary := plural(len(workflow.Calls), "call")
+	if problems := workflowProblems(workflow); problems > 0 {
+		summary += fmt.Sprintf(", %s", plural(problems, "problem"))
+	}
+	return summary
+}
+
+func plural(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("1 %s", noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
+func shortWorkflowFile(file string) string {
+	return strings.TrimPrefix(file, ".github/workflows/")
+}
+
+func shortWorkflowFiles(files []string) []string {
+	short := make([]string, 0, len(files))
+	for _, file := range files {
+		short = append(short, shortWorkflowFile(file))
+	}
+	return short
+}
+
+func (m *ModelGithubRepository) updateReusableWorkflows(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc":
+		m.closeReusableWorkflows()
+		return nil
+	case "r", "R":
+		m.syncReusableWorkflows()
+		return nil
+	case "enter":
+		m.openReusableWorkflow()
+		return nil
+	}
+
+	var cmd tea.Cmd
+	m.tableReusableWorkflows, cmd = m.tableReusableWorkflows.Update(msg)
+	return cmd
+}
+
+// openReusableWorkflow opens the selected workflow file on GitHub.
+func (m *ModelGithubRepository) openReusableWorkflow() {
+	reusable := m.reusableWorkflows
+	cursor := m.tableReusableWorkflows.Cursor()
+	if cursor < 0 || cursor >= len(reusable.workflows) {
+		return
+	}
+
+	url := fmt.Sprintf("%s/%s/blob/%s/%s", m.githubUseCase.WebURL(), reusable.repository, reusable.branch, reusable.workflows[cursor].WorkflowFile)
+	if err := browser.OpenInBrowser(url); err != nil {
+		m.modelError.SetError(err)
+		m.modelError.SetErrorMessage(fmt.Sprintf("Cannot open in browser: %v", err))
+		return
+	}
+	m.modelError.SetSuccessMessage("Opened in browser")
+}
+
+// reusableWorkflowsView renders the workflows in place of the repositories,
+// with the interface, the calls and the callers of the selected workflow.
+func (m *ModelGithubRepository) reusableWorkflowsView() string {
+	reusable := m.reusableWorkflows
+
+	columns := slices.Clone(tableColumnsReusableWorkflows)
+	var tableWidth int
+	for _, column := range columns {
+		tableWidth += column.Width
+	}
+	if widthDiff := m.Viewport.Width - tableWidth; widthDiff > 0 {
+		columns[3].Width += widthDiff - (7 + 2*len(columns))
+	}
+	m.tableReusableWorkflows.SetColumns(columns)
+	m.tableReusableWorkflows.SetHeight(m.Viewport.Height - 17 - (reusableDetailLines - 1))
+
+	details := []string{fmt.Sprintf("Reusable workflows of %s@%s", reusable.repository, reusable.branch)}
+	if cursor := m.tableReusableWorkflows.Cursor(); cursor >= 0 && cursor < len(reusable.workflows) {
+		details = workflowDetails(reusable.workflows[cursor])
+	}
+	if len(details) > reusableDetailLines {
+		more := len(details) - reusableDetailLines + 1
+		details = append(details[:reusableDetailLines-1], fmt.Sprintf("… %d more lines", more))
+	}
+	for len(details) < reusableDetailLines {
+		details = append(details, "")
+	}
+
+	windowStyle := lipgloss.NewStyle().
+		Border(lipgloss.NormalBorder()).
+		Padding(0, 1).
+		Width(*hdltypes.ScreenWidth - 4)
+	lineStyle := lipgloss.NewStyle().MaxWidth(*hdltypes.ScreenWidth - 8)
+	for i, line := range details {
+		details[i] = lineStyle.Render(line)
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Top,
+		baseStyle.Render(m.tableReusableWorkflows.View()),
+		windowStyle.Render(strings.Join(details, "\n")))
+}
+
+// workflowDetails describes the interface of a workflow, the workflows calling
+// it and the workflows it calls with the problems of every call.
+func workflowDetails(workflow gu.WorkflowCalls) []string {
+	title := shortWorkflowFile(workflow.WorkflowFile)
+	if workflow.Name != "" {
+		title = fmt.Sprintf("%s (%s)", workflow.Name, title)
+	}
+	if workflow.Err != nil {
+		return []string{title, fmt.Sprintf("cannot be read: %v", workflow.Err)}
+	}
+
+	lines := []string{title}
+	if call := workflow.Interface; call != nil {
+		lines = append(lines, "inputs: "+inputsDetail(call.Inputs))
+		lines = append(lines, "secrets: "+secretsDetail(call.Secrets))
+		lines = append(lines, "outputs: "+outputsDetail(call.Outputs))
+	}
+	if len(workflow.CalledBy) > 0 {
+		lines = append(lines, "called by: "+strings.Join(shortWorkflowFiles(workflow.CalledBy), ", "))
+	}
+	for _, call := range workflow.Calls {
+		lines = append(lines, fmt.Sprintf("job %s calls %s", call.Job, call.Uses))
+		if call.Err != nil {
+			lines = append(lines, fmt.Sprintf("  line %d: %v", call.Line, call.Err))
+		}
+		for _, finding := range call.Findings {
+			lines = append(lines, fmt.Sprintf("  line %d:%d: %s", finding.Line, finding.Column, finding.Message))
+		}
+	}
+	if workflow.Interface == nil && len(workflow.Calls) == 0 {
+		lines = append(lines, "neither reusable nor calling a reusable workflow")
+	}
+	return lines
+}
+
+func inputsDetail(inputs map[string]py.WorkflowInput) string {
+	if len(inputs) == 0 {
+		return "-"
+	}
+	var details []string
+	for _, name := range sortedNames(inputs) {
+		input := inputs[name]
+		var attributes []string
+		if input.Type != "" {
+			attributes = append(attributes, input.Type)
+		}
+		if input.Required {
+			attributes = append(attributes, "required")
+		}
+		if input.Default != nil {
+			attributes = append(attributes, fmt.Sprintf("default %v", input.Default))
+		}
+		if len(attributes) > 0 {
+			name += " (" + strings.Join(attributes, ", ") + ")"
+		}
+		details = append(details, name)
+	}
+	return strings.Join(details, ", ")
+}
+
+func secretsDetail(secrets map[string]py.WorkflowSecret) string {
+	if len(secrets) == 0 {
+		return "-"
+	}
+	var details []string
+	for _, name := range sortedNames(secrets) {
+		if secrets[name].Required {
+			name += " (required)"
+		}
+		details = append(details, name)
+	}
+	return strings.Join(details, ", ")
+}
+
+func outputsDetail(outputs map[string]py.WorkflowOutput) string {
+	if len(outputs) == 0 {
+		return "-"
+	}
+	var details []string
+	for _, name := range sortedNames(outputs) {
+		if description := outputs[name].Description; description != "" {
+			name += " (" + description + ")"
+		}
+		details = append(details, name)
+	}
+	return strings.Join(details, ", ")
+}
+
+func sortedNames[V any](m map[string]V) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
@@ -0,0 +1,198 @@
+package ghrepository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	gu "github.com/termkit/gama/internal/github/usecase"
+	hdltypes "github.com/termkit/gama/internal/terminal/handler/types"
+	"github.com/termkit/gama/pkg/browser"
+	pw "github.com/termkit/gama/pkg/workflow"
+)
+
+// securityReport lists the findings of the security rules in the workflows of a repository.
+type securityReport struct {
+	repository string
+	branch     string
+	findings   []reportFinding // in the order of the rows
+
+	context context.Context
+	cancel  context.CancelFunc
+}
+
+type reportFinding struct {
+	workflowFile string
+	finding      pw.Finding
+}
+
+func (m *ModelGithubRepository) openSecurityReport() {
+	selectedRow := m.tableGithubRepository.SelectedRow()
+	if !m.tableReady || len(selectedRow) == 0 {
+		return
+	}
+
+	m.securityReport = &securityReport{repository: selectedRow[0], branch: selectedRow[1]}
+	m.syncSecurityReport()
+}
+
+func (m *ModelGithubRepository) closeSecurityReport() {
+	m.securityReport.cancel()
+	m.securityReport = nil
+	m.modelError.Reset()
+}
+
+func (m *ModelGithubRepository) syncSecurityReport() {
+	report := m.securityReport
+	if report.cancel != nil {
+		report.cancel() // cancel previous check
+	}
+	report.context, report.cancel = context.WithCancel(m.closeContext)
+	report.findings = nil
+	m.tableSecurityReport.SetRows([]table.Row{})
+
+	go m.checkSecurity(report.context, report)
+}
+
+func (m *ModelGithubRepository) checkSecurity(ctx context.Context, report *securityReport) {
+	m.modelError.Reset()
+	m.modelError.SetProgressMessage(fmt.Sprintf("[%s@%s] Checking the security of the workflows...", report.repository, report.branch))
+
+	output, err := m.githubUseCase.GetSecurityReport(ctx, gu.GetSecurityReportInput{
+		Repository: report.repository,
+		Branch:     report.branch,
+	})
+	if errors.Is(err, context.Canceled) {
+		return
+	} else if err != nil {
+		m.modelError.SetError(err)
+		m.modelError.SetErrorMessage("Workflows cannot be checked")
+		return
+	}
+
+	counts := make(map[pw.Severity]int)
+	for _, workflow := range output.Workflows {
+		if workflow.Err != nil {
+			// The file cannot be fetched or parsed, which hides all of its findings
+			report.findings = append(report.findings, reportFinding{workflowFile: workflow.WorkflowFile, finding: pw.Finding{
+				Rule:     pw.RuleSyntax,
+				Severity: pw.SeverityError,
+				Line:     1,
+				Column:   1,
+				Message:  fmt.Sprintf("not checked: %v", workflow.Err),
+			}})
+			counts[pw.SeverityError]++
+			continue
+		}
+		for _, finding := range workflow.Findings {
+			report.findings = append(report.findings, reportFinding{workflowFile: workflow.WorkflowFile, finding: finding})
+			counts[finding.Severity]++
+		}
+	}
+
+	// Most severe first, then in the order of the files
+	slices.SortStableFunc(report.findings, func(a, b reportFinding) int {
+		if a.finding.Severity != b.finding.Severity {
+			return int(b.finding.Severity - a.finding.Severity)
+		}
+		return strings.Compare(a.workflowFile, b.workflowFile)
+	})
+
+	var rows []table.Row
+	for _, f := range report.findings {
+		rows = append(rows, table.Row{
+			f.finding.Severity.String(),
+			strings.TrimPrefix(f.workflowFile, ".github/workflows/"),
+			strconv.Itoa(f.finding.Line),
+			f.finding.Rule,
+			f.finding.Message,
+		})
+	}
+	m.tableSecurityReport.SetRows(rows)
+	m.tableSecurityReport.SetCursor(0)
+
+	summary := fmt.Sprintf("[%s@%s] %d workflows checked: %d errors, %d warnings, %d info.",
+		report.repository, report.branch, len(output.Workflows), counts[pw.SeverityError], counts[pw.SeverityWarning], counts[pw.SeverityInfo])
+	if len(rows) > 0 {
+		summary += " enter to open the finding on GitHub, r to check again, esc to close"
+	}
+	m.modelError.SetDefaultMessage(summary)
+
+	go m.Update(m) // update model
+}
+
+func (m *ModelGithubRepository) updateSecurityReport(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc":
+		m.closeSecurityReport()
+		return nil
+	case "r", "R":
+		m.syncSecurityReport()
+		return nil
+	case "enter":
+		m.openFinding()
+		return nil
+	}
+
+	var cmd tea.Cmd
+	m.tableSecurityReport, cmd = m.tableSecurityReport.Update(msg)
+	return cmd
+}
+
+// openFinding opens the line of the selected finding on GitHub.
+func (m *ModelGithubRepository) openFinding() {
+	report := m.securityReport
+	cursor := m.tableSecurityReport.Cursor()
+	if cursor < 0 || cursor >= len(report.findings) {
+		return
+	}
+
+	f := report.findings[cursor]
+	url := fmt.Sprintf("%s/%s/blob/%s/%s#L%d", m.githubUseCase.WebURL(), report.repository, report.branch, f.workflowFile, f.finding.Line)
+	if err := browser.OpenInBrowser(url); err != nil {
+		m.modelError.SetError(err)
+		m.modelError.SetErrorMessage(fmt.Sprintf("Cannot open in browser: %v", err))
+		return
+	}
+	m.modelError.SetSuccessMessage("Opened in browser")
+}
+
+// securityReportView renders the report in place of the repositories, with
+// the description of the rule of the selected finding.
+func (m *ModelGithubRepository) securityReportView() string {
+	report := m.securityReport
+
+	columns := slices.Clone(tableColumnsSecurityReport)
+	var tableWidth int
+	for _, column := range columns {
+		tableWidth += column.Width
+	}
+	if widthDiff := m.Viewport.Width - tableWidth; widthDiff > 0 {
+		columns[4].Width += widthDiff - 17
+	}
+	m.tableSecurityReport.SetColumns(columns)
+	m.tableSecurityReport.SetHeight(m.Viewport.Height - 17)
+
+	description := fmt.Sprintf("Security report of %s@%s", report.repository, report.branch)
+	if cursor := m.tableSecurityReport.Cursor(); cursor >= 0 && cursor < len(report.findings) {
+		rule := report.findings[cursor].finding.Rule
+		if index := slices.IndexFunc(pw.SecurityRules, func(r pw.SecurityRule) bool { return r.ID == rule }); index >= 0 {
+			description = fmt.Sprintf("%s: %s", rule, pw.SecurityRules[index].Description)
+		}
+	}
+
+	windowStyle := lipgloss.NewStyle().
+		Border(lipgloss.NormalBorder()).
+		Padding(0, 1).
+		Width(*hdltypes.ScreenWidth - 4)
+
+	return lipgloss.JoinVertical(lipgloss.Top,
+		baseStyle.Render(m.tableSecurityReport.View()),
+		windowStyle.Render(description))
+}
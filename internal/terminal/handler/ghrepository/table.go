@@ -10,3 +10,18 @@ var tableColumnsGithubRepository = []table.Column{
 	{Title: "Stars", Width: 6},
 	{Title: "Workflows", Width: 9},
 }
+
+var tableColumnsSecurityReport = []table.Column{
+	{Title: "Severity", Width: 8},
+	{Title: "Workflow", Width: 20},
+	{Title: "Line", Width: 5},
+	{Title: "Rule", Width: 28},
+	{Title: "Finding", Width: 40},
+}
+
+var tableColumnsReusableWorkflows = []table.Column{
+	{Title: "Workflow", Width: 24},
+	{Title: "Interface", Width: 30},
+	{Title: "Calls", Width: 20},
+	{Title: "Called by", Width: 30},
+}
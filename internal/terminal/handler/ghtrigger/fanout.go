@@ -0,0 +1,382 @@
+package ghtrigger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	gu "github.com/termkit/gama/internal/github/usecase"
+	hdltypes "github.com/termkit/gama/internal/terminal/handler/types"
+	"github.com/termkit/gama/pkg/browser"
+)
+
+type fanOutMode int
+
+const (
+	fanOutModeNone    fanOutMode = iota
+	fanOutModeTargets            // repositories and branches are picked
+	fanOutModeResults            // targets are dispatched, with their results
+)
+
+const (
+	fanOutSelected   = "[x]"
+	fanOutUnselected = "[ ]"
+
+	fanOutStatusDispatching = "dispatching"
+	fanOutStatusDispatched  = "dispatched"
+	fanOutStatusFailed      = "failed"
+)
+
+func (m *ModelGithubTrigger) openFanOut() {
+	if !m.validateInputs() {
+		m.showValidation = true
+		m.modelError.SetError(errors.New("workflow inputs are invalid"))
+		m.modelError.SetErrorMessage("Fix the inputs marked in the Error column before fanning out")
+		return
+	}
+
+	// Inputs are frozen when the fan-out opens, every target and retry gets the same ones
+	inputs, err := m.workflowContent.ToPayload()
+	if err != nil {
+		m.modelError.SetError(err)
+		m.modelError.SetErrorMessage("Workflow inputs cannot be converted")
+		return
+	}
+
+	m.fanOutMode = fanOutModeTargets
+	m.fanOutInputs = inputs
+	m.fanOutResults = nil
+	m.textInput.Blur()
+	m.tableTrigger.Blur()
+	m.tableFanOutTargets.SetRows([]table.Row{})
+	m.fanOutContext, m.cancelFanOut = context.WithCancel(m.closeContext)
+
+	go m.syncFanOutTargets(m.fanOutContext)
+}
+
+func (m *ModelGithubTrigger) closeFanOut() {
+	m.cancelFanOut()
+	m.fanOutMode = fanOutModeNone
+	m.fanOutInputs = nil
+	m.fanOutResults = nil
+	m.fanOutBranchInput.Blur()
+	m.tableTrigger.Focus()
+	m.switchBetweenInputAndTable()
+}
+
+func (m *ModelGithubTrigger) syncFanOutTargets(ctx context.Context) {
+	m.modelError.Reset()
+	m.modelError.SetProgressMessage(fmt.Sprintf("Finding repositories with %s...", m.selectedWorkflow))
+
+	output, err := m.githubUseCase.ListWorkflowRepositories(ctx, gu.ListWorkflowRepositoriesInput{
+		WorkflowFile: m.selectedWorkflow,
+	})
+	if errors.Is(err, context.Canceled) {
+		return
+	} else if err != nil && (output == nil || len(output.Repositories) == 0) {
+		m.modelError.SetError(err)
+		m.modelError.SetErrorMessage("Repositories cannot be listed")
+		return
+	}
+
+	var rows []table.Row
+	for _, repository := range output.Repositories {
+		// The repository and branch of the Trigger tab are picked by default
+		selected, branches := fanOutUnselected, repository.DefaultBranch
+		if repository.Name == m.selectedRepositoryName {
+			selected, branches = fanOutSelected, m.selectedBranch
+		}
+		rows = append(rows, table.Row{selected, repository.Name, branches})
+	}
+	m.tableFanOutTargets.SetRows(rows)
+	m.tableFanOutTargets.SetCursor(0)
+	m.tableFanOutTargets.Focus()
+	m.syncFanOutBranchInput()
+
+	if err != nil {
+		m.modelError.SetError(err)
+		m.modelError.SetErrorMessage("Some repositories cannot be listed")
+	} else if len(rows) == 0 {
+		m.modelError.SetDefaultMessage(fmt.Sprintf("No repository has %s, esc to go back", m.selectedWorkflow))
+	} else {
+		m.modelError.SetDefaultMessage("ctrl+t to pick a repository, ctrl+a for all, type comma separated branches, enter to dispatch, esc to cancel")
+	}
+
+	go m.Update(m) // update model
+}
+
+func (m *ModelGithubTrigger) updateFanOut(msg tea.KeyMsg) tea.Cmd {
+	if m.fanOutMode == fanOutModeResults {
+		m.updateFanOutResults(msg)
+		return nil
+	}
+
+	rows := m.tableFanOutTargets.Rows()
+	switch msg.String() {
+	case "esc":
+		m.closeFanOut()
+		m.modelError.Reset()
+		return nil
+	case "up", "down":
+		if msg.String() == "up" {
+			m.tableFanOutTargets.MoveUp(1)
+		} else {
+			m.tableFanOutTargets.MoveDown(1)
+		}
+		m.syncFanOutBranchInput()
+		return nil
+	case "ctrl+t":
+		if cursor := m.tableFanOutTargets.Cursor(); cursor < len(rows) {
+			rows[cursor][0] = toggleFanOutSelection(rows[cursor][0])
+			m.tableFanOutTargets.SetRows(rows)
+		}
+		return nil
+	case "ctrl+a":
+		// Pick every repository, unless all of them are picked already
+		selection := fanOutUnselected
+		if slices.ContainsFunc(rows, func(row table.Row) bool { return row[0] != fanOutSelected }) {
+			selection = fanOutSelected
+		}
+		for i := range rows {
+			rows[i][0] = selection
+		}
+		m.tableFanOutTargets.SetRows(rows)
+		return nil
+	case "enter":
+		m.dispatchFanOut(m.fanOutTargets())
+		return nil
+	}
+
+	var cmd tea.Cmd
+	m.fanOutBranchInput, cmd = m.fanOutBranchInput.Update(msg)
+	if cursor := m.tableFanOutTargets.Cursor(); cursor < len(rows) {
+		rows[cursor][2] = m.fanOutBranchInput.Value()
+		m.tableFanOutTargets.SetRows(rows)
+	}
+	return cmd
+}
+
+func (m *ModelGithubTrigger) updateFanOutResults(msg tea.KeyMsg) {
+	switch msg.String() {
+	case "up":
+		m.tableFanOutResults.MoveUp(1)
+	case "down":
+		m.tableFanOutResults.MoveDown(1)
+	case "esc":
+		if m.isFanningOut {
+			return
+		}
+		m.fanOutMode = fanOutModeTargets
+		m.tableFanOutTargets.Focus()
+		m.syncFanOutBranchInput()
+		m.modelError.SetDefaultMessage("ctrl+t to pick a repository, ctrl+a for all, type comma separated branches, enter to dispatch, esc to cancel")
+	case "ctrl+r", "ctrl+R":
+		if m.isFanningOut {
+			return
+		}
+		var failed []gu.DispatchTarget
+		for _, result := range m.fanOutResults {
+			if result.Err != nil {
+				failed = append(failed, result.Target)
+			}
+		}
+		if len(failed) == 0 {
+			m.modelError.SetDefaultMessage("No failed targets to retry.")
+			return
+		}
+		m.dispatchFanOut(failed)
+	case "ctrl+o":
+		cursor := m.tableFanOutResults.Cursor()
+		if cursor >= len(m.fanOutResults) || m.fanOutResults[cursor].RunURL == "" {
+			m.modelError.SetDefaultMessage("The run of this target is not known.")
+			return
+		}
+		if err := browser.OpenInBrowser(m.fanOutResults[cursor].RunURL); err != nil {
+			m.modelError.SetError(err)
+			m.modelError.SetErrorMessage(fmt.Sprintf("Cannot open in browser: %v", err))
+			return
+		}
+		m.modelError.SetSuccessMessage("Opened in browser")
+	}
+}
+
+// fanOutTargets returns a target for every branch of the picked repositories.
+func (m *ModelGithubTrigger) fanOutTargets() []gu.DispatchTarget {
+	var targets []gu.DispatchTarget
+	for _, row := range m.tableFanOutTargets.Rows() {
+		if row[0] != fanOutSelected {
+			continue
+		}
+		for _, branch := range strings.Split(row[2], ",") {
+			target := gu.DispatchTarget{Repository: row[1], Branch: strings.TrimSpace(branch)}
+			if target.Branch != "" && !slices.Contains(targets, target) {
+				targets = append(targets, target)
+			}
+		}
+	}
+	return targets
+}
+
+// dispatchFanOut dispatches the workflow on the targets, replacing their previous results.
+func (m *ModelGithubTrigger) dispatchFanOut(targets []gu.DispatchTarget) {
+	if len(targets) == 0 {
+		m.modelError.SetError(errors.New("no target is picked"))
+		m.modelError.SetErrorMessage("Pick at least one repository with a branch")
+		return
+	}
+
+	if m.fanOutMode == fanOutModeTargets {
+		m.fanOutResults = nil
+	}
+	for _, target := range targets {
+		index := slices.IndexFunc(m.fanOutResults, func(result gu.FanOutResult) bool { return result.Target == target })
+		if index < 0 {
+			m.fanOutResults = append(m.fanOutResults, gu.FanOutResult{Target: target})
+		} else {
+			m.fanOutResults[index] = gu.FanOutResult{Target: target}
+		}
+	}
+
+	m.fanOutMode = fanOutModeResults
+	m.isFanningOut = true
+	m.fanOutBranchInput.Blur()
+	m.tableFanOutTargets.Blur()
+	m.tableFanOutResults.Focus()
+	m.tableFanOutResults.SetCursor(0)
+
+	pending := make(map[gu.DispatchTarget]bool, len(targets))
+	for _, target := range targets {
+		pending[target] = true
+	}
+	m.refreshFanOutResults(pending)
+
+	m.modelError.Reset()
+	m.modelError.SetProgressMessage(fmt.Sprintf("[%s] Dispatching on %d targets...", m.selectedWorkflow, len(targets)))
+
+	ctx := m.fanOutContext
+	go func() {
+		defer func() {
+			m.isFanningOut = false
+			go m.Update(m) // update model
+		}()
+
+		var done int
+		_, err := m.githubUseCase.FanOutWorkflow(ctx, gu.FanOutWorkflowInput{
+			WorkflowFile: m.selectedWorkflow,
+			Targets:      targets,
+			Inputs:       m.fanOutInputs,
+		}, func(result gu.FanOutResult) {
+			if ctx.Err() != nil {
+				return // the fan-out is closed
+			}
+
+			done++
+			index := slices.IndexFunc(m.fanOutResults, func(r gu.FanOutResult) bool { return r.Target == result.Target })
+			m.fanOutResults[index] = result
+			delete(pending, result.Target)
+			m.refreshFanOutResults(pending)
+
+			m.modelError.SetProgressMessage(fmt.Sprintf("[%s] Dispatching... %d/%d targets", m.selectedWorkflow, done, len(targets)))
+			go m.Update(m) // update model
+		})
+		if errors.Is(err, context.Canceled) {
+			return
+		}
+
+		var failed int
+		for _, result := range m.fanOutResults {
+			if result.Err != nil {
+				failed++
+			}
+		}
+		if failed > 0 {
+			m.modelError.SetError(err)
+			m.modelError.SetErrorMessage(fmt.Sprintf("%d of %d targets failed, ctrl+r to retry them, ctrl+o to open a run, esc to go back", failed, len(m.fanOutResults)))
+			return
+		}
+		m.modelError.SetSuccessMessage(fmt.Sprintf("[%s] Dispatched on %d targets, ctrl+o to open a run, esc to go back", m.selectedWorkflow, len(m.fanOutResults)))
+	}()
+}
+
+func (m *ModelGithubTrigger) refreshFanOutResults(pending map[gu.DispatchTarget]bool) {
+	var rows []table.Row
+	for _, result := range m.fanOutResults {
+		status, run := fanOutStatusDispatched, result.RunURL
+		switch {
+		case pending[result.Target]:
+			status, run = fanOutStatusDispatching, ""
+		case result.Err != nil:
+			status, run = fanOutStatusFailed, result.Err.Error()
+		case result.RunURL == "":
+			run = "run not found yet, see Workflow History"
+		}
+		rows = append(rows, table.Row{result.Target.Repository, result.Target.Branch, status, run})
+	}
+	m.tableFanOutResults.SetRows(rows)
+}
+
+// syncFanOutBranchInput puts the branches of the selected repository in the input.
+func (m *ModelGithubTrigger) syncFanOutBranchInput() {
+	selectedRow := m.tableFanOutTargets.SelectedRow()
+	if len(selectedRow) == 0 {
+		m.fanOutBranchInput.Blur()
+		return
+	}
+
+	m.fanOutBranchInput.SetValue(selectedRow[2])
+	m.fanOutBranchInput.CursorEnd()
+	m.fanOutBranchInput.Focus()
+}
+
+func toggleFanOutSelection(selection string) string {
+	if selection == fanOutSelected {
+		return fanOutUnselected
+	}
+	return fanOutSelected
+}
+
+// fanOutView renders the fan-out table in place of the inputs table.
+func (m *ModelGithubTrigger) fanOutView(baseStyle lipgloss.Style) string {
+	termWidth := m.Viewport.Width
+	termHeight := m.Viewport.Height
+
+	windowStyle := lipgloss.NewStyle().
+		Border(lipgloss.NormalBorder()).
+		Padding(0, 1).
+		Width(*hdltypes.ScreenWidth - 4)
+
+	if m.fanOutMode == fanOutModeResults {
+		m.tableFanOutResults.SetColumns(widenColumn(tableColumnsFanOutResults, 3, termWidth-16))
+		m.tableFanOutResults.SetHeight(termHeight - 17)
+
+		return lipgloss.JoinVertical(lipgloss.Top,
+			baseStyle.Render(m.tableFanOutResults.View()),
+			windowStyle.Render(fmt.Sprintf("Fan-out of %s", m.selectedWorkflow)))
+	}
+
+	m.tableFanOutTargets.SetColumns(widenColumn(tableColumnsFanOutTargets, 2, termWidth-12))
+	m.tableFanOutTargets.SetHeight(termHeight - 17)
+
+	return lipgloss.JoinVertical(lipgloss.Top,
+		baseStyle.Render(m.tableFanOutTargets.View()),
+		windowStyle.Render(m.fanOutBranchInput.View()))
+}
+
+// widenColumn returns a copy of the columns with the column at index filling up width.
+func widenColumn(columns []table.Column, index int, width int) []table.Column {
+	columns = slices.Clone(columns)
+
+	var tableWidth int
+	for _, column := range columns {
+		tableWidth += column.Width
+	}
+	if width > tableWidth {
+		columns[index].Width += width - tableWidth
+	}
+	return columns
+}
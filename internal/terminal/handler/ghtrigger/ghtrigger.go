@@ -17,6 +17,7 @@ import (
 	gu "github.com/termkit/gama/internal/github/usecase"
 	hdlerror "github.com/termkit/gama/internal/terminal/handler/error"
 	hdltypes "github.com/termkit/gama/internal/terminal/handler/types"
+	pkgpreset "github.com/termkit/gama/pkg/preset"
 	"github.com/termkit/gama/pkg/workflow"
 )
 
@@ -35,7 +36,24 @@ type ModelGithubTrigger struct {
 	currentOption              string
 	selectedWorkflow           string
 	selectedRepositoryName     string
+	selectedBranch             string
 	triggerFocused             bool
+	showValidation             bool // set by the first dispatch attempt, values are validated as they change afterwards
+	presetMode                 presetMode
+	presetList                 []pkgpreset.Preset
+	presetCursor               int
+	currentPreset              string
+	fanOutMode                 fanOutMode
+	fanOutContext              context.Context
+	cancelFanOut               context.CancelFunc
+	closeContext               context.Context
+	close                      context.CancelFunc
+	fanOutInputs               map[string]any    // inputs sent to every fan-out target
+	fanOutResults              []gu.FanOutResult // in the order of the results table
+	isFanningOut               bool
+	preview                    *gu.PreviewTriggerWorkflowOutput // dry run of the dispatch, nil when not shown
+	previewInputs              []previewInput
+	previewJobs                string // jobs of the dispatch with their matrices expanded
 
 	// shared properties
 	SelectedRepository *hdltypes.SelectedRepository
@@ -43,6 +61,9 @@ type ModelGithubTrigger struct {
 	// use cases
 	githubUseCase gu.UseCase
 
+	// presets is nil when presets cannot be stored
+	presets *pkgpreset.Store
+
 	// keymap
 	Keys keyMap
 
@@ -51,10 +72,16 @@ type ModelGithubTrigger struct {
 	Viewport     *viewport.Model
 	modelError   hdlerror.ModelError
 	textInput    textinput.Model
+	presetInput  textinput.Model
 	tableTrigger table.Model
+
+	tableFanOutTargets table.Model
+	tableFanOutResults table.Model
+	fanOutBranchInput  textinput.Model
+	previewViewport    viewport.Model
 }
 
-func SetupModelGithubTrigger(githubUseCase gu.UseCase, selectedRepository *hdltypes.SelectedRepository, currentTab *int, forceUpdateWorkflowHistory *bool) *ModelGithubTrigger {
+func SetupModelGithubTrigger(githubUseCase gu.UseCase, presets *pkgpreset.Store, selectedRepository *hdltypes.SelectedRepository, currentTab *int, forceUpdateWorkflowHistory *bool) *ModelGithubTrigger {
 	var tableRowsTrigger []table.Row
 
 	tableTrigger := table.New(
@@ -76,25 +103,58 @@ func SetupModelGithubTrigger(githubUseCase gu.UseCase, selectedRepository *hdlty
 		Bold(false)
 	tableTrigger.SetStyles(s)
 
+	tableFanOutTargets := table.New(table.WithColumns(tableColumnsFanOutTargets), table.WithHeight(7))
+	tableFanOutTargets.SetStyles(s)
+	tableFanOutResults := table.New(table.WithColumns(tableColumnsFanOutResults), table.WithHeight(7))
+	tableFanOutResults.SetStyles(s)
+
 	ti := textinput.New()
 	ti.Blur()
 	ti.CharLimit = 72
 
+	pi := textinput.New()
+	pi.Blur()
+	pi.CharLimit = 48
+	pi.Prompt = "Preset name: "
+
+	bi := textinput.New()
+	bi.Blur()
+	bi.CharLimit = 256
+	bi.Prompt = "Branches: "
+
+	closeContext, closeFunc := context.WithCancel(context.Background())
+
 	return &ModelGithubTrigger{
 		currentTab:                 currentTab,
 		forceUpdateWorkflowHistory: forceUpdateWorkflowHistory,
 		Help:                       help.New(),
 		Keys:                       keys,
 		githubUseCase:              githubUseCase,
+		presets:                    presets,
 		SelectedRepository:         selectedRepository,
 		modelError:                 hdlerror.SetupModelError(),
 		tableTrigger:               tableTrigger,
 		textInput:                  ti,
+		presetInput:                pi,
+		tableFanOutTargets:         tableFanOutTargets,
+		tableFanOutResults:         tableFanOutResults,
+		fanOutBranchInput:          bi,
 		syncWorkflowContext:        context.Background(),
 		cancelSyncWorkflow:         func() {},
+		fanOutContext:              context.Background(),
+		cancelFanOut:               func() {},
+		closeContext:               closeContext,
+		close:                      closeFunc,
 	}
 }
 
+// Close stops the background work of the model, it is not usable afterwards.
+func (m *ModelGithubTrigger) Close() {
+	m.cancelSyncWorkflow()
+	m.cancelFanOut()
+	m.close()
+}
+
 func (m *ModelGithubTrigger) Init() tea.Cmd {
 	m.modelError.SetDefaultMessage("No workflow contents found.")
 	return textinput.Blink
@@ -106,26 +166,61 @@ func (m *ModelGithubTrigger) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.modelError.SetDefaultMessage("No workflow selected.")
 		return m, nil
 	}
-	if m.SelectedRepository.WorkflowName != "" && (m.SelectedRepository.WorkflowName != m.selectedWorkflow || m.SelectedRepository.RepositoryName != m.selectedRepositoryName) {
+	if m.SelectedRepository.WorkflowName != "" && (m.SelectedRepository.WorkflowName != m.selectedWorkflow || m.SelectedRepository.RepositoryName != m.selectedRepositoryName || m.SelectedRepository.BranchName != m.selectedBranch) {
 		m.tableReady = false
 		m.isTriggerable = false
 		m.triggerFocused = false
+		m.presetMode = presetModeNone
+		m.currentPreset = ""
+		m.fanOutMode = fanOutModeNone
+		m.cancelFanOut() // stop a fan-out of the previous workflow
+		m.preview = nil
 
 		m.cancelSyncWorkflow() // cancel previous sync workflow
 
 		m.selectedWorkflow = m.SelectedRepository.WorkflowName
 		m.selectedRepositoryName = m.SelectedRepository.RepositoryName
-		m.syncWorkflowContext, m.cancelSyncWorkflow = context.WithCancel(context.Background())
+		m.selectedBranch = m.SelectedRepository.BranchName
+		m.syncWorkflowContext, m.cancelSyncWorkflow = context.WithCancel(m.closeContext)
 
 		go m.syncWorkflowContent(m.syncWorkflowContext)
 	}
 
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && m.presetMode != presetModeNone {
+		return m, m.updatePreset(keyMsg)
+	}
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && m.fanOutMode != fanOutModeNone {
+		return m, m.updateFanOut(keyMsg)
+	}
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && m.preview != nil {
+		return m, m.updatePreview(keyMsg)
+	}
+
 	var cmds []tea.Cmd
 	var cmd tea.Cmd
 
 	switch shadowMsg := msg.(type) {
 	case tea.KeyMsg:
 		switch shadowMsg.String() {
+		case "ctrl+s":
+			if m.tableReady && m.workflowContent != nil && !m.triggerFocused {
+				return m, m.openSavePreset()
+			}
+		case "ctrl+p":
+			if m.tableReady && m.workflowContent != nil && !m.triggerFocused {
+				m.openLoadPreset()
+				return m, nil
+			}
+		case "ctrl+x":
+			if m.tableReady && m.workflowContent != nil {
+				m.openPreview()
+				return m, nil
+			}
+		case "ctrl+f":
+			if m.tableReady && m.workflowContent != nil && !m.triggerFocused {
+				m.openFanOut()
+				return m, nil
+			}
 		case "up":
 			if len(m.tableTrigger.Rows()) > 0 && !m.triggerFocused {
 				m.tableTrigger.MoveUp(1)
@@ -181,11 +276,18 @@ func (m *ModelGithubTrigger) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	m.inputController(m.syncWorkflowContext)
 
+	if m.showValidation && m.workflowContent != nil {
+		m.validateInputs()
+	}
+
 	return m, tea.Batch(cmds...)
 }
 
 func (m *ModelGithubTrigger) switchBetweenInputAndTable() {
 	var selectedRow = m.tableTrigger.SelectedRow()
+	if len(selectedRow) == 0 {
+		return // workflow has no inputs
+	}
 
 	if selectedRow[1] == "input" || selectedRow[1] == "bool" {
 		m.textInput.Focus()
@@ -326,17 +428,26 @@ func (m *ModelGithubTrigger) View() string {
 	newTableColumns := tableColumnsTrigger
 	widthDiff := termWidth - tableWidth
 	if widthDiff > 0 {
-		newTableColumns[4].Width += widthDiff - 17
+		newTableColumns[4].Width += widthDiff - 19
 		m.tableTrigger.SetColumns(newTableColumns)
 		m.tableTrigger.SetHeight(termHeight - 17)
 	}
 
+	if m.fanOutMode != fanOutModeNone {
+		return m.fanOutView(baseStyle)
+	}
+	if m.preview != nil {
+		return m.previewView()
+	}
+
 	doc := strings.Builder{}
 	doc.WriteString(baseStyle.Render(m.tableTrigger.View()))
 
 	var selectedRow = m.tableTrigger.SelectedRow()
 	var selector = m.emptySelector()
-	if len(m.tableTrigger.Rows()) > 0 {
+	if m.presetMode != presetModeNone {
+		selector = m.presetSelector()
+	} else if len(m.tableTrigger.Rows()) > 0 {
 		if selectedRow[1] == "input" || selectedRow[1] == "bool" {
 			selector = m.inputSelector()
 		} else {
@@ -383,9 +494,10 @@ func (m *ModelGithubTrigger) syncWorkflowContent(ctx context.Context) {
 		tableRowsTrigger = append(tableRowsTrigger, table.Row{
 			fmt.Sprintf("%d", keyVal.ID),
 			"input", // json type
-			keyVal.Key,
+			keyVal.FullKey(),
 			keyVal.Default,
 			keyVal.Value,
+			"",
 		})
 	}
 
@@ -396,6 +508,7 @@ func (m *ModelGithubTrigger) syncWorkflowContent(ctx context.Context) {
 			choice.Key,
 			choice.Default,
 			choice.Value,
+			"",
 		})
 	}
 
@@ -406,6 +519,7 @@ func (m *ModelGithubTrigger) syncWorkflowContent(ctx context.Context) {
 			input.Key,
 			input.Default,
 			input.Value,
+			"",
 		})
 	}
 
@@ -416,6 +530,7 @@ func (m *ModelGithubTrigger) syncWorkflowContent(ctx context.Context) {
 			boolean.Key,
 			boolean.Default,
 			boolean.Value,
+			"",
 		})
 	}
 
@@ -434,6 +549,7 @@ func (m *ModelGithubTrigger) syncWorkflowContent(ctx context.Context) {
 
 	m.tableReady = true
 	m.isTriggerable = true
+	m.showValidation = false
 
 	if len(workflowContent.Workflow.KeyVals) == 0 &&
 		len(workflowContent.Workflow.Choices) == 0 &&
@@ -515,7 +631,30 @@ func (m *ModelGithubTrigger) fillEmptyValuesWithDefault() {
 	}
 }
 
+// validateInputs shows the validation error of every row and reports whether all values are valid.
+func (m *ModelGithubTrigger) validateInputs() bool {
+	validationErrors := make(map[string]string)
+	for _, validationError := range m.workflowContent.Validate() {
+		validationErrors[fmt.Sprintf("%d", validationError.ID)] = validationError.Message
+	}
+
+	rows := m.tableTrigger.Rows()
+	for i, row := range rows {
+		rows[i][5] = validationErrors[row[0]]
+	}
+	m.tableTrigger.SetRows(rows)
+
+	return len(validationErrors) == 0
+}
+
 func (m *ModelGithubTrigger) triggerWorkflow() {
+	if m.workflowContent != nil && !m.validateInputs() {
+		m.showValidation = true
+		m.modelError.SetError(errors.New("workflow inputs are invalid"))
+		m.modelError.SetErrorMessage("Fix the inputs marked in the Error column before triggering")
+		return
+	}
+
 	if m.triggerFocused {
 		m.fillEmptyValuesWithDefault()
 	}
@@ -529,10 +668,10 @@ func (m *ModelGithubTrigger) triggerWorkflow() {
 		return
 	}
 
-	content, err := m.workflowContent.ToJson()
+	inputs, err := m.workflowContent.ToPayload()
 	if err != nil {
 		m.modelError.SetError(err)
-		m.modelError.SetErrorMessage("Workflow contents cannot be converted to JSON")
+		m.modelError.SetErrorMessage("Workflow inputs cannot be converted")
 		return
 	}
 
@@ -540,7 +679,7 @@ func (m *ModelGithubTrigger) triggerWorkflow() {
 		Repository:   m.SelectedRepository.RepositoryName,
 		Branch:       m.SelectedRepository.BranchName,
 		WorkflowFile: m.selectedWorkflow,
-		Content:      content,
+		Inputs:       inputs,
 	})
 	if err != nil {
 		m.modelError.SetError(err)
@@ -9,10 +9,14 @@ type keyMap struct {
 	SwitchTab   teakey.Binding
 	Trigger     teakey.Binding
 	Refresh     teakey.Binding
+	SavePreset  teakey.Binding
+	LoadPreset  teakey.Binding
+	FanOut      teakey.Binding
+	Preview     teakey.Binding
 }
 
 func (k keyMap) ShortHelp() []teakey.Binding {
-	return []teakey.Binding{k.PreviousTab, k.Refresh, k.SwitchTab, k.Trigger}
+	return []teakey.Binding{k.PreviousTab, k.Refresh, k.SwitchTab, k.Trigger, k.SavePreset, k.LoadPreset, k.FanOut, k.Preview}
 }
 
 func (k keyMap) FullHelp() [][]teakey.Binding {
@@ -21,6 +25,10 @@ func (k keyMap) FullHelp() [][]teakey.Binding {
 		{k.Refresh},
 		{k.SwitchTab},
 		{k.Trigger},
+		{k.SavePreset},
+		{k.LoadPreset},
+		{k.FanOut},
+		{k.Preview},
 	}
 }
 
@@ -41,6 +49,22 @@ var keys = keyMap{
 		teakey.WithKeys("enter"),
 		teakey.WithHelp("enter", "trigger workflow"),
 	),
+	SavePreset: teakey.NewBinding(
+		teakey.WithKeys("ctrl+s"),
+		teakey.WithHelp("ctrl+s", "save preset"),
+	),
+	LoadPreset: teakey.NewBinding(
+		teakey.WithKeys("ctrl+p"),
+		teakey.WithHelp("ctrl+p", "load preset"),
+	),
+	FanOut: teakey.NewBinding(
+		teakey.WithKeys("ctrl+f"),
+		teakey.WithHelp("ctrl+f", "fan out"),
+	),
+	Preview: teakey.NewBinding(
+		teakey.WithKeys("ctrl+x"),
+		teakey.WithHelp("ctrl+x", "dry run"),
+	),
 }
 
 func (m *ModelGithubTrigger) ViewHelp() string {
@@ -0,0 +1,242 @@
+package ghtrigger
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	hdltypes "github.com/termkit/gama/internal/terminal/handler/types"
+	pkgpreset "github.com/termkit/gama/pkg/preset"
+)
+
+type presetMode int
+
+const (
+	presetModeNone presetMode = iota
+	presetModeSave            // the name of the new preset is typed in
+	presetModeLoad            // a saved preset is picked
+)
+
+func (m *ModelGithubTrigger) openSavePreset() tea.Cmd {
+	if m.presets == nil {
+		m.modelError.SetError(errors.New("presets store is not available"))
+		m.modelError.SetErrorMessage("Presets cannot be saved")
+		return nil
+	}
+
+	m.presetMode = presetModeSave
+	m.textInput.Blur()
+	m.tableTrigger.Blur()
+	m.presetInput.SetValue(m.currentPreset)
+	m.presetInput.SetCursor(len(m.currentPreset))
+	m.modelError.SetDefaultMessage("Type a preset name, enter to save, esc to cancel")
+	return m.presetInput.Focus()
+}
+
+func (m *ModelGithubTrigger) openLoadPreset() {
+	if m.presets == nil {
+		m.modelError.SetError(errors.New("presets store is not available"))
+		m.modelError.SetErrorMessage("Presets cannot be loaded")
+		return
+	}
+
+	presets, err := m.presets.List(m.presetHost(), m.selectedRepositoryName, m.selectedWorkflow)
+	if err != nil {
+		m.modelError.SetError(err)
+		m.modelError.SetErrorMessage("Presets cannot be loaded")
+		return
+	}
+	if len(presets) == 0 {
+		m.modelError.SetDefaultMessage("No presets saved for this workflow, press ctrl+s to save one")
+		return
+	}
+
+	m.presetMode = presetModeLoad
+	m.presetList = presets
+	m.presetCursor = 0
+	for i, preset := range presets {
+		if preset.Name == m.currentPreset {
+			m.presetCursor = i
+		}
+	}
+	m.textInput.Blur()
+	m.tableTrigger.Blur()
+	m.modelError.SetDefaultMessage("← → to pick a preset, enter to load, ctrl+d to delete, esc to cancel")
+}
+
+func (m *ModelGithubTrigger) closePreset() {
+	m.presetMode = presetModeNone
+	m.presetList = nil
+	m.presetInput.Blur()
+	m.tableTrigger.Focus()
+	m.switchBetweenInputAndTable()
+}
+
+func (m *ModelGithubTrigger) updatePreset(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc":
+		m.closePreset()
+		m.modelError.Reset()
+		return nil
+	case "enter":
+		if m.presetMode == presetModeSave {
+			m.savePreset()
+		} else {
+			m.loadPreset(m.presetList[m.presetCursor])
+		}
+		return nil
+	}
+
+	if m.presetMode == presetModeLoad {
+		switch msg.String() {
+		case "left":
+			m.presetCursor = max(m.presetCursor-1, 0)
+		case "right":
+			m.presetCursor = min(m.presetCursor+1, len(m.presetList)-1)
+		case "ctrl+d":
+			m.deletePreset(m.presetList[m.presetCursor])
+		}
+		return nil
+	}
+
+	var cmd tea.Cmd
+	m.presetInput, cmd = m.presetInput.Update(msg)
+	return cmd
+}
+
+func (m *ModelGithubTrigger) savePreset() {
+	name := strings.TrimSpace(m.presetInput.Value())
+	if name == "" {
+		m.modelError.SetError(errors.New("preset name cannot be empty"))
+		m.modelError.SetErrorMessage("Type a name for the preset")
+		return
+	}
+
+	err := m.presets.Save(m.presetHost(), m.selectedRepositoryName, m.selectedWorkflow, pkgpreset.Preset{
+		Name:   name,
+		Inputs: m.workflowContent.Values(),
+	})
+	if err != nil {
+		m.modelError.SetError(err)
+		m.modelError.SetErrorMessage("Preset cannot be saved")
+		return
+	}
+
+	m.currentPreset = name
+	m.closePreset()
+	m.modelError.SetSuccessMessage(fmt.Sprintf("Preset %q saved.", name))
+}
+
+func (m *ModelGithubTrigger) loadPreset(preset pkgpreset.Preset) {
+	check := m.workflowContent.ApplyValues(preset.Inputs)
+	m.currentPreset = preset.Name
+
+	values := m.workflowContent.Values()
+	rows := m.tableTrigger.Rows()
+	for i, row := range rows {
+		rows[i][4] = values[m.rowKey(row[0])]
+	}
+	m.tableTrigger.SetRows(rows)
+
+	m.optionInit = false
+	m.showValidation = true
+	m.validateInputs()
+	m.closePreset()
+
+	if check.IsEmpty() {
+		m.modelError.SetSuccessMessage(fmt.Sprintf("Preset %q loaded.", preset.Name))
+		return
+	}
+
+	var warnings []string
+	if len(check.Unknown) > 0 {
+		warnings = append(warnings, "inputs no longer in the workflow: "+strings.Join(check.Unknown, ", "))
+	}
+	if len(check.MissingRequired) > 0 {
+		warnings = append(warnings, "no value for required inputs: "+strings.Join(check.MissingRequired, ", "))
+	}
+	m.modelError.SetDefaultMessage(fmt.Sprintf("Warning: Preset %q is outdated, %s. Save it again to update it.",
+		preset.Name, strings.Join(warnings, "; ")))
+}
+
+// presetHost is the host of the presets, github.com or the GitHub Enterprise server.
+func (m *ModelGithubTrigger) presetHost() string {
+	webURL, err := url.Parse(m.githubUseCase.WebURL())
+	if err != nil || webURL.Host == "" {
+		return "github.com"
+	}
+	return webURL.Host
+}
+
+func (m *ModelGithubTrigger) deletePreset(preset pkgpreset.Preset) {
+	if err := m.presets.Delete(m.presetHost(), m.selectedRepositoryName, m.selectedWorkflow, preset.Name); err != nil {
+		m.modelError.SetError(err)
+		m.modelError.SetErrorMessage("Preset cannot be deleted")
+		return
+	}
+
+	if m.currentPreset == preset.Name {
+		m.currentPreset = ""
+	}
+	m.presetList = append(m.presetList[:m.presetCursor], m.presetList[m.presetCursor+1:]...)
+	m.presetCursor = min(m.presetCursor, len(m.presetList)-1)
+	m.modelError.SetSuccessMessage(fmt.Sprintf("Preset %q deleted.", preset.Name))
+
+	if len(m.presetList) == 0 {
+		m.closePreset()
+	}
+}
+
+// rowKey returns the key the value of a table row is stored with in a preset.
+func (m *ModelGithubTrigger) rowKey(id string) string {
+	for _, keyVal := range m.workflowContent.KeyVals {
+		if fmt.Sprintf("%d", keyVal.ID) == id {
+			return keyVal.FullKey()
+		}
+	}
+	for _, choice := range m.workflowContent.Choices {
+		if fmt.Sprintf("%d", choice.ID) == id {
+			return choice.Key
+		}
+	}
+	for _, input := range m.workflowContent.Inputs {
+		if fmt.Sprintf("%d", input.ID) == id {
+			return input.Key
+		}
+	}
+	for _, boolean := range m.workflowContent.Boolean {
+		if fmt.Sprintf("%d", boolean.ID) == id {
+			return boolean.Key
+		}
+	}
+	return ""
+}
+
+// presetSelector renders the preset name input or the list of saved presets
+func (m *ModelGithubTrigger) presetSelector() string {
+	windowStyle := lipgloss.NewStyle().
+		Border(lipgloss.NormalBorder()).
+		Padding(0, 1).
+		Width(*hdltypes.ScreenWidth - 13)
+
+	if m.presetMode == presetModeSave {
+		return windowStyle.Render(m.presetInput.View())
+	}
+
+	selectedOptionStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("120")).Padding(0, 1)
+	unselectedOptionStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("140")).Padding(0, 1)
+
+	processedValues := []string{"Presets:"}
+	for i, preset := range m.presetList {
+		if i == m.presetCursor {
+			processedValues = append(processedValues, selectedOptionStyle.Render(preset.Name))
+		} else {
+			processedValues = append(processedValues, unselectedOptionStyle.Render(preset.Name))
+		}
+	}
+
+	return windowStyle.Render(lipgloss.JoinHorizontal(lipgloss.Left, processedValues...))
+}
@@ -0,0 +1,184 @@
+package ghtrigger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	gu "github.com/termkit/gama/internal/github/usecase"
+	"github.com/termkit/gama/internal/terminal/handler/jobgraph"
+	hdltypes "github.com/termkit/gama/internal/terminal/handler/types"
+)
+
+var previewTitleStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("130"))
+
+// previewInput is a resolved input of the preview.
+type previewInput struct {
+	key       string
+	value     string
+	isDefault bool // value is the default, the input was left empty
+}
+
+// openPreview resolves the inputs like triggerWorkflow does and shows the
+// request it would send. Nothing is dispatched.
+func (m *ModelGithubTrigger) openPreview() {
+	if !m.validateInputs() {
+		m.showValidation = true
+		m.modelError.SetError(errors.New("workflow inputs are invalid"))
+		m.modelError.SetErrorMessage("Fix the inputs marked in the Error column before previewing")
+		return
+	}
+
+	// Empty values are shown and sent with their default, the form keeps them empty
+	var inputs []previewInput
+	for _, row := range m.tableTrigger.Rows() {
+		input := previewInput{key: row[2], value: row[4]}
+		if input.value == "" {
+			input.value, input.isDefault = row[3], true
+		}
+		inputs = append(inputs, input)
+	}
+
+	payload, err := m.workflowContent.ToPayload()
+	if err != nil {
+		m.modelError.SetError(err)
+		m.modelError.SetErrorMessage("Workflow inputs cannot be converted")
+		return
+	}
+
+	preview, err := m.githubUseCase.PreviewTriggerWorkflow(context.Background(), gu.TriggerWorkflowInput{
+		Repository:   m.SelectedRepository.RepositoryName,
+		Branch:       m.SelectedRepository.BranchName,
+		WorkflowFile: m.selectedWorkflow,
+		Inputs:       payload,
+	})
+	if err != nil {
+		m.modelError.SetError(err)
+		m.modelError.SetErrorMessage("Request cannot be previewed")
+		return
+	}
+
+	m.preview = preview
+	m.previewInputs = inputs
+	m.previewJobs = "Expanding the matrices with the inputs..."
+	go m.syncPreviewJobs(preview, payload)
+	m.previewViewport = viewport.New(0, 0)
+	m.textInput.Blur()
+	m.tableTrigger.Blur()
+	m.modelError.SetDefaultMessage("Dry run, nothing is dispatched. g, c or b to copy the gh command, curl command or body, esc to close")
+}
+
+// syncPreviewJobs expands the jobs of the workflow with the inputs of the preview.
+func (m *ModelGithubTrigger) syncPreviewJobs(preview *gu.PreviewTriggerWorkflowOutput, inputs map[string]any) {
+	output, err := m.githubUseCase.GetWorkflowJobs(context.Background(), gu.GetWorkflowJobsInput{
+		Repository:   m.SelectedRepository.RepositoryName,
+		Branch:       m.SelectedRepository.BranchName,
+		WorkflowFile: m.selectedWorkflow,
+		Inputs:       inputs,
+	})
+	if m.preview != preview {
+		return // the preview is closed
+	}
+	if err != nil {
+		m.previewJobs = fmt.Sprintf("Jobs cannot be expanded: %v", err)
+	} else {
+		m.previewJobs = jobgraph.JobList(output.Jobs)
+	}
+
+	go m.Update(m) // update model
+}
+
+func (m *ModelGithubTrigger) closePreview() {
+	m.preview = nil
+	m.previewInputs = nil
+	m.previewJobs = ""
+	m.triggerFocused = false
+	m.tableTrigger.Focus()
+	m.switchBetweenInputAndTable()
+}
+
+func (m *ModelGithubTrigger) updatePreview(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc":
+		m.closePreview()
+		m.modelError.Reset()
+		return nil
+	case "g":
+		m.copyToClipboard("gh command", m.preview.GhCommand)
+		return nil
+	case "c":
+		m.copyToClipboard("curl command", m.preview.CurlCommand)
+		return nil
+	case "b":
+		m.copyToClipboard("Body", m.preview.Body)
+		return nil
+	}
+
+	var cmd tea.Cmd
+	m.previewViewport, cmd = m.previewViewport.Update(msg)
+	return cmd
+}
+
+func (m *ModelGithubTrigger) copyToClipboard(name string, content string) {
+	if err := clipboard.WriteAll(content); err != nil {
+		m.modelError.SetError(err)
+		m.modelError.SetErrorMessage("Clipboard is not available, select the text with the mouse instead")
+		return
+	}
+	m.modelError.SetSuccessMessage(fmt.Sprintf("%s copied to the clipboard.", name))
+}
+
+// previewView renders the preview in place of the inputs table.
+func (m *ModelGithubTrigger) previewView() string {
+	width := *hdltypes.ScreenWidth - 6
+	m.previewViewport.Width = width
+	m.previewViewport.Height = m.Viewport.Height - 19
+	m.previewViewport.SetContent(m.previewContent(width))
+
+	return lipgloss.NewStyle().
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		Render(m.previewViewport.View())
+}
+
+func (m *ModelGithubTrigger) previewContent(width int) string {
+	wrap := lipgloss.NewStyle().Width(width).PaddingLeft(2)
+
+	var keyWidth int
+	for _, input := range m.previewInputs {
+		keyWidth = max(keyWidth, len(input.key))
+	}
+
+	var inputs []string
+	for _, input := range m.previewInputs {
+		line := fmt.Sprintf("%-*s = %s", keyWidth, input.key, input.value)
+		if input.isDefault {
+			line += " (default)"
+		}
+		inputs = append(inputs, line)
+	}
+	if len(inputs) == 0 {
+		inputs = append(inputs, "workflow has no inputs")
+	}
+
+	sections := []string{
+		previewTitleStyle.Render("Endpoint"),
+		wrap.Render(m.preview.Method + " " + m.preview.URL),
+		previewTitleStyle.Render("Resolved inputs"),
+		wrap.Render(strings.Join(inputs, "\n")),
+		previewTitleStyle.Render("Jobs"),
+		wrap.Render(m.previewJobs),
+		previewTitleStyle.Render("Body"),
+		wrap.Render(m.preview.Body),
+		previewTitleStyle.Render("GitHub CLI"),
+		wrap.Render(m.preview.GhCommand),
+		previewTitleStyle.Render("curl"),
+		wrap.Render(m.preview.CurlCommand),
+	}
+	return strings.Join(sections, "\n")
+}
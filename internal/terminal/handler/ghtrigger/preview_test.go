@@ -0,0 +1,101 @@
+package ghtrigger
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/stretchr/testify/assert"
+	gu "github.com/termkit/gama/internal/github/usecase"
+	hdltypes "github.com/termkit/gama/internal/terminal/handler/types"
+	"github.com/termkit/gama/pkg/workflow"
+	py "github.com/termkit/gama/pkg/yaml"
+)
+
+const releaseWorkflow = `on:
+  workflow_dispatch:
+    inputs:
+      version:
+        type: string
+        default: v1.0.0
+      environment:
+        type: choice
+        options: [staging, production]
+        default: staging
+      dry_run:
+        type: boolean
+        default: true
+`
+
+// stubUseCase serves the workflow of the trigger tab, calling any other
+// method panics on the nil embedded interface.
+type stubUseCase struct {
+	gu.UseCase
+
+	previewed []map[string]any // inputs of the previewed dispatches
+}
+
+func (s *stubUseCase) InspectWorkflow(_ context.Context, _ gu.InspectWorkflowInput) (*gu.InspectWorkflowOutput, error) {
+	content, err := py.UnmarshalWorkflowContent([]byte(releaseWorkflow))
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := workflow.ParseWorkflow(*content)
+	if err != nil {
+		return nil, err
+	}
+	return &gu.InspectWorkflowOutput{Workflow: parsed.ToPretty()}, nil
+}
+
+func (s *stubUseCase) PreviewTriggerWorkflow(_ context.Context, input gu.TriggerWorkflowInput) (*gu.PreviewTriggerWorkflowOutput, error) {
+	s.previewed = append(s.previewed, input.Inputs)
+	return &gu.PreviewTriggerWorkflowOutput{}, nil
+}
+
+func (s *stubUseCase) GetWorkflowJobs(_ context.Context, _ gu.GetWorkflowJobsInput) (*gu.GetWorkflowJobsOutput, error) {
+	return &gu.GetWorkflowJobsOutput{}, nil
+}
+
+func TestModelGithubTrigger_OpenPreview(t *testing.T) {
+	githubUseCase := &stubUseCase{}
+	// Without a selected workflow the updates of the model return early
+	selected := &hdltypes.SelectedRepository{RepositoryName: "owner/repo", BranchName: "main"}
+	var currentTab int
+	var forceUpdate bool
+	m := SetupModelGithubTrigger(githubUseCase, nil, selected, &currentTab, &forceUpdate)
+	m.selectedWorkflow = ".github/workflows/release.yml"
+	m.syncWorkflowContent(context.Background())
+
+	rows := m.tableTrigger.Rows()
+	version := slices.IndexFunc(rows, func(row table.Row) bool { return row[2] == "version" })
+	rows[version][4] = "v2.0.0"
+	m.tableTrigger.SetRows(rows)
+	m.workflowContent.Inputs[0].SetValue("v2.0.0")
+
+	before := cloneRows(m.tableTrigger.Rows())
+	m.openPreview()
+
+	// The preview sends and shows the defaults, the form keeps its empty values
+	assert.NotNil(t, m.preview)
+	assert.Equal(t, []map[string]any{{"version": "v2.0.0", "environment": "staging", "dry_run": true}}, githubUseCase.previewed)
+	assert.Equal(t, before, m.tableTrigger.Rows())
+	assert.Empty(t, m.workflowContent.Choices[0].Value)
+	assert.Empty(t, m.workflowContent.Boolean[0].Value)
+
+	var defaults []string
+	for _, input := range m.previewInputs {
+		if input.isDefault {
+			defaults = append(defaults, input.key+"="+input.value)
+		}
+	}
+	assert.ElementsMatch(t, []string{"environment=staging", "dry_run=true"}, defaults)
+}
+
+func cloneRows(rows []table.Row) []table.Row {
+	cloned := make([]table.Row, len(rows))
+	for i, row := range rows {
+		cloned[i] = slices.Clone(row)
+	}
+	return cloned
+}
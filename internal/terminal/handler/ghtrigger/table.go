@@ -11,4 +11,18 @@ var tableColumnsTrigger = []table.Column{
 	{Title: "Default", Width: 16},
 	//{Title: "Description", Width: 64},
 	{Title: "Value", Width: 44},
+	{Title: "Error", Width: 22},
+}
+
+var tableColumnsFanOutTargets = []table.Column{
+	{Title: "Sel", Width: 3},
+	{Title: "Repository", Width: 36},
+	{Title: "Branches", Width: 44},
+}
+
+var tableColumnsFanOutResults = []table.Column{
+	{Title: "Repository", Width: 28},
+	{Title: "Branch", Width: 16},
+	{Title: "Status", Width: 12},
+	{Title: "Run", Width: 28},
 }
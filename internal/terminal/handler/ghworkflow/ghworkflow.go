@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/table"
 	hdlerror "github.com/termkit/gama/internal/terminal/handler/error"
 	"github.com/termkit/gama/internal/terminal/handler/ghtrigger"
@@ -26,6 +27,14 @@ type ModelGithubWorkflow struct {
 	cancelSyncTriggerableWorkflows  context.CancelFunc
 	tableReady                      bool
 	lastRepository                  string
+	lastBranch                      string
+	syncYAMLContext                 context.Context
+	cancelSyncYAML                  context.CancelFunc
+	closeContext                    context.Context
+	close                           context.CancelFunc
+	yamlViewer                      *yamlViewer  // nil when the YAML of the workflow is not shown
+	graphViewer                     *graphViewer // nil when the job graph of the workflow is not shown
+	jobsViewer                      *jobsViewer  // nil when the jobs of the workflow are not shown
 
 	// shared properties
 	SelectedRepository *hdltypes.SelectedRepository
@@ -78,6 +87,8 @@ func SetupModelGithubWorkflow(githubUseCase gu.UseCase, selectedRepository *hdlt
 
 	tabOptions := taboptions.NewOptions()
 
+	closeContext, closeFunc := context.WithCancel(context.Background())
+
 	return &ModelGithubWorkflow{
 		Help:                            help.New(),
 		Keys:                            keys,
@@ -88,9 +99,20 @@ func SetupModelGithubWorkflow(githubUseCase gu.UseCase, selectedRepository *hdlt
 		actualModelTabOptions:           tabOptions,
 		syncTriggerableWorkflowsContext: context.Background(),
 		cancelSyncTriggerableWorkflows:  func() {},
+		syncYAMLContext:                 context.Background(),
+		cancelSyncYAML:                  func() {},
+		closeContext:                    closeContext,
+		close:                           closeFunc,
 	}
 }
 
+// Close stops the background work of the model, it is not usable afterwards.
+func (m *ModelGithubWorkflow) Close() {
+	m.cancelSyncTriggerableWorkflows()
+	m.cancelSyncYAML()
+	m.close()
+}
+
 func (m *ModelGithubWorkflow) Init() tea.Cmd {
 	return nil
 }
@@ -98,16 +120,50 @@ func (m *ModelGithubWorkflow) Init() tea.Cmd {
 func (m *ModelGithubWorkflow) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 
-	if m.lastRepository != m.SelectedRepository.RepositoryName {
+	if m.lastRepository != m.SelectedRepository.RepositoryName || m.lastBranch != m.SelectedRepository.BranchName {
 		m.tableReady = false               // reset table ready status
 		m.cancelSyncTriggerableWorkflows() // cancel previous sync
-		m.syncTriggerableWorkflowsContext, m.cancelSyncTriggerableWorkflows = context.WithCancel(context.Background())
+		m.syncTriggerableWorkflowsContext, m.cancelSyncTriggerableWorkflows = context.WithCancel(m.closeContext)
 
 		m.lastRepository = m.SelectedRepository.RepositoryName
+		m.lastBranch = m.SelectedRepository.BranchName
+
+		if m.yamlViewer != nil {
+			m.closeYAMLViewer()
+		}
+		if m.graphViewer != nil {
+			m.closeGraphViewer()
+		}
+		if m.jobsViewer != nil {
+			m.closeJobsViewer()
+		}
 
 		go m.syncTriggerableWorkflows(m.syncTriggerableWorkflowsContext)
 	}
 
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		if m.yamlViewer != nil {
+			return m, m.updateYAMLViewer(keyMsg)
+		}
+		if m.graphViewer != nil {
+			return m, m.updateGraphViewer(keyMsg)
+		}
+		if m.jobsViewer != nil {
+			return m, m.updateJobsViewer(keyMsg)
+		}
+		switch {
+		case key.Matches(keyMsg, m.Keys.ViewYAML):
+			m.openYAMLViewer()
+			return m, nil
+		case key.Matches(keyMsg, m.Keys.ViewGraph):
+			m.openGraphViewer()
+			return m, nil
+		case key.Matches(keyMsg, m.Keys.ViewJobs):
+			m.openJobsViewer()
+			return m, nil
+		}
+	}
+
 	m.tableTriggerableWorkflow, cmd = m.tableTriggerableWorkflow.Update(msg)
 
 	m.handleTableInputs(m.syncTriggerableWorkflowsContext) // update table operations
@@ -127,11 +183,21 @@ func (m *ModelGithubWorkflow) View() string {
 	newTableColumns := tableColumnsWorkflow
 	widthDiff := termWidth - tableWidth
 	if widthDiff > 0 {
-		newTableColumns[1].Width += widthDiff - 11
+		newTableColumns[1].Width += widthDiff - 13
 		m.tableTriggerableWorkflow.SetColumns(newTableColumns)
 		m.tableTriggerableWorkflow.SetHeight(termHeight - 17)
 	}
 
+	if m.yamlViewer != nil {
+		return m.yamlView()
+	}
+	if m.graphViewer != nil {
+		return m.graphView()
+	}
+	if m.jobsViewer != nil {
+		return m.jobsView()
+	}
+
 	doc := strings.Builder{}
 	doc.WriteString(baseStyle.Render(m.tableTriggerableWorkflow.View()))
 
@@ -170,6 +236,7 @@ func (m *ModelGithubWorkflow) syncTriggerableWorkflows(ctx context.Context) {
 		tableRowsTriggerableWorkflow = append(tableRowsTriggerableWorkflow, table.Row{
 			workflow.Name,
 			workflow.Path,
+			"...",
 		})
 	}
 
@@ -178,6 +245,7 @@ func (m *ModelGithubWorkflow) syncTriggerableWorkflows(ctx context.Context) {
 	m.tableReady = true
 	m.modelError.SetSuccessMessage(fmt.Sprintf("[%s@%s] Triggerable workflows fetched.", m.SelectedRepository.RepositoryName, m.SelectedRepository.BranchName))
 
+	go m.lintWorkflows(ctx, tableRowsTriggerableWorkflow)
 	go m.Update(m) // update model
 }
 
@@ -0,0 +1,84 @@
+package ghworkflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	gu "github.com/termkit/gama/internal/github/usecase"
+	"github.com/termkit/gama/internal/terminal/handler/jobgraph"
+	hdltypes "github.com/termkit/gama/internal/terminal/handler/types"
+)
+
+// graphViewer shows the dependency graph of the jobs of a workflow.
+type graphViewer struct {
+	path   string
+	branch string
+	graph  *jobgraph.Model
+}
+
+func (m *ModelGithubWorkflow) openGraphViewer() {
+	selectedRow := m.tableTriggerableWorkflow.SelectedRow()
+	if !m.tableReady || len(selectedRow) == 0 {
+		return
+	}
+
+	viewer := &graphViewer{
+		path:   selectedRow[1],
+		branch: m.SelectedRepository.BranchName,
+		graph:  jobgraph.New(),
+	}
+	m.graphViewer = viewer
+	m.cancelSyncYAML() // cancel previous fetch
+	m.syncYAMLContext, m.cancelSyncYAML = context.WithCancel(m.closeContext)
+
+	go m.syncGraph(m.syncYAMLContext, viewer)
+}
+
+func (m *ModelGithubWorkflow) closeGraphViewer() {
+	m.cancelSyncYAML()
+	m.graphViewer = nil
+	m.modelError.Reset()
+}
+
+func (m *ModelGithubWorkflow) syncGraph(ctx context.Context, viewer *graphViewer) {
+	m.modelError.Reset()
+	m.modelError.SetProgressMessage(fmt.Sprintf("[%s@%s] Fetching the jobs of %s...", m.SelectedRepository.RepositoryName, viewer.branch, viewer.path))
+
+	output, err := m.githubUseCase.GetWorkflowGraph(ctx, gu.GetWorkflowGraphInput{
+		Repository:   m.SelectedRepository.RepositoryName,
+		Branch:       viewer.branch,
+		WorkflowFile: viewer.path,
+	})
+	if errors.Is(err, context.Canceled) {
+		return
+	} else if err != nil {
+		m.modelError.SetError(err)
+		m.modelError.SetErrorMessage("Job graph cannot be built")
+		return
+	}
+
+	viewer.graph.SetGraph(output.Graph, nil)
+	m.modelError.SetDefaultMessage(fmt.Sprintf("[%s@%s] %s, %d jobs in %d stages. Arrow keys to scroll, esc to close",
+		m.SelectedRepository.RepositoryName, viewer.branch, viewer.path, len(output.Graph.Jobs), len(output.Graph.Layers)))
+
+	go m.Update(m) // update model
+}
+
+func (m *ModelGithubWorkflow) updateGraphViewer(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc":
+		m.closeGraphViewer()
+		return nil
+	}
+
+	m.graphViewer.graph.Update(msg)
+	return nil
+}
+
+func (m *ModelGithubWorkflow) graphView() string {
+	width := *hdltypes.ScreenWidth - 6
+	height := m.Viewport.Height - 19
+	return baseStyle.Width(width).Height(height).Render(m.graphViewer.graph.View(width, height))
+}
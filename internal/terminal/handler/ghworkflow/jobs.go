@@ -0,0 +1,100 @@
+package ghworkflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	gu "github.com/termkit/gama/internal/github/usecase"
+	"github.com/termkit/gama/internal/terminal/handler/jobgraph"
+	hdltypes "github.com/termkit/gama/internal/terminal/handler/types"
+)
+
+// jobsViewer lists the jobs a run of a workflow will have, with the matrices
+// expanded with the default inputs.
+type jobsViewer struct {
+	path     string
+	branch   string
+	viewport viewport.Model
+}
+
+func (m *ModelGithubWorkflow) openJobsViewer() {
+	selectedRow := m.tableTriggerableWorkflow.SelectedRow()
+	if !m.tableReady || len(selectedRow) == 0 {
+		return
+	}
+
+	viewer := &jobsViewer{
+		path:     selectedRow[1],
+		branch:   m.SelectedRepository.BranchName,
+		viewport: viewport.New(0, 0),
+	}
+	m.jobsViewer = viewer
+	m.cancelSyncYAML() // cancel previous fetch
+	m.syncYAMLContext, m.cancelSyncYAML = context.WithCancel(m.closeContext)
+
+	go m.syncJobs(m.syncYAMLContext, viewer)
+}
+
+func (m *ModelGithubWorkflow) closeJobsViewer() {
+	m.cancelSyncYAML()
+	m.jobsViewer = nil
+	m.modelError.Reset()
+}
+
+func (m *ModelGithubWorkflow) syncJobs(ctx context.Context, viewer *jobsViewer) {
+	m.modelError.Reset()
+	m.modelError.SetProgressMessage(fmt.Sprintf("[%s@%s] Expanding the jobs of %s...", m.SelectedRepository.RepositoryName, viewer.branch, viewer.path))
+
+	output, err := m.githubUseCase.GetWorkflowJobs(ctx, gu.GetWorkflowJobsInput{
+		Repository:   m.SelectedRepository.RepositoryName,
+		Branch:       viewer.branch,
+		WorkflowFile: viewer.path,
+	})
+	if errors.Is(err, context.Canceled) {
+		return
+	} else if err != nil {
+		m.modelError.SetError(err)
+		m.modelError.SetErrorMessage("Jobs cannot be expanded")
+		return
+	}
+
+	var total, unknown int
+	for _, job := range output.Jobs {
+		total += len(job.Names)
+		if job.Err != nil {
+			unknown++
+		}
+	}
+	viewer.viewport.SetContent(jobgraph.JobList(output.Jobs))
+
+	message := fmt.Sprintf("[%s@%s] %s, %d jobs with the default inputs", m.SelectedRepository.RepositoryName, viewer.branch, viewer.path, total)
+	if unknown > 0 {
+		message += fmt.Sprintf(" and %d matrices that are not expanded", unknown)
+	}
+	m.modelError.SetDefaultMessage(message + ". Arrow keys to scroll, esc to close")
+
+	go m.Update(m) // update model
+}
+
+func (m *ModelGithubWorkflow) updateJobsViewer(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc":
+		m.closeJobsViewer()
+		return nil
+	}
+
+	var cmd tea.Cmd
+	m.jobsViewer.viewport, cmd = m.jobsViewer.viewport.Update(msg)
+	return cmd
+}
+
+func (m *ModelGithubWorkflow) jobsView() string {
+	width := *hdltypes.ScreenWidth - 6
+	height := m.Viewport.Height - 19
+	m.jobsViewer.viewport.Width = width
+	m.jobsViewer.viewport.Height = height
+	return baseStyle.Width(width).Height(height).Render(m.jobsViewer.viewport.View())
+}
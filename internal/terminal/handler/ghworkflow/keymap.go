@@ -6,15 +6,21 @@ import (
 
 type keyMap struct {
 	TabSwitch teakey.Binding
+	ViewYAML  teakey.Binding
+	ViewGraph teakey.Binding
+	ViewJobs  teakey.Binding
 }
 
 func (k keyMap) ShortHelp() []teakey.Binding {
-	return []teakey.Binding{k.TabSwitch}
+	return []teakey.Binding{k.TabSwitch, k.ViewYAML, k.ViewGraph, k.ViewJobs}
 }
 
 func (k keyMap) FullHelp() [][]teakey.Binding {
 	return [][]teakey.Binding{
 		{k.TabSwitch},
+		{k.ViewYAML},
+		{k.ViewGraph},
+		{k.ViewJobs},
 	}
 }
 
@@ -23,6 +29,18 @@ var keys = keyMap{
 		teakey.WithKeys(""), // help-only binding
 		teakey.WithHelp("shift + (← | →)", "switch tab"),
 	),
+	ViewYAML: teakey.NewBinding(
+		teakey.WithKeys("v"),
+		teakey.WithHelp("v", "view YAML"),
+	),
+	ViewGraph: teakey.NewBinding(
+		teakey.WithKeys("g"),
+		teakey.WithHelp("g", "job graph"),
+	),
+	ViewJobs: teakey.NewBinding(
+		teakey.WithKeys("m"),
+		teakey.WithHelp("m", "matrix jobs"),
+	),
 }
 
 func (m *ModelGithubWorkflow) ViewHelp() string {
@@ -0,0 +1,70 @@
+package ghworkflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/table"
+	gu "github.com/termkit/gama/internal/github/usecase"
+	pw "github.com/termkit/gama/pkg/workflow"
+)
+
+// lintWorkflows lints the listed workflows and fills in the Lint column.
+func (m *ModelGithubWorkflow) lintWorkflows(ctx context.Context, rows []table.Row) {
+	var files []string
+	for _, row := range rows {
+		files = append(files, row[1])
+	}
+
+	output, err := m.githubUseCase.LintWorkflows(ctx, gu.LintWorkflowsInput{
+		Repository:    m.SelectedRepository.RepositoryName,
+		Branch:        m.SelectedRepository.BranchName,
+		WorkflowFiles: files,
+	})
+	if errors.Is(err, context.Canceled) {
+		return
+	} else if err != nil {
+		m.modelError.SetError(err)
+		m.modelError.SetErrorMessage("Workflows cannot be linted")
+		return
+	}
+
+	var failing int
+	for i, result := range output.Results {
+		rows[i][2] = lintSummary(result)
+		if result.Err == nil && pw.HasErrors(result.Findings) {
+			failing++
+		}
+	}
+	m.tableTriggerableWorkflow.SetRows(rows)
+
+	if failing > 0 {
+		m.modelError.SetDefaultMessage(fmt.Sprintf("[%s@%s] %d of %d workflows have lint errors, v to see the findings.",
+			m.SelectedRepository.RepositoryName, m.SelectedRepository.BranchName, failing, len(rows)))
+	}
+
+	go m.Update(m) // update model
+}
+
+// lintSummary counts the findings of the most severe kind.
+func lintSummary(result gu.WorkflowFindings) string {
+	if result.Err != nil {
+		return "unavailable"
+	}
+
+	counts := make(map[pw.Severity]int)
+	for _, finding := range result.Findings {
+		counts[finding.Severity]++
+	}
+
+	for _, severity := range []pw.Severity{pw.SeverityError, pw.SeverityWarning, pw.SeverityInfo} {
+		switch count := counts[severity]; {
+		case count == 1 || count > 1 && severity == pw.SeverityInfo:
+			return fmt.Sprintf("%d %s", count, severity)
+		case count > 1:
+			return fmt.Sprintf("%d %ss", count, severity)
+		}
+	}
+	return "ok"
+}
@@ -6,5 +6,6 @@ import (
 
 var tableColumnsWorkflow = []table.Column{
 	{Title: "Workflow", Width: 32},
-	{Title: "File", Width: 48},
+	{Title: "File", Width: 36},
+	{Title: "Lint", Width: 12},
 }
@@ -0,0 +1,383 @@
+package ghworkflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	gu "github.com/termkit/gama/internal/github/usecase"
+	hdltypes "github.com/termkit/gama/internal/terminal/handler/types"
+	pw "github.com/termkit/gama/pkg/workflow"
+	py "github.com/termkit/gama/pkg/yaml"
+)
+
+var (
+	yamlStyles = map[py.TokenKind]lipgloss.Style{
+		py.TokenText:       lipgloss.NewStyle(),
+		py.TokenKey:        lipgloss.NewStyle().Foreground(lipgloss.Color("39")),
+		py.TokenString:     lipgloss.NewStyle().Foreground(lipgloss.Color("114")),
+		py.TokenNumber:     lipgloss.NewStyle().Foreground(lipgloss.Color("215")),
+		py.TokenBool:       lipgloss.NewStyle().Foreground(lipgloss.Color("170")),
+		py.TokenComment:    lipgloss.NewStyle().Foreground(lipgloss.Color("244")).Italic(true),
+		py.TokenExpression: lipgloss.NewStyle().Foreground(lipgloss.Color("220")).Bold(true),
+		py.TokenPunct:      lipgloss.NewStyle().Foreground(lipgloss.Color("245")),
+		py.TokenAnchor:     lipgloss.NewStyle().Foreground(lipgloss.Color("141")),
+	}
+	yamlMatchStyle       = lipgloss.NewStyle().Foreground(lipgloss.Color("229")).Background(lipgloss.Color("57"))
+	lineNumberStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	lineNumberMatchStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("229")).Bold(true)
+	severityStyles       = map[pw.Severity]lipgloss.Style{
+		pw.SeverityError:   lipgloss.NewStyle().Foreground(lipgloss.Color("196")),
+		pw.SeverityWarning: lipgloss.NewStyle().Foreground(lipgloss.Color("214")),
+		pw.SeverityInfo:    lipgloss.NewStyle().Foreground(lipgloss.Color("39")),
+	}
+	findingSelectedStyle = lipgloss.NewStyle().Bold(true)
+)
+
+// maxFindingLines is the height of the findings under the YAML.
+const maxFindingLines = 3
+
+// yamlViewer shows the YAML of a workflow with line numbers and search.
+type yamlViewer struct {
+	path   string
+	branch string
+	lines  []string
+	tokens [][]py.Token
+
+	query      string
+	matches    []int // lines matching the query
+	matchIndex int
+	searching  bool
+
+	findings     []pw.Finding
+	findingLines map[int]pw.Severity // most severe finding of each line
+	findingIndex int
+
+	renderedWidth int // width the content was rendered for, 0 when it must be rendered again
+
+	viewport    viewport.Model
+	searchInput textinput.Model
+}
+
+func (m *ModelGithubWorkflow) openYAMLViewer() {
+	selectedRow := m.tableTriggerableWorkflow.SelectedRow()
+	if !m.tableReady || len(selectedRow) == 0 {
+		return
+	}
+
+	si := textinput.New()
+	si.Prompt = "/"
+	si.CharLimit = 64
+
+	viewer := &yamlViewer{
+		path:        selectedRow[1],
+		branch:      m.SelectedRepository.BranchName,
+		viewport:    viewport.New(0, 0),
+		searchInput: si,
+	}
+	m.yamlViewer = viewer
+	m.cancelSyncYAML() // cancel previous fetch
+	m.syncYAMLContext, m.cancelSyncYAML = context.WithCancel(m.closeContext)
+
+	go m.syncYAML(m.syncYAMLContext, viewer)
+}
+
+func (m *ModelGithubWorkflow) closeYAMLViewer() {
+	m.cancelSyncYAML()
+	m.yamlViewer = nil
+	m.modelError.Reset()
+}
+
+func (m *ModelGithubWorkflow) syncYAML(ctx context.Context, viewer *yamlViewer) {
+	m.modelError.Reset()
+	m.modelError.SetProgressMessage(fmt.Sprintf("[%s@%s] Fetching %s...", m.SelectedRepository.RepositoryName, viewer.branch, viewer.path))
+
+	output, err := m.githubUseCase.GetWorkflowFile(ctx, gu.GetWorkflowFileInput{
+		Repository:   m.SelectedRepository.RepositoryName,
+		Branch:       viewer.branch,
+		WorkflowFile: viewer.path,
+	})
+	if errors.Is(err, context.Canceled) {
+		return
+	} else if err != nil {
+		m.modelError.SetError(err)
+		m.modelError.SetErrorMessage("Workflow file cannot be fetched")
+		return
+	}
+
+	viewer.lines = strings.Split(strings.TrimSuffix(output.Content, "\n"), "\n")
+	viewer.tokens = py.Highlight(output.Content)
+	viewer.findings = pw.Lint([]byte(output.Content))
+	if security, err := pw.CheckSecurity([]byte(output.Content)); err == nil {
+		viewer.findings = append(viewer.findings, security...)
+		slices.SortStableFunc(viewer.findings, func(a, b pw.Finding) int {
+			if a.Line != b.Line {
+				return a.Line - b.Line
+			}
+			return a.Column - b.Column
+		})
+	}
+	viewer.findingLines = make(map[int]pw.Severity)
+	for _, finding := range viewer.findings {
+		if severity, ok := viewer.findingLines[finding.Line-1]; !ok || finding.Severity > severity {
+			viewer.findingLines[finding.Line-1] = finding.Severity
+		}
+	}
+	viewer.renderedWidth = 0
+
+	if len(viewer.findings) > 0 {
+		m.modelError.SetDefaultMessage(fmt.Sprintf("[%s@%s] %s, %d lines, %d findings. e/E for the next/previous finding, / to search, esc to close",
+			m.SelectedRepository.RepositoryName, viewer.branch, viewer.path, len(viewer.lines), len(viewer.findings)))
+	} else {
+		m.modelError.SetDefaultMessage(fmt.Sprintf("[%s@%s] %s, %d lines. / to search, n/N for the next/previous match, esc to close",
+			m.SelectedRepository.RepositoryName, viewer.branch, viewer.path, len(viewer.lines)))
+	}
+
+	go m.Update(m) // update model
+}
+
+func (m *ModelGithubWorkflow) updateYAMLViewer(msg tea.KeyMsg) tea.Cmd {
+	viewer := m.yamlViewer
+
+	if viewer.searching {
+		switch msg.String() {
+		case "esc":
+			viewer.searching = false
+			viewer.searchInput.Blur()
+			return nil
+		case "enter":
+			viewer.searching = false
+			viewer.searchInput.Blur()
+			m.search(viewer.searchInput.Value())
+			return nil
+		}
+
+		var cmd tea.Cmd
+		viewer.searchInput, cmd = viewer.searchInput.Update(msg)
+		return cmd
+	}
+
+	switch msg.String() {
+	case "esc":
+		m.closeYAMLViewer()
+		return nil
+	case "/":
+		viewer.searching = true
+		viewer.searchInput.SetValue(viewer.query)
+		viewer.searchInput.CursorEnd()
+		return viewer.searchInput.Focus()
+	case "n":
+		m.jumpToMatch(viewer.matchIndex + 1)
+		return nil
+	case "N":
+		m.jumpToMatch(viewer.matchIndex - 1)
+		return nil
+	case "e":
+		m.jumpToFinding(viewer.findingIndex + 1)
+		return nil
+	case "E":
+		m.jumpToFinding(viewer.findingIndex - 1)
+		return nil
+	}
+
+	var cmd tea.Cmd
+	viewer.viewport, cmd = viewer.viewport.Update(msg)
+	return cmd
+}
+
+func (m *ModelGithubWorkflow) search(query string) {
+	viewer := m.yamlViewer
+	viewer.query = query
+	viewer.matches = nil
+	viewer.renderedWidth = 0
+
+	if query == "" {
+		m.modelError.SetDefaultMessage(fmt.Sprintf("%s, %d lines.", viewer.path, len(viewer.lines)))
+		return
+	}
+
+	for i, line := range viewer.lines {
+		if len(matchRanges(line, query)) > 0 {
+			viewer.matches = append(viewer.matches, i)
+		}
+	}
+	if len(viewer.matches) == 0 {
+		m.modelError.SetDefaultMessage(fmt.Sprintf("No match for %q.", query))
+		return
+	}
+
+	// Start from the first match below the top of the view
+	first := 0
+	for i, line := range viewer.matches {
+		if line >= viewer.viewport.YOffset {
+			first = i
+			break
+		}
+	}
+	m.jumpToMatch(first)
+}
+
+func (m *ModelGithubWorkflow) jumpToMatch(index int) {
+	viewer := m.yamlViewer
+	if len(viewer.matches) == 0 {
+		return
+	}
+
+	viewer.matchIndex = (index + len(viewer.matches)) % len(viewer.matches)
+	viewer.renderedWidth = 0 // the line number of the current match is highlighted
+	m.renderYAML(viewer.viewport.Width)
+	viewer.viewport.SetYOffset(viewer.matches[viewer.matchIndex] - viewer.viewport.Height/2)
+
+	m.modelError.SetDefaultMessage(fmt.Sprintf("Match %d of %d for %q on line %d.",
+		viewer.matchIndex+1, len(viewer.matches), viewer.query, viewer.matches[viewer.matchIndex]+1))
+}
+
+func (m *ModelGithubWorkflow) jumpToFinding(index int) {
+	viewer := m.yamlViewer
+	if len(viewer.findings) == 0 {
+		return
+	}
+
+	viewer.findingIndex = (index + len(viewer.findings)) % len(viewer.findings)
+	finding := viewer.findings[viewer.findingIndex]
+	viewer.viewport.SetYOffset(finding.Line - 1 - viewer.viewport.Height/2)
+
+	m.modelError.SetDefaultMessage(fmt.Sprintf("Finding %d of %d on line %d.", viewer.findingIndex+1, len(viewer.findings), finding.Line))
+}
+
+func (m *ModelGithubWorkflow) yamlView() string {
+	viewer := m.yamlViewer
+
+	width := *hdltypes.ScreenWidth - 6
+	height := m.Viewport.Height - 19
+	if viewer.searching || viewer.query != "" {
+		height--
+	}
+	findings := m.findingsView(width)
+	if findings != "" {
+		height -= lipgloss.Height(findings)
+	}
+	viewer.viewport.Width = width
+	viewer.viewport.Height = max(height, 1)
+	m.renderYAML(width)
+
+	views := []string{baseStyle.Render(viewer.viewport.View())}
+	if findings != "" {
+		views = append(views, findings)
+	}
+	if viewer.searching {
+		views = append(views, viewer.searchInput.View())
+	} else if viewer.query != "" {
+		views = append(views, lineNumberStyle.Render("/"+viewer.query))
+	}
+	return lipgloss.JoinVertical(lipgloss.Top, views...)
+}
+
+// findingsView lists the lint findings around the current one.
+func (m *ModelGithubWorkflow) findingsView(width int) string {
+	viewer := m.yamlViewer
+	if len(viewer.findings) == 0 {
+		return ""
+	}
+
+	first := max(0, min(viewer.findingIndex-maxFindingLines/2, len(viewer.findings)-maxFindingLines))
+	last := min(first+maxFindingLines, len(viewer.findings))
+
+	lineStyle := lipgloss.NewStyle().MaxWidth(width)
+	var lines []string
+	for i := first; i < last; i++ {
+		finding := viewer.findings[i]
+		position := fmt.Sprintf("%d:%d", finding.Line, finding.Column)
+		message := fmt.Sprintf("%s [%s]", finding.Message, finding.Rule)
+		if i == viewer.findingIndex {
+			message = findingSelectedStyle.Render(message)
+		}
+		lines = append(lines, lineStyle.Render(fmt.Sprintf(" %-8s %s %s",
+			position, severityStyles[finding.Severity].Render(fmt.Sprintf("%-7s", finding.Severity)), message)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderYAML renders the lines for the width, unless they already are.
+func (m *ModelGithubWorkflow) renderYAML(width int) {
+	viewer := m.yamlViewer
+	if viewer.renderedWidth == width || width <= 0 {
+		return
+	}
+	viewer.renderedWidth = width
+
+	gutterWidth := len(fmt.Sprint(len(viewer.lines)))
+	currentMatch := -1
+	if len(viewer.matches) > 0 {
+		currentMatch = viewer.matches[viewer.matchIndex]
+	}
+
+	lineStyle := lipgloss.NewStyle().MaxWidth(width)
+	rendered := make([]string, len(viewer.lines))
+	for i := range viewer.lines {
+		numberStyle := lineNumberStyle
+		if i == currentMatch {
+			numberStyle = lineNumberMatchStyle
+		}
+		separator := lineNumberStyle.Render("│")
+		if severity, ok := viewer.findingLines[i]; ok {
+			separator = severityStyles[severity].Render("●")
+		}
+		gutter := numberStyle.Render(fmt.Sprintf("%*d ", gutterWidth, i+1)) + separator + " "
+		rendered[i] = lineStyle.Render(gutter + renderTokens(viewer.tokens[i], matchRanges(viewer.lines[i], viewer.query)))
+	}
+
+	viewer.viewport.SetContent(strings.Join(rendered, "\n"))
+}
+
+// renderTokens styles the tokens of a line, with the byte ranges of search matches highlighted.
+func renderTokens(tokens []py.Token, matches [][2]int) string {
+	var b strings.Builder
+	var offset int
+	for _, token := range tokens {
+		style := yamlStyles[token.Kind]
+		start, end := offset, offset+len(token.Text)
+		offset = end
+
+		position := start
+		for _, match := range matches {
+			if match[1] <= position || match[0] >= end {
+				continue
+			}
+			matchStart, matchEnd := max(match[0], position), min(match[1], end)
+			b.WriteString(style.Render(token.Text[position-start : matchStart-start]))
+			b.WriteString(yamlMatchStyle.Render(token.Text[matchStart-start : matchEnd-start]))
+			position = matchEnd
+		}
+		b.WriteString(style.Render(token.Text[position-start:]))
+	}
+	return b.String()
+}
+
+// matchRanges returns the byte ranges of the query in the line, ignoring case.
+func matchRanges(line string, query string) [][2]int {
+	if query == "" {
+		return nil
+	}
+
+	haystack, needle := strings.ToLower(line), strings.ToLower(query)
+	if len(haystack) != len(line) || len(needle) != len(query) {
+		haystack, needle = line, query // lowercasing moved the bytes, match the case
+	}
+
+	var ranges [][2]int
+	for offset := 0; ; {
+		index := strings.Index(haystack[offset:], needle)
+		if index < 0 {
+			return ranges
+		}
+		ranges = append(ranges, [2]int{offset + index, offset + index + len(needle)})
+		offset += index + len(needle)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
@@ -26,10 +27,15 @@ type ModelGithubWorkflowHistory struct {
 	selectedWorkflowID         int64
 	isTableFocused             bool
 	lastRepository             string
+	lastBranch                 string
 	forceUpdate                *bool
 	syncWorkflowHistoryContext context.Context
 	cancelSyncWorkflowHistory  context.CancelFunc
+	closeContext               context.Context
+	close                      context.CancelFunc
 	Workflows                  []gu.Workflow
+	runGraph                   *runGraph  // nil when the job graph of a run is not shown
+	runMatrix                  *runMatrix // nil when the matrix of a run is not shown
 
 	// shared properties
 	SelectedRepository *hdltypes.SelectedRepository
@@ -78,6 +84,8 @@ func SetupModelGithubWorkflowHistory(githubUseCase gu.UseCase, selectedRepositor
 
 	tabOptions := taboptions.NewOptions()
 
+	closeContext, closeFunc := context.WithCancel(context.Background())
+
 	return &ModelGithubWorkflowHistory{
 		Help:                       help.New(),
 		Keys:                       keys,
@@ -90,14 +98,22 @@ func SetupModelGithubWorkflowHistory(githubUseCase gu.UseCase, selectedRepositor
 		forceUpdate:                forceUpdate,
 		syncWorkflowHistoryContext: context.Background(),
 		cancelSyncWorkflowHistory:  func() {},
+		closeContext:               closeContext,
+		close:                      closeFunc,
 	}
 }
 
+// Close stops the background work of the model, it is not usable afterwards.
+func (m *ModelGithubWorkflowHistory) Close() {
+	m.cancelSyncWorkflowHistory()
+	m.close()
+}
+
 func (m *ModelGithubWorkflowHistory) Init() tea.Cmd {
 	openInBrowser := func() {
 		m.modelError.SetProgressMessage(fmt.Sprintf("Opening in browser..."))
 
-		var selectedWorkflow = fmt.Sprintf("https://github.com/%s/actions/runs/%d", m.SelectedRepository.RepositoryName, m.selectedWorkflowID)
+		var selectedWorkflow = fmt.Sprintf("%s/%s/actions/runs/%d", m.githubUseCase.WebURL(), m.SelectedRepository.RepositoryName, m.selectedWorkflowID)
 
 		err := browser.OpenInBrowser(selectedWorkflow)
 		if err != nil {
@@ -165,7 +181,14 @@ func (m *ModelGithubWorkflowHistory) Init() tea.Cmd {
 
 	go func() {
 		// Make it works with to channels
+		ticker := time.NewTicker(100 * time.Millisecond)
+		defer ticker.Stop()
 		for {
+			select {
+			case <-m.closeContext.Done():
+				return
+			case <-ticker.C:
+			}
 			if *m.forceUpdate {
 				m.tableReady = false
 				go m.syncWorkflowHistory(m.syncWorkflowHistoryContext)
@@ -178,11 +201,19 @@ func (m *ModelGithubWorkflowHistory) Init() tea.Cmd {
 }
 
 func (m *ModelGithubWorkflowHistory) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	if m.lastRepository != m.SelectedRepository.RepositoryName {
+	if m.lastRepository != m.SelectedRepository.RepositoryName || m.lastBranch != m.SelectedRepository.BranchName {
 		m.tableReady = false
 		m.cancelSyncWorkflowHistory() // cancel previous sync
 
 		m.lastRepository = m.SelectedRepository.RepositoryName
+		m.lastBranch = m.SelectedRepository.BranchName
+
+		if m.runGraph != nil {
+			m.closeRunGraph()
+		}
+		if m.runMatrix != nil {
+			m.closeRunMatrix()
+		}
 
 		m.syncWorkflowHistoryContext, m.cancelSyncWorkflowHistory = context.WithCancel(context.Background())
 		go m.syncWorkflowHistory(m.syncWorkflowHistoryContext)
@@ -196,10 +227,22 @@ func (m *ModelGithubWorkflowHistory) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if m.runGraph != nil {
+			return m, m.updateRunGraph(msg)
+		}
+		if m.runMatrix != nil {
+			return m, m.updateRunMatrix(msg)
+		}
 		switch {
 		case key.Matches(msg, m.Keys.Refresh):
 			m.tableReady = false
 			go m.syncWorkflowHistory(m.syncWorkflowHistoryContext)
+		case key.Matches(msg, m.Keys.JobGraph):
+			m.openRunGraph()
+			return m, nil
+		case key.Matches(msg, m.Keys.RunMatrix):
+			m.openRunMatrix()
+			return m, nil
 		}
 	}
 
@@ -288,6 +331,13 @@ func (m *ModelGithubWorkflowHistory) View() string {
 
 	m.tableWorkflowHistory.SetHeight(termHeight - 17)
 
+	if m.runGraph != nil {
+		return m.runGraphView()
+	}
+	if m.runMatrix != nil {
+		return m.runMatrixView()
+	}
+
 	doc := strings.Builder{}
 	doc.WriteString(baseStyle.Render(m.tableWorkflowHistory.View()))
 
@@ -0,0 +1,110 @@
+package ghworkflowhistory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	gu "github.com/termkit/gama/internal/github/usecase"
+	"github.com/termkit/gama/internal/terminal/handler/jobgraph"
+	hdltypes "github.com/termkit/gama/internal/terminal/handler/types"
+)
+
+// runGraphRefresh is how often the jobs of a run are fetched until it completes.
+const runGraphRefresh = 5 * time.Second
+
+// runGraph shows the job graph of a run with the status of its jobs.
+type runGraph struct {
+	run   gu.Workflow
+	graph *jobgraph.Model
+
+	context context.Context
+	cancel  context.CancelFunc
+}
+
+func (m *ModelGithubWorkflowHistory) openRunGraph() {
+	cursor := m.tableWorkflowHistory.Cursor()
+	if !m.tableReady || cursor < 0 || cursor >= len(m.Workflows) {
+		return
+	}
+
+	graph := &runGraph{run: m.Workflows[cursor], graph: jobgraph.New()}
+	graph.context, graph.cancel = context.WithCancel(m.closeContext)
+	m.runGraph = graph
+
+	go m.watchRunGraph(graph)
+}
+
+func (m *ModelGithubWorkflowHistory) closeRunGraph() {
+	m.runGraph.cancel()
+	m.runGraph = nil
+	m.modelError.Reset()
+}
+
+// watchRunGraph fetches the jobs of the run until it completes.
+func (m *ModelGithubWorkflowHistory) watchRunGraph(graph *runGraph) {
+	m.modelError.Reset()
+	m.modelError.SetProgressMessage(fmt.Sprintf("[%s] Fetching the jobs of %s...", m.SelectedRepository.RepositoryName, graph.run.WorkflowName))
+
+	for {
+		output, err := m.githubUseCase.GetWorkflowGraph(graph.context, gu.GetWorkflowGraphInput{
+			Repository: m.SelectedRepository.RepositoryName,
+			RunID:      graph.run.ID,
+		})
+		if errors.Is(err, context.Canceled) {
+			return
+		} else if err != nil {
+			m.modelError.SetError(err)
+			m.modelError.SetErrorMessage("Job graph cannot be built")
+			return
+		}
+
+		graph.graph.SetGraph(output.Graph, output.Jobs)
+
+		var jobs, completed int
+		for _, job := range output.Jobs {
+			jobs += job.Jobs
+			completed += job.Completed
+		}
+		status := strings.ReplaceAll(output.RunStatus, "_", " ")
+		if output.RunStatus == "completed" {
+			status = strings.ReplaceAll(output.RunConclusion, "_", " ")
+		}
+		message := fmt.Sprintf("[%s] %s: %s, %d of %d jobs completed.", m.SelectedRepository.RepositoryName, graph.run.WorkflowName, status, completed, jobs)
+		if output.RunStatus != "completed" {
+			message += fmt.Sprintf(" Refreshed every %s,", runGraphRefresh)
+		}
+		m.modelError.SetDefaultMessage(message + " arrow keys to scroll, esc to close")
+
+		go m.Update(m) // update model
+
+		if output.RunStatus == "completed" {
+			return
+		}
+		select {
+		case <-graph.context.Done():
+			return
+		case <-time.After(runGraphRefresh):
+		}
+	}
+}
+
+func (m *ModelGithubWorkflowHistory) updateRunGraph(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc":
+		m.closeRunGraph()
+		return nil
+	}
+
+	m.runGraph.graph.Update(msg)
+	return nil
+}
+
+func (m *ModelGithubWorkflowHistory) runGraphView() string {
+	width := *hdltypes.ScreenWidth - 6
+	height := m.Viewport.Height - 19
+	return baseStyle.Width(width).Height(height).Render(m.runGraph.graph.View(width, height))
+}
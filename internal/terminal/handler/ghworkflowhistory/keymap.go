@@ -7,17 +7,21 @@ import (
 type keyMap struct {
 	LaunchTab teakey.Binding
 	Refresh   teakey.Binding
+	JobGraph  teakey.Binding
+	RunMatrix teakey.Binding
 	TabSwitch teakey.Binding
 }
 
 func (k keyMap) ShortHelp() []teakey.Binding {
-	return []teakey.Binding{k.TabSwitch, k.Refresh, k.LaunchTab}
+	return []teakey.Binding{k.TabSwitch, k.Refresh, k.JobGraph, k.RunMatrix, k.LaunchTab}
 }
 
 func (k keyMap) FullHelp() [][]teakey.Binding {
 	return [][]teakey.Binding{
 		{k.TabSwitch},
 		{k.Refresh},
+		{k.JobGraph},
+		{k.RunMatrix},
 		{k.LaunchTab},
 	}
 }
@@ -27,6 +31,14 @@ var keys = keyMap{
 		teakey.WithKeys("r", "R"),
 		teakey.WithHelp("r/R", "Refresh list"),
 	),
+	JobGraph: teakey.NewBinding(
+		teakey.WithKeys("g"),
+		teakey.WithHelp("g", "Job graph of the run"),
+	),
+	RunMatrix: teakey.NewBinding(
+		teakey.WithKeys("x"),
+		teakey.WithHelp("x", "Matrix of the run"),
+	),
 	LaunchTab: teakey.NewBinding(
 		teakey.WithKeys("enter"),
 		teakey.WithHelp("enter", "Launch the selected option"),
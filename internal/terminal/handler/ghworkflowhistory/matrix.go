@@ -0,0 +1,103 @@
+package ghworkflowhistory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	gu "github.com/termkit/gama/internal/github/usecase"
+	"github.com/termkit/gama/internal/terminal/handler/jobgraph"
+	hdltypes "github.com/termkit/gama/internal/terminal/handler/types"
+)
+
+// runMatrix shows the jobs of the matrices of a run coloured by their conclusion.
+type runMatrix struct {
+	run      gu.Workflow
+	viewport viewport.Model
+
+	context context.Context
+	cancel  context.CancelFunc
+}
+
+func (m *ModelGithubWorkflowHistory) openRunMatrix() {
+	cursor := m.tableWorkflowHistory.Cursor()
+	if !m.tableReady || cursor < 0 || cursor >= len(m.Workflows) {
+		return
+	}
+
+	m.runMatrix = &runMatrix{run: m.Workflows[cursor], viewport: viewport.New(0, 0)}
+	m.syncRunMatrix()
+}
+
+func (m *ModelGithubWorkflowHistory) closeRunMatrix() {
+	m.runMatrix.cancel()
+	m.runMatrix = nil
+	m.modelError.Reset()
+}
+
+func (m *ModelGithubWorkflowHistory) syncRunMatrix() {
+	matrix := m.runMatrix
+	if matrix.cancel != nil {
+		matrix.cancel() // cancel previous fetch
+	}
+	matrix.context, matrix.cancel = context.WithCancel(m.closeContext)
+
+	go m.fetchRunMatrix(matrix)
+}
+
+func (m *ModelGithubWorkflowHistory) fetchRunMatrix(matrix *runMatrix) {
+	m.modelError.Reset()
+	m.modelError.SetProgressMessage(fmt.Sprintf("[%s] Fetching the jobs of %s...", m.SelectedRepository.RepositoryName, matrix.run.WorkflowName))
+
+	output, err := m.githubUseCase.GetRunMatrix(matrix.context, gu.GetRunMatrixInput{
+		Repository: m.SelectedRepository.RepositoryName,
+		RunID:      matrix.run.ID,
+	})
+	if errors.Is(err, context.Canceled) {
+		return
+	} else if err != nil {
+		m.modelError.SetError(err)
+		m.modelError.SetErrorMessage("Matrix of the run cannot be built")
+		return
+	}
+
+	status := strings.ReplaceAll(output.RunStatus, "_", " ")
+	if output.RunStatus == "completed" {
+		status = strings.ReplaceAll(output.RunConclusion, "_", " ")
+	}
+	if len(output.Matrices) == 0 {
+		matrix.viewport.SetContent("The workflow of the run has no matrix")
+	} else {
+		matrix.viewport.SetContent(jobgraph.MatrixGrid(output.Matrices))
+	}
+	m.modelError.SetDefaultMessage(fmt.Sprintf("[%s] %s: %s, %d matrices. r to refresh, arrow keys to scroll, esc to close",
+		m.SelectedRepository.RepositoryName, matrix.run.WorkflowName, status, len(output.Matrices)))
+
+	go m.Update(m) // update model
+}
+
+func (m *ModelGithubWorkflowHistory) updateRunMatrix(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc":
+		m.closeRunMatrix()
+		return nil
+	case "r", "R":
+		m.syncRunMatrix()
+		return nil
+	}
+
+	var cmd tea.Cmd
+	m.runMatrix.viewport, cmd = m.runMatrix.viewport.Update(msg)
+	return cmd
+}
+
+func (m *ModelGithubWorkflowHistory) runMatrixView() string {
+	width := *hdltypes.ScreenWidth - 6
+	height := m.Viewport.Height - 19
+	m.runMatrix.viewport.Width = width
+	m.runMatrix.viewport.Height = height
+	return baseStyle.Width(width).Height(height).Render(m.runMatrix.viewport.View())
+}
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"fmt"
+	"log/slog"
 	"strings"
 	"time"
 
@@ -11,6 +12,8 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	gu "github.com/termkit/gama/internal/github/usecase"
+	hdlauditlog "github.com/termkit/gama/internal/terminal/handler/auditlog"
+	hdldispatch "github.com/termkit/gama/internal/terminal/handler/ghdispatch"
 	hdlgithubrepo "github.com/termkit/gama/internal/terminal/handler/ghrepository"
 	hdltrigger "github.com/termkit/gama/internal/terminal/handler/ghtrigger"
 	hdlWorkflow "github.com/termkit/gama/internal/terminal/handler/ghworkflow"
@@ -19,6 +22,8 @@ import (
 	hdltypes "github.com/termkit/gama/internal/terminal/handler/types"
 	ts "github.com/termkit/gama/internal/terminal/style"
 	vu "github.com/termkit/gama/internal/version/usecase"
+	pkgaudit "github.com/termkit/gama/pkg/audit"
+	pkgpreset "github.com/termkit/gama/pkg/preset"
 )
 
 type model struct {
@@ -31,6 +36,12 @@ type model struct {
 	// Shared properties
 	SelectedRepository *hdltypes.SelectedRepository
 	lockTabs           *bool // lockTabs will be set true if test connection fails
+	profiles           *hdltypes.Profiles
+	presets            *pkgpreset.Store
+	auditLog           *pkgaudit.Log
+
+	// use cases
+	versionUseCase vu.UseCase
 
 	// models
 	viewport viewport.Model
@@ -51,49 +62,70 @@ type model struct {
 	modelTrigger       tea.Model
 	actualModelTrigger *hdltrigger.ModelGithubTrigger
 
+	modelDispatch       tea.Model
+	actualModelDispatch *hdldispatch.ModelGithubDispatch
+
+	modelAuditLog       tea.Model
+	actualModelAuditLog *hdlauditlog.ModelAuditLog
+
 	// keymap
 	keys keyMap
 }
 
-func SetupTerminal(githubUseCase gu.UseCase, versionUseCase vu.UseCase) tea.Model {
-	var currentTab = new(int)
+func SetupTerminal(githubUseCase gu.UseCase, versionUseCase vu.UseCase, profiles *hdltypes.Profiles, presets *pkgpreset.Store, auditLog *pkgaudit.Log) tea.Model {
+	tabsWithColor := []string{"Info", "Repository", "Workflow History", "Workflow", "Trigger", "Events", "Audit"}
+
+	m := model{
+		currentTab:     new(int),
+		TabsWithColor:  tabsWithColor,
+		timer:          timer.NewWithInterval(1<<63-1, time.Millisecond*200),
+		profiles:       profiles,
+		versionUseCase: versionUseCase,
+		presets:        presets,
+		auditLog:       auditLog,
+		keys:           keys,
+	}
+
+	m.setupTabs(githubUseCase)
+
+	return &m
+}
+
+// setupTabs (re)creates every tab with the given github use case.
+func (m *model) setupTabs(githubUseCase gu.UseCase) {
 	var forceUpdateWorkflowHistory = new(bool)
 	var lockTabs = new(bool)
 
 	*lockTabs = true // by default lock tabs
 
-	tabsWithColor := []string{"Info", "Repository", "Workflow History", "Workflow", "Trigger"}
-
 	selectedRepository := hdltypes.SelectedRepository{}
 
 	// setup models
-	hdlModelInfo := hdlinfo.SetupModelInfo(githubUseCase, versionUseCase, lockTabs)
+	hdlModelInfo := hdlinfo.SetupModelInfo(githubUseCase, m.versionUseCase, lockTabs, m.profiles)
 	hdlModelGithubRepository := hdlgithubrepo.SetupModelGithubRepository(githubUseCase, &selectedRepository)
 	hdlModelWorkflowHistory := hdlworkflowhistory.SetupModelGithubWorkflowHistory(githubUseCase, &selectedRepository, forceUpdateWorkflowHistory)
 	hdlModelWorkflow := hdlWorkflow.SetupModelGithubWorkflow(githubUseCase, &selectedRepository)
-	hdlModelTrigger := hdltrigger.SetupModelGithubTrigger(githubUseCase, &selectedRepository, currentTab, forceUpdateWorkflowHistory)
-
-	m := model{
-		lockTabs:      lockTabs,
-		currentTab:    currentTab,
-		TabsWithColor: tabsWithColor,
-		timer:         timer.NewWithInterval(1<<63-1, time.Millisecond*200),
-		modelInfo:     hdlModelInfo, actualModelInfo: hdlModelInfo,
-		SelectedRepository:    &selectedRepository,
-		modelGithubRepository: hdlModelGithubRepository, actualModelGithubRepository: hdlModelGithubRepository,
-		modelWorkflowHistory: hdlModelWorkflowHistory, directModelWorkflowHistory: hdlModelWorkflowHistory,
-		modelWorkflow: hdlModelWorkflow, directModelWorkflow: hdlModelWorkflow,
-		modelTrigger: hdlModelTrigger, actualModelTrigger: hdlModelTrigger,
-		keys: keys,
-	}
+	hdlModelTrigger := hdltrigger.SetupModelGithubTrigger(githubUseCase, m.presets, &selectedRepository, m.currentTab, forceUpdateWorkflowHistory)
+	hdlModelDispatch := hdldispatch.SetupModelGithubDispatch(githubUseCase, &selectedRepository)
+	hdlModelAuditLog := hdlauditlog.SetupModelAuditLog(githubUseCase, m.auditLog)
+
+	m.lockTabs = lockTabs
+	m.SelectedRepository = &selectedRepository
+	m.modelInfo, m.actualModelInfo = hdlModelInfo, hdlModelInfo
+	m.modelGithubRepository, m.actualModelGithubRepository = hdlModelGithubRepository, hdlModelGithubRepository
+	m.modelWorkflowHistory, m.directModelWorkflowHistory = hdlModelWorkflowHistory, hdlModelWorkflowHistory
+	m.modelWorkflow, m.directModelWorkflow = hdlModelWorkflow, hdlModelWorkflow
+	m.modelTrigger, m.actualModelTrigger = hdlModelTrigger, hdlModelTrigger
+	m.modelDispatch, m.actualModelDispatch = hdlModelDispatch, hdlModelDispatch
+	m.modelAuditLog, m.actualModelAuditLog = hdlModelAuditLog, hdlModelAuditLog
 
 	hdlModelInfo.Viewport = &m.viewport
 	hdlModelGithubRepository.Viewport = &m.viewport
 	hdlModelWorkflowHistory.Viewport = &m.viewport
 	hdlModelWorkflow.Viewport = &m.viewport
 	hdlModelTrigger.Viewport = &m.viewport
-
-	return &m
+	hdlModelDispatch.Viewport = &m.viewport
+	hdlModelAuditLog.Viewport = &m.viewport
 }
 
 func (m *model) Init() tea.Cmd {
@@ -101,17 +133,49 @@ func (m *model) Init() tea.Cmd {
 		tea.EnterAltScreen,
 		tea.SetWindowTitle("GitHub Actions Manager (GAMA)"),
 		m.timer.Init(),
+		m.initTabs())
+}
+
+func (m *model) initTabs() tea.Cmd {
+	return tea.Batch(
 		m.modelInfo.Init(),
 		m.modelGithubRepository.Init(),
 		m.modelWorkflowHistory.Init(),
 		m.modelWorkflow.Init(),
-		m.modelTrigger.Init())
+		m.modelTrigger.Init(),
+		m.modelDispatch.Init(),
+		m.modelAuditLog.Init())
+}
+
+// switchProfile replaces every tab with fresh ones built on the new profile's use case.
+func (m *model) switchProfile(msg hdltypes.ProfileSwitchedMsg) tea.Cmd {
+	m.closeTabs() // stop background work of the old tabs
+
+	m.profiles.Current = msg.Name
+	*m.currentTab = 0
+
+	m.setupTabs(msg.GithubUseCase)
+
+	return m.initTabs()
+}
+
+// closeTabs stops the background work of every tab.
+func (m *model) closeTabs() {
+	m.actualModelInfo.Close()
+	m.actualModelGithubRepository.Close()
+	m.directModelWorkflowHistory.Close()
+	m.directModelWorkflow.Close()
+	m.actualModelTrigger.Close()
+	m.actualModelDispatch.Close()
+	m.actualModelAuditLog.Close()
 }
 
 func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	// Sync terminal size
 	m.syncTerminal(msg)
 
+	m.logMessage(msg)
+
 	var cmd tea.Cmd
 	var cmds []tea.Cmd
 
@@ -136,6 +200,10 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case timer.TickMsg:
 		m.timer, cmd = m.timer.Update(msg)
 		cmds = append(cmds, cmd)
+	case hdltypes.ProfileSwitchedMsg:
+		cmds = append(cmds, m.switchProfile(msg))
+	case hdltypes.EditorFinishedMsg:
+		cmds = append(cmds, m.handleTabContent(cmd, msg))
 	}
 
 	return m, tea.Batch(cmds...)
@@ -208,6 +276,14 @@ func (m *model) View() string {
 		mainDoc.WriteString(dynamicWindowStyle.Render(m.modelTrigger.View()))
 		operationDoc = operationWindowStyle.Render(m.actualModelTrigger.ViewStatus())
 		helpDoc = helpWindowStyle.Render(m.actualModelTrigger.ViewHelp())
+	case 5:
+		mainDoc.WriteString(dynamicWindowStyle.Render(m.modelDispatch.View()))
+		operationDoc = operationWindowStyle.Render(m.actualModelDispatch.ViewStatus())
+		helpDoc = helpWindowStyle.Render(m.actualModelDispatch.ViewHelp())
+	case 6:
+		mainDoc.WriteString(dynamicWindowStyle.Render(m.modelAuditLog.View()))
+		operationDoc = operationWindowStyle.Render(m.actualModelAuditLog.ViewStatus())
+		helpDoc = helpWindowStyle.Render(m.actualModelAuditLog.ViewHelp())
 	}
 
 	mainDocContent := ts.DocStyle.Render(mainDoc.String())
@@ -252,16 +328,37 @@ func (m *model) handleTabContent(cmd tea.Cmd, msg tea.Msg) tea.Cmd {
 		m.modelWorkflow, cmd = m.modelWorkflow.Update(msg)
 	case 4:
 		m.modelTrigger, cmd = m.modelTrigger.Update(msg)
+	case 5:
+		m.modelDispatch, cmd = m.modelDispatch.Update(msg)
+	case 6:
+		m.modelAuditLog, cmd = m.modelAuditLog.Update(msg)
 	}
 	return cmd
 }
 
+// logMessage traces the message flow in debug mode. Timer ticks are skipped as they
+// fire every 200ms, and typed characters are not logged since they may be secrets.
+func (m *model) logMessage(msg tea.Msg) {
+	switch msg := msg.(type) {
+	case timer.TickMsg:
+		return
+	case tea.KeyMsg:
+		keyName := msg.String()
+		if msg.Type == tea.KeyRunes {
+			keyName = "runes"
+		}
+		slog.Debug("tea message", "type", fmt.Sprintf("%T", msg), "key", keyName, "tab", *m.currentTab)
+	default:
+		slog.Debug("tea message", "type", fmt.Sprintf("%T", msg), "tab", *m.currentTab)
+	}
+}
+
 func (m *model) headerView(titles ...string) string {
-	var renderedTitles string
+	var titlesWidth int
 	for _, t := range titles {
-		renderedTitles += t
+		titlesWidth += lipgloss.Width(t)
 	}
-	line := strings.Repeat("─", max(0, m.viewport.Width-79))
+	line := strings.Repeat("─", max(0, m.viewport.Width-titlesWidth-4))
 	titles = append(titles, line)
 	return lipgloss.JoinHorizontal(lipgloss.Center, titles...)
 }
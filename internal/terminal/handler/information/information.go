@@ -2,7 +2,9 @@ package information
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -14,6 +16,7 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	gu "github.com/termkit/gama/internal/github/usecase"
 	hdlerror "github.com/termkit/gama/internal/terminal/handler/error"
+	hdltypes "github.com/termkit/gama/internal/terminal/handler/types"
 	vu "github.com/termkit/gama/internal/version/usecase"
 )
 
@@ -25,6 +28,13 @@ type ModelInfo struct {
 	// lockTabs will be set true if test connection fails
 	lockTabs *bool
 
+	// profiles are the configured profiles, profileCursor is the highlighted one
+	profiles      *hdltypes.Profiles
+	profileCursor int
+
+	closeContext context.Context
+	close        context.CancelFunc
+
 	// models
 	Help       help.Model
 	Viewport   *viewport.Model
@@ -51,15 +61,18 @@ var (
 	gamaVersion            string
 	newVersionAvailableMsg string
 	applicationDescription string
+	credentialSourceMsg    string
 )
 
-func SetupModelInfo(githubUseCase gu.UseCase, versionUseCase vu.UseCase, lockTabs *bool) *ModelInfo {
+func SetupModelInfo(githubUseCase gu.UseCase, versionUseCase vu.UseCase, lockTabs *bool, profiles *hdltypes.Profiles) *ModelInfo {
 	modelError := hdlerror.SetupModelError()
 
 	s := spinner.New()
 	s.Spinner = spinner.Pulse
 	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("120"))
 
+	closeContext, closeFunc := context.WithCancel(context.Background())
+
 	return &ModelInfo{
 		githubUseCase:  githubUseCase,
 		versionUseCase: versionUseCase,
@@ -67,16 +80,26 @@ func SetupModelInfo(githubUseCase gu.UseCase, versionUseCase vu.UseCase, lockTab
 		Keys:           keys,
 		modelError:     modelError,
 		lockTabs:       lockTabs,
+		profiles:       profiles,
+		profileCursor:  max(0, slices.Index(profiles.Names, profiles.Current)),
 		spinner:        s,
+		closeContext:   closeContext,
+		close:          closeFunc,
 	}
 }
 
+// Close stops the background work of the model, it is not usable afterwards.
+func (m *ModelInfo) Close() {
+	m.close()
+}
+
 func (m *ModelInfo) Init() tea.Cmd {
 	gamaVersion = m.versionUseCase.CurrentVersion()
 	applicationDescription = fmt.Sprintf("Github Actions Manager (%s)", gamaVersion)
+	credentialSourceMsg = fmt.Sprintf("Token source: %s", m.githubUseCase.CredentialSource())
 
-	go m.testConnection(context.Background())
-	go m.checkUpdates(context.Background())
+	go m.testConnection(m.closeContext)
+	go m.checkUpdates(m.closeContext)
 	return nil
 }
 
@@ -105,12 +128,69 @@ func (m *ModelInfo) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		switch {
 		case key.Matches(msg, m.Keys.Quit):
 			return m, tea.Quit
+		case key.Matches(msg, m.Keys.PreviousProfile):
+			m.profileCursor = max(m.profileCursor-1, 0)
+		case key.Matches(msg, m.Keys.NextProfile):
+			m.profileCursor = min(m.profileCursor+1, len(m.profiles.Names)-1)
+		case key.Matches(msg, m.Keys.SwitchProfile):
+			cmd = m.switchProfile()
 		}
 	}
 
 	return m, cmd
 }
 
+func (m *ModelInfo) switchProfile() tea.Cmd {
+	if len(m.profiles.Names) == 0 {
+		return nil
+	}
+
+	name := m.profiles.Names[m.profileCursor]
+	if name == m.profiles.Current {
+		return nil
+	}
+
+	m.modelError.Reset()
+	m.modelError.SetProgressMessage(fmt.Sprintf("Switching to profile %s...", name))
+
+	return func() tea.Msg {
+		githubUseCase, err := m.profiles.Switch(name)
+		if err != nil {
+			m.modelError.SetError(err)
+			m.modelError.SetErrorMessage(fmt.Sprintf("failed to switch to profile %s", name))
+			return nil
+		}
+
+		return hdltypes.ProfileSwitchedMsg{
+			Name:          name,
+			GithubUseCase: githubUseCase,
+		}
+	}
+}
+
+func (m *ModelInfo) viewProfiles() string {
+	if len(m.profiles.Names) < 2 {
+		return fmt.Sprintf("Profile: %s", m.profiles.Current)
+	}
+
+	selectedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("120")).Padding(0, 1)
+	unselectedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("140")).Padding(0, 1)
+
+	var renderedProfiles []string
+	for i, name := range m.profiles.Names {
+		if name == m.profiles.Current {
+			name = "*" + name
+		}
+		if i == m.profileCursor {
+			renderedProfiles = append(renderedProfiles, selectedStyle.Render("["+name+"]"))
+		} else {
+			renderedProfiles = append(renderedProfiles, unselectedStyle.Render(name))
+		}
+	}
+
+	return lipgloss.JoinHorizontal(lipgloss.Left, append([]string{"Profiles:"}, renderedProfiles...)...)
+}
+
 func (m *ModelInfo) View() string {
 	infoDoc := strings.Builder{}
 
@@ -120,7 +200,7 @@ func (m *ModelInfo) View() string {
 		Border(lipgloss.RoundedBorder()).
 		Width(m.Viewport.Width - 7)
 
-	infoDoc.WriteString(lipgloss.JoinVertical(lipgloss.Center, applicationName, applicationDescription, newVersionAvailableMsg))
+	infoDoc.WriteString(lipgloss.JoinVertical(lipgloss.Center, applicationName, applicationDescription, m.viewProfiles(), credentialSourceMsg, newVersionAvailableMsg))
 
 	docHeight := strings.Count(infoDoc.String(), "\n")
 	requiredNewlinesForPadding := m.Viewport.Height - docHeight - 13
@@ -149,6 +229,9 @@ func (m *ModelInfo) testConnection(ctx context.Context) {
 	defer cancel()
 
 	_, err := m.githubUseCase.ListRepositories(ctx, gu.ListRepositoriesInput{Limit: 1})
+	if errors.Is(err, context.Canceled) {
+		return
+	}
 	if err != nil {
 		m.modelError.SetError(err)
 		m.modelError.SetErrorMessage("failed to test connection, please check your token&permission")
@@ -5,17 +5,22 @@ import (
 )
 
 type keyMap struct {
-	NextTab teakey.Binding
-	Quit    teakey.Binding
+	NextTab         teakey.Binding
+	PreviousProfile teakey.Binding
+	NextProfile     teakey.Binding
+	SwitchProfile   teakey.Binding
+	Quit            teakey.Binding
 }
 
 func (k keyMap) ShortHelp() []teakey.Binding {
-	return []teakey.Binding{k.NextTab, k.Quit}
+	return []teakey.Binding{k.NextTab, k.PreviousProfile, k.NextProfile, k.SwitchProfile, k.Quit}
 }
 
 func (k keyMap) FullHelp() [][]teakey.Binding {
 	return [][]teakey.Binding{
 		{k.NextTab},
+		{k.PreviousProfile, k.NextProfile},
+		{k.SwitchProfile},
 		{k.Quit},
 	}
 }
@@ -25,6 +30,18 @@ var keys = keyMap{
 		teakey.WithKeys(""), // help-only binding
 		teakey.WithHelp("shift + →", "next tab"),
 	),
+	PreviousProfile: teakey.NewBinding(
+		teakey.WithKeys("left"),
+		teakey.WithHelp("←", "previous profile"),
+	),
+	NextProfile: teakey.NewBinding(
+		teakey.WithKeys("right"),
+		teakey.WithHelp("→", "next profile"),
+	),
+	SwitchProfile: teakey.NewBinding(
+		teakey.WithKeys("enter"),
+		teakey.WithHelp("enter", "switch profile"),
+	),
 	Quit: teakey.NewBinding(
 		teakey.WithKeys("q", "ctrl+c"),
 		teakey.WithHelp("q", "quit"),
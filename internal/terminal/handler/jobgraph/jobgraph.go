@@ -0,0 +1,197 @@
+package jobgraph
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	gu "github.com/termkit/gama/internal/github/usecase"
+	pw "github.com/termkit/gama/pkg/workflow"
+)
+
+// Model shows the dependency graph of the jobs of a workflow, with the status
+// of the jobs of a run when they are set. It scrolls with the arrow keys.
+type Model struct {
+	graph   *pw.Graph
+	jobs    map[string]gu.JobStatus // nil when no run is shown
+	drawing *pw.GraphDrawing
+	owners  [][]int // label index of every rune of the drawing, -1 for edges
+
+	xOffset int
+	yOffset int
+	width   int // size of the last view
+	height  int
+}
+
+type statusStyle struct {
+	icon  string
+	text  string
+	style lipgloss.Style
+}
+
+var (
+	edgeStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	jobStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("39"))
+	statusStyles = map[string]statusStyle{
+		"success":         {"✓", "success", lipgloss.NewStyle().Foreground(lipgloss.Color("42"))},
+		"failure":         {"✗", "failure", lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Bold(true)},
+		"timed_out":       {"✗", "timed out", lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Bold(true)},
+		"startup_failure": {"✗", "startup failure", lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Bold(true)},
+		"cancelled":       {"⊘", "cancelled", lipgloss.NewStyle().Foreground(lipgloss.Color("244"))},
+		"skipped":         {"↷", "skipped", lipgloss.NewStyle().Foreground(lipgloss.Color("244"))},
+		"neutral":         {"✓", "neutral", lipgloss.NewStyle().Foreground(lipgloss.Color("244"))},
+		"action_required": {"!", "action required", lipgloss.NewStyle().Foreground(lipgloss.Color("214"))},
+		"in_progress":     {"●", "in progress", lipgloss.NewStyle().Foreground(lipgloss.Color("220"))},
+		"waiting":         {"◆", "waiting", lipgloss.NewStyle().Foreground(lipgloss.Color("214"))},
+		"queued":          {"○", "queued", lipgloss.NewStyle().Foreground(lipgloss.Color("250"))},
+		"":                {"○", "not started", lipgloss.NewStyle().Foreground(lipgloss.Color("240"))},
+	}
+	legendStatuses = []string{"success", "failure", "in_progress", "waiting", "queued", "skipped", "cancelled"}
+)
+
+// New returns an empty graph.
+func New() *Model {
+	return &Model{}
+}
+
+// SetGraph sets the graph and the status of the jobs of a run by job ID, jobs
+// is nil to show the graph of the workflow alone. The scroll offsets are kept
+// so that refreshing the statuses of a run doesn't move the view.
+func (m *Model) SetGraph(graph *pw.Graph, jobs map[string]gu.JobStatus) {
+	m.graph, m.jobs = graph, jobs
+	m.drawing, m.owners = nil, nil
+	if graph == nil {
+		return
+	}
+
+	m.drawing = graph.Draw(m.label)
+	for _, line := range m.drawing.Lines {
+		owners := make([]int, len([]rune(line)))
+		for i := range owners {
+			owners[i] = -1
+		}
+		m.owners = append(m.owners, owners)
+	}
+	for i, label := range m.drawing.Labels {
+		for x := label.Column; x < label.Column+label.Width; x++ {
+			m.owners[label.Line][x] = i
+		}
+	}
+}
+
+// Empty tells whether there is no graph to show.
+func (m *Model) Empty() bool {
+	return m.graph == nil
+}
+
+func (m *Model) label(job *pw.GraphJob) string {
+	if m.jobs == nil {
+		return job.Name
+	}
+
+	status, ok := m.jobs[job.ID]
+	label := fmt.Sprintf("%s %s", m.status(status, ok).icon, job.Name)
+	if status.Jobs > 1 {
+		label += fmt.Sprintf(" %d/%d", status.Completed, status.Jobs)
+	}
+	return label
+}
+
+func (m *Model) status(status gu.JobStatus, ok bool) statusStyle {
+	key := status.Status
+	switch {
+	case !ok:
+		key = ""
+	case status.Status == "completed":
+		key = status.Conclusion
+	}
+	if style, ok := statusStyles[key]; ok {
+		return style
+	}
+	return statusStyles["neutral"]
+}
+
+// Update scrolls the graph.
+func (m *Model) Update(msg tea.KeyMsg) {
+	if m.drawing == nil {
+		return
+	}
+
+	switch msg.String() {
+	case "left", "h":
+		m.xOffset -= 8
+	case "right", "l":
+		m.xOffset += 8
+	case "up", "k":
+		m.yOffset--
+	case "down", "j":
+		m.yOffset++
+	case "home":
+		m.xOffset, m.yOffset = 0, 0
+	}
+	m.clampOffsets()
+}
+
+// clampOffsets keeps the view on the graph.
+func (m *Model) clampOffsets() {
+	var width int
+	for _, line := range m.owners {
+		width = max(width, len(line))
+	}
+	m.xOffset = max(0, min(m.xOffset, width-m.width))
+	m.yOffset = max(0, min(m.yOffset, len(m.owners)-m.height))
+}
+
+// View renders the part of the graph at the offsets for the size, with the
+// legend of the statuses under it when a run is shown.
+func (m *Model) View(width int, height int) string {
+	if m.drawing == nil {
+		return ""
+	}
+	if m.jobs != nil {
+		height -= 2
+	}
+	m.width, m.height = max(width, 1), max(height, 1)
+	m.clampOffsets()
+
+	var lines []string
+	for y := m.yOffset; y < min(len(m.owners), m.yOffset+m.height); y++ {
+		runes := []rune(m.drawing.Lines[y])
+		var b strings.Builder
+		for x := m.xOffset; x < min(len(runes), m.xOffset+m.width); {
+			owner := m.owners[y][x]
+			end := x + 1
+			for end < min(len(runes), m.xOffset+m.width) && m.owners[y][end] == owner {
+				end++
+			}
+
+			text := string(runes[x:end])
+			if owner < 0 {
+				b.WriteString(edgeStyle.Render(text))
+			} else {
+				b.WriteString(m.labelStyle(m.drawing.Labels[owner].Job).Render(text))
+			}
+			x = end
+		}
+		lines = append(lines, b.String())
+	}
+
+	if m.jobs != nil {
+		var legend []string
+		for _, status := range legendStatuses {
+			style := statusStyles[status]
+			legend = append(legend, style.style.Render(style.icon)+" "+style.text)
+		}
+		lines = append(lines, "", lipgloss.NewStyle().MaxWidth(m.width).Render(strings.Join(legend, "  ")))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m *Model) labelStyle(job *pw.GraphJob) lipgloss.Style {
+	if m.jobs == nil {
+		return jobStyle
+	}
+	status, ok := m.jobs[job.ID]
+	return m.status(status, ok).style
+}
@@ -0,0 +1,164 @@
+package jobgraph
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	gu "github.com/termkit/gama/internal/github/usecase"
+	pw "github.com/termkit/gama/pkg/workflow"
+)
+
+var (
+	dimStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	dynamicStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+	errorStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+)
+
+// JobList renders the jobs a run of a workflow will have, with the jobs of
+// every combination of the matrices.
+func JobList(jobs []pw.ExpandedJob) string {
+	var lines []string
+	for _, job := range jobs {
+		title := jobStyle.Render(job.Name)
+		switch {
+		case job.IsDynamic():
+			lines = append(lines, title+dimStyle.Render(" — matrix"), dynamicStyle.Render("  ◆ expanded when the workflow runs: "+job.Err.Error()))
+			continue
+		case job.Err != nil:
+			lines = append(lines, title+dimStyle.Render(" — matrix"), errorStyle.Render("  ✗ "+job.Err.Error()))
+			continue
+		case !job.Matrix:
+			lines = append(lines, title+dimStyle.Render(" — 1 job"))
+			continue
+		}
+
+		lines = append(lines, title+dimStyle.Render(fmt.Sprintf(" — matrix of %d jobs", len(job.Names))))
+		for _, name := range job.Names {
+			lines = append(lines, "  "+name)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// MatrixGrid renders the cells of the matrices of a run coloured by their
+// status, the first value of the combinations by row and the others by column.
+func MatrixGrid(matrices []gu.RunMatrix) string {
+	var sections []string
+	for _, matrix := range matrices {
+		var section []string
+
+		counts := make(map[string]int)
+		var keys []string
+		for _, cell := range matrix.Cells {
+			key := statusKey(cell.Status)
+			if counts[key] == 0 {
+				keys = append(keys, key)
+			}
+			counts[key]++
+		}
+		var summary []string
+		for _, key := range keys {
+			style := statusStyles[key]
+			summary = append(summary, style.style.Render(fmt.Sprintf("%d %s", counts[key], style.icon)))
+		}
+		title := jobStyle.Render(matrix.Name) + dimStyle.Render(fmt.Sprintf(" — %d jobs ", len(matrix.Cells))) + strings.Join(summary, " ")
+		section = append(section, title)
+
+		if matrix.Err != nil {
+			section = append(section, dimStyle.Render("  combinations of the run, "+matrix.Err.Error()))
+		}
+		section = append(section, grid(matrix.Cells)...)
+		sections = append(sections, strings.Join(section, "\n"))
+	}
+
+	var legend []string
+	for _, status := range legendStatuses {
+		style := statusStyles[status]
+		legend = append(legend, style.style.Render(style.icon)+" "+style.text)
+	}
+	sections = append(sections, strings.Join(legend, "  "))
+	return strings.Join(sections, "\n\n")
+}
+
+func statusKey(status gu.JobStatus) string {
+	key := status.Status
+	if status.Status == "completed" {
+		key = status.Conclusion
+	}
+	if _, ok := statusStyles[key]; !ok {
+		return "neutral"
+	}
+	return key
+}
+
+// grid lays out the cells, a row per first value and a column per the rest.
+func grid(cells []gu.MatrixCell) []string {
+	if len(cells) == 0 {
+		return []string{dimStyle.Render("  the run has no job for the matrix")}
+	}
+
+	var rows, columns []string
+	byPosition := make(map[[2]string]gu.MatrixCell)
+	for _, cell := range cells {
+		var row, column string
+		if len(cell.Values) > 0 {
+			row, column = cell.Values[0], strings.Join(cell.Values[1:], ", ")
+		}
+		if !slices.Contains(rows, row) {
+			rows = append(rows, row)
+		}
+		if !slices.Contains(columns, column) {
+			columns = append(columns, column)
+		}
+		if _, ok := byPosition[[2]string{row, column}]; !ok {
+			byPosition[[2]string{row, column}] = cell
+		}
+	}
+
+	label := func(cell gu.MatrixCell) string {
+		style := statusStyles[statusKey(cell.Status)]
+		return style.icon + " " + style.text
+	}
+
+	rowWidth := 0
+	for _, row := range rows {
+		rowWidth = max(rowWidth, lipgloss.Width(row))
+	}
+	widths := make([]int, len(columns))
+	for i, column := range columns {
+		widths[i] = lipgloss.Width(column)
+		for _, row := range rows {
+			if cell, ok := byPosition[[2]string{row, column}]; ok {
+				widths[i] = max(widths[i], lipgloss.Width(label(cell)))
+			}
+		}
+	}
+
+	pad := func(s string, width int) string {
+		return s + strings.Repeat(" ", max(0, width-lipgloss.Width(s)))
+	}
+
+	var lines []string
+	if len(columns) > 1 || columns[0] != "" {
+		header := "  " + pad("", rowWidth)
+		for i, column := range columns {
+			header += "  " + pad(column, widths[i])
+		}
+		lines = append(lines, dimStyle.Render(header))
+	}
+	for _, row := range rows {
+		line := "  " + pad(row, rowWidth)
+		for i, column := range columns {
+			cell, ok := byPosition[[2]string{row, column}]
+			if !ok {
+				line += "  " + dimStyle.Render(pad("·", widths[i]))
+				continue
+			}
+			line += "  " + statusStyles[statusKey(cell.Status)].style.Render(pad(label(cell), widths[i]))
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
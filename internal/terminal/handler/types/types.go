@@ -1,5 +1,9 @@
 package types
 
+import (
+	gu "github.com/termkit/gama/internal/github/usecase"
+)
+
 type SelectedRepository struct {
 	RepositoryID   int64  // repository id
 	RepositoryName string // full repository name (owner/name)
@@ -8,3 +12,24 @@ type SelectedRepository struct {
 }
 
 var ScreenWidth *int
+
+// Profiles holds the configured profiles and builds the github use case of a profile.
+type Profiles struct {
+	Names   []string // all profile names
+	Current string   // active profile name
+	Switch  func(name string) (gu.UseCase, error)
+}
+
+// ProfileSwitchedMsg is sent when the github use case of another profile is ready,
+// the terminal rebuilds all tabs with it.
+type ProfileSwitchedMsg struct {
+	Name          string
+	GithubUseCase gu.UseCase
+}
+
+// EditorFinishedMsg is sent when the external editor opened by a tab exits,
+// the terminal passes it to the current tab.
+type EditorFinishedMsg struct {
+	Path string // file that was edited
+	Err  error
+}
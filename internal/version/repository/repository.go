@@ -8,7 +8,6 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
-	"time"
 )
 
 type Repo struct {
@@ -23,11 +22,9 @@ const (
 	repo  = "gama"
 )
 
-func New(currentVersion string) *Repo {
+func New(currentVersion string, client HttpClient) *Repo {
 	return &Repo{
-		Client: &http.Client{
-			Timeout: 20 * time.Second,
-		},
+		Client:         client,
 		currentVersion: currentVersion,
 	}
 }
@@ -47,7 +44,7 @@ func (r *Repo) LatestVersion() (string, error) {
 		accept: "application/vnd.github+json",
 	})
 	// client time out error
-	if errors.As(err, &context.DeadlineExceeded) {
+	if errors.Is(err, context.DeadlineExceeded) {
 		return "", errors.New("request timed out")
 	} else if err != nil {
 		return "", err
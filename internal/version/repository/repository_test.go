@@ -1,11 +1,12 @@
 package repository
 
 import (
+	"net/http"
 	"testing"
 )
 
 func NewRepository() Repository {
-	return New("")
+	return New("", http.DefaultClient)
 }
 
 func TestRepo_LatestVersion(t *testing.T) {
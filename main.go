@@ -1,35 +1,224 @@
 package main
 
 import (
+	"flag"
 	"fmt"
+	"io"
+	"log/slog"
 	"os"
+	"path/filepath"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/termkit/gama/internal/demo"
 	gr "github.com/termkit/gama/internal/github/repository"
 	gu "github.com/termkit/gama/internal/github/usecase"
 	th "github.com/termkit/gama/internal/terminal/handler"
+	hdltypes "github.com/termkit/gama/internal/terminal/handler/types"
 	vr "github.com/termkit/gama/internal/version/repository"
 	vu "github.com/termkit/gama/internal/version/usecase"
+	pkgaudit "github.com/termkit/gama/pkg/audit"
+	pkgconcurrency "github.com/termkit/gama/pkg/concurrency"
 	pkgconfig "github.com/termkit/gama/pkg/config"
+	pkghttpclient "github.com/termkit/gama/pkg/httpclient"
+	pkglogging "github.com/termkit/gama/pkg/logging"
+	pkgpreset "github.com/termkit/gama/pkg/preset"
+	pw "github.com/termkit/gama/pkg/workflow"
 )
 
 var Version = "under development" // will be set by build flag
 
 func main() {
+	profile := flag.String("profile", "", "name of the profile in .gama.yaml to start with")
+	debug := flag.Bool("debug", false, "write redacted debug logs to the state directory (or set GAMA_DEBUG=1)")
+	demoMode := flag.Bool("demo", false, "run against built-in sample data, without a token or network access")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage:\n  gama [flags]\n  gama lint <workflow file>...\n\nFlags:\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.Arg(0) == "lint" {
+		os.Exit(runLint(flag.Args()[1:]))
+	}
+
+	if *demoMode {
+		runDemo(*debug)
+		return
+	}
+
 	cfg, err := pkgconfig.LoadConfig()
 	if err != nil {
 		panic(fmt.Sprintf("failed to load config: %v", err))
 	}
 
-	githubRepository := gr.New(cfg)
-	versionRepository := vr.New(Version)
+	debugLogPath, closeLog, err := setupLogging(*debug || cfg.Debug)
+	if err != nil {
+		panic(fmt.Sprintf("failed to setup debug logging: %v", err))
+	}
+	defer closeLog.Close()
 
-	githubUseCase := gu.New(githubRepository)
+	profileName := *profile
+	if profileName == "" {
+		profileName = cfg.DefaultProfile
+	}
+	cfg, err = cfg.WithProfile(profileName)
+	if err != nil {
+		panic(fmt.Sprintf("failed to load profile: %v", err))
+	}
+
+	networkClient, err := pkghttpclient.New(cfg.Network)
+	if err != nil {
+		panic(fmt.Sprintf("failed to configure network: %v", err))
+	}
+
+	limiter := pkgconcurrency.New(cfg.Concurrency)
+
+	var httpClient gr.HttpClient = networkClient
+	if debugLogPath != "" {
+		httpClient = pkglogging.NewHTTPClient(networkClient)
+	}
+	httpClient = pkgconcurrency.NewHTTPClient(httpClient, limiter)
+
+	githubRepository := gr.New(cfg, httpClient, limiter)
+	versionRepository := vr.New(Version, httpClient)
+
+	auditLog := setupAuditLog()
+
+	githubUseCase := gu.New(githubRepository, limiter, auditLog)
 	versionUseCase := vu.New(versionRepository)
 
-	terminal := th.SetupTerminal(githubUseCase, versionUseCase)
+	profiles := &hdltypes.Profiles{
+		Names:   cfg.ProfileNames(),
+		Current: cfg.Profile,
+		Switch: func(name string) (gu.UseCase, error) {
+			profileCfg, err := cfg.WithProfile(name)
+			if err != nil {
+				return nil, err
+			}
+			return gu.New(gr.New(profileCfg, httpClient, limiter), limiter, auditLog), nil
+		},
+	}
+
+	runTerminal(githubUseCase, versionUseCase, profiles, auditLog, debugLogPath)
+}
+
+// runDemo starts gama against the in-process fake GitHub API.
+func runDemo(debug bool) {
+	debugLogPath, closeLog, err := setupLogging(debug)
+	if err != nil {
+		panic(fmt.Sprintf("failed to setup debug logging: %v", err))
+	}
+	defer closeLog.Close()
+
+	cfg := pkgconfig.DemoConfig()
+
+	limiter := pkgconcurrency.New(cfg.Concurrency)
+
+	var httpClient gr.HttpClient = demo.NewClient()
+	if debugLogPath != "" {
+		httpClient = pkglogging.NewHTTPClient(httpClient)
+	}
+	httpClient = pkgconcurrency.NewHTTPClient(httpClient, limiter)
+
+	// Demo actions are kept apart from the audit log of real ones
+	auditLog := pkgaudit.New(filepath.Join(os.TempDir(), "gama-demo-audit.jsonl"))
+
+	githubUseCase := gu.New(gr.New(cfg, httpClient, limiter), limiter, auditLog)
+	versionUseCase := vu.New(vr.New(Version, httpClient))
+
+	profiles := &hdltypes.Profiles{
+		Names:   []string{cfg.Profile},
+		Current: cfg.Profile,
+		Switch: func(name string) (gu.UseCase, error) {
+			return githubUseCase, nil
+		},
+	}
+
+	runTerminal(githubUseCase, versionUseCase, profiles, auditLog, debugLogPath)
+}
+
+func runTerminal(githubUseCase gu.UseCase, versionUseCase vu.UseCase, profiles *hdltypes.Profiles, auditLog *pkgaudit.Log, debugLogPath string) {
+	terminal := th.SetupTerminal(githubUseCase, versionUseCase, profiles, setupPresets(), auditLog)
 	if _, err := tea.NewProgram(terminal).Run(); err != nil {
+		slog.Error("program stopped", "error", err)
 		fmt.Println("Error running program:", err)
 		os.Exit(1)
 	}
+
+	if debugLogPath != "" {
+		fmt.Println("Debug log written to", debugLogPath)
+	}
+}
+
+// runLint prints the findings of the workflow files like compilers print
+// errors and returns the exit code: 1 when a file has errors, 2 when a file
+// cannot be read.
+func runLint(files []string) int {
+	if len(files) == 0 {
+		flag.Usage()
+		return 2
+	}
+
+	code := 0
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			code = 2
+			continue
+		}
+
+		findings := pw.Lint(content)
+		for _, finding := range findings {
+			fmt.Printf("%s:%s\n", file, finding)
+		}
+		if pw.HasErrors(findings) && code == 0 {
+			code = 1
+		}
+	}
+	return code
+}
+
+// setupAuditLog returns the log of mutating actions, or nil when there is no state directory.
+func setupAuditLog() *pkgaudit.Log {
+	stateDir, err := pkgconfig.StateDir()
+	if err != nil {
+		slog.Warn("audit log is disabled", "error", err)
+		return nil
+	}
+
+	return pkgaudit.New(filepath.Join(stateDir, "audit.jsonl"))
+}
+
+// setupPresets returns the store of trigger input presets, or nil when there is no state directory.
+func setupPresets() *pkgpreset.Store {
+	stateDir, err := pkgconfig.StateDir()
+	if err != nil {
+		slog.Warn("presets are disabled", "error", err)
+		return nil
+	}
+
+	return pkgpreset.NewStore(filepath.Join(stateDir, "presets.json"))
+}
+
+// setupLogging returns the path of the debug log, which is empty when debug logging is disabled.
+func setupLogging(enabled bool) (string, io.Closer, error) {
+	if !enabled {
+		closer, err := pkglogging.Setup(false, "")
+		return "", closer, err
+	}
+
+	stateDir, err := pkgconfig.StateDir()
+	if err != nil {
+		return "", nil, err
+	}
+
+	logPath := filepath.Join(stateDir, "debug.log")
+	closer, err := pkglogging.Setup(true, logPath)
+	if err != nil {
+		return "", nil, err
+	}
+
+	slog.Info("starting gama", "version", Version)
+	return logPath, closer, nil
 }
@@ -0,0 +1,148 @@
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Actions recorded in the audit log.
+const (
+	ActionTriggerWorkflow    = "trigger_workflow"
+	ActionRepositoryDispatch = "repository_dispatch"
+	ActionReRunWorkflow      = "rerun_workflow"
+	ActionReRunFailedJobs    = "rerun_failed_jobs"
+	ActionCancelWorkflow     = "cancel_workflow"
+)
+
+// Results of a recorded action.
+const (
+	ResultSuccess = "success"
+	ResultFailure = "failure"
+)
+
+// Entry is a single mutating action.
+type Entry struct {
+	Time       time.Time      `json:"time"`
+	Login      string         `json:"login"`
+	Host       string         `json:"host"`
+	Profile    string         `json:"profile,omitempty"` // config profile the action was sent with
+	APIURL     string         `json:"api_url,omitempty"` // REST API the action was sent to
+	Action     string         `json:"action"`
+	Repository string         `json:"repository"`
+	Ref        string         `json:"ref,omitempty"`
+	Workflow   string         `json:"workflow,omitempty"`
+	EventType  string         `json:"event_type,omitempty"`
+	RunID      int64          `json:"run_id,omitempty"`
+	Inputs     map[string]any `json:"inputs,omitempty"` // workflow inputs or the client_payload of repository_dispatch
+	Result     string         `json:"result"`
+	Error      string         `json:"error,omitempty"`
+}
+
+// Matches reports whether any field of the entry contains the query, ignoring case.
+func (e Entry) Matches(query string) bool {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return true
+	}
+
+	fields := []string{e.Login, e.Host, e.Profile, e.Action, e.Repository, e.Ref, e.Workflow, e.EventType, e.Result, e.Error}
+	if e.RunID != 0 {
+		fields = append(fields, fmt.Sprint(e.RunID))
+	}
+	for _, field := range fields {
+		if strings.Contains(strings.ToLower(field), query) {
+			return true
+		}
+	}
+	return false
+}
+
+// SentWith reports whether the entry was sent with the profile and API URL.
+// Entries written before they were recorded match any.
+func (e Entry) SentWith(profile, apiURL string) bool {
+	if e.Profile == "" && e.APIURL == "" {
+		return true
+	}
+	return e.Profile == profile && strings.TrimSuffix(e.APIURL, "/") == strings.TrimSuffix(apiURL, "/")
+}
+
+// Log is an append-only JSON Lines file, one entry per line.
+type Log struct {
+	path string
+	mu   sync.Mutex
+}
+
+func New(path string) *Log {
+	return &Log{path: path}
+}
+
+func (l *Log) Path() string {
+	return l.path
+}
+
+// Append writes the entry at the end of the log.
+func (l *Log) Append(entry Entry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(l.path), 0o700); err != nil {
+		return fmt.Errorf("failed to create audit log directory: %w", err)
+	}
+
+	file, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	return nil
+}
+
+// Read returns every entry of the log, oldest first. Lines that cannot be
+// parsed, e.g. one cut short by a crash, are skipped.
+func (l *Log) Read() ([]Entry, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	data, err := os.ReadFile(l.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read audit log: %w", err)
+	}
+
+	var entries []Entry
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, 1024*1024)
+	for scanner.Scan() {
+		decoder := json.NewDecoder(bytes.NewReader(scanner.Bytes()))
+		decoder.UseNumber() // keep numeric inputs as written for re-dispatching
+
+		var entry Entry
+		if err := decoder.Decode(&entry); err != nil {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read audit log: %w", err)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,71 @@
+package audit
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLog(t *testing.T) {
+	log := New(filepath.Join(t.TempDir(), "state", "audit.jsonl"))
+
+	entries, err := log.Read()
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+
+	first := Entry{
+		Time:       time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+		Login:      "octocat",
+		Action:     ActionTriggerWorkflow,
+		Repository: "owner/repo",
+		Ref:        "main",
+		Workflow:   ".github/workflows/deploy.yml",
+		Inputs:     map[string]any{"replicas": json.Number("3"), "dry_run": false},
+		Result:     ResultSuccess,
+	}
+	second := Entry{
+		Time:       time.Date(2024, 1, 1, 12, 5, 0, 0, time.UTC),
+		Login:      "octocat",
+		Action:     ActionCancelWorkflow,
+		Repository: "owner/repo",
+		RunID:      42,
+		Result:     ResultFailure,
+		Error:      "Cannot cancel a workflow run that is completed.",
+	}
+	assert.NoError(t, log.Append(first))
+	assert.NoError(t, log.Append(second))
+
+	// A line cut short is skipped
+	file, err := os.OpenFile(log.Path(), os.O_APPEND|os.O_WRONLY, 0o600)
+	assert.NoError(t, err)
+	_, err = file.WriteString(`{"action": "trig`)
+	assert.NoError(t, err)
+	assert.NoError(t, file.Close())
+
+	entries, err = log.Read()
+	assert.NoError(t, err)
+	assert.Equal(t, []Entry{first, second}, entries)
+}
+
+func TestEntry_Matches(t *testing.T) {
+	entry := Entry{Login: "octocat", Action: ActionReRunWorkflow, Repository: "owner/repo", Ref: "main", RunID: 42, Result: ResultSuccess}
+
+	assert.True(t, entry.Matches(""))
+	assert.True(t, entry.Matches("OctoCat"))
+	assert.True(t, entry.Matches("rerun"))
+	assert.True(t, entry.Matches("42"))
+	assert.False(t, entry.Matches("failure"))
+}
+
+func TestEntry_SentWith(t *testing.T) {
+	entry := Entry{Profile: "work", APIURL: "https://ghe.example.com/api/v3/"}
+
+	assert.True(t, entry.SentWith("work", "https://ghe.example.com/api/v3"))
+	assert.False(t, entry.SentWith("default", "https://ghe.example.com/api/v3"))
+	assert.False(t, entry.SentWith("work", "https://api.github.com"))
+	assert.True(t, Entry{}.SentWith("default", "https://api.github.com"))
+}
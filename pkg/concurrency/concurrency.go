@@ -0,0 +1,139 @@
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	pkgconfig "github.com/termkit/gama/pkg/config"
+	"golang.org/x/sync/semaphore"
+)
+
+const (
+	defaultWorkers = 8
+	defaultPerHost = 6
+)
+
+// Limiter bounds the work gama does at once. Fan-outs run at most Workers jobs
+// each, and every request to a host shares that host's in-flight limit, so
+// nested or parallel fan-outs never exceed it either.
+type Limiter struct {
+	workers int
+	perHost int
+	hosts   map[string]int
+
+	mu    sync.Mutex
+	slots map[string]*semaphore.Weighted
+}
+
+// New returns a limiter for the given settings, zero values fall back to defaults.
+func New(cfg pkgconfig.Concurrency) *Limiter {
+	workers := cfg.Workers
+	if workers <= 0 {
+		workers = defaultWorkers
+	}
+	perHost := cfg.PerHost
+	if perHost <= 0 {
+		perHost = defaultPerHost
+	}
+
+	return &Limiter{
+		workers: workers,
+		perHost: perHost,
+		hosts:   cfg.Hosts,
+		slots:   make(map[string]*semaphore.Weighted),
+	}
+}
+
+// Workers returns the number of jobs a single fan-out runs at once.
+func (l *Limiter) Workers() int {
+	if l == nil {
+		return defaultWorkers
+	}
+	return l.workers
+}
+
+// acquireHost blocks until a request to host may be sent, or ctx is done.
+func (l *Limiter) acquireHost(ctx context.Context, host string) (release func(), err error) {
+	l.mu.Lock()
+	slots, ok := l.slots[host]
+	if !ok {
+		limit := l.perHost
+		if hostLimit, ok := l.hosts[host]; ok && hostLimit > 0 {
+			limit = hostLimit
+		}
+		slots = semaphore.NewWeighted(int64(limit))
+		l.slots[host] = slots
+	}
+	l.mu.Unlock()
+
+	if err := slots.Acquire(ctx, 1); err != nil {
+		return nil, err
+	}
+
+	var once sync.Once
+	return func() { once.Do(func() { slots.Release(1) }) }, nil
+}
+
+// ForEach calls fn for every item, running at most limiter.Workers() calls at
+// once, and returns when all calls have returned. Once ctx is done, items that
+// haven't started are skipped and the error of ctx is returned.
+func ForEach[T any](ctx context.Context, limiter *Limiter, items []T, fn func(ctx context.Context, index int, item T)) error {
+	workers := semaphore.NewWeighted(int64(limiter.Workers()))
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for i, item := range items {
+		if err := workers.Acquire(ctx, 1); err != nil {
+			return err
+		}
+		// Acquire may still succeed after ctx is done when a worker is free
+		if err := ctx.Err(); err != nil {
+			workers.Release(1)
+			return err
+		}
+
+		wg.Add(1)
+		go func(i int, item T) {
+			defer wg.Done()
+			defer workers.Release(1)
+
+			fn(ctx, i, item)
+		}(i, item)
+	}
+
+	return nil
+}
+
+// Map calls fn for every item like ForEach. Results of successful calls are
+// returned in the order of items, errors are joined.
+func Map[T any, R any](ctx context.Context, limiter *Limiter, items []T, fn func(ctx context.Context, item T) (R, error)) ([]R, error) {
+	type result struct {
+		value R
+		err   error
+		done  bool
+	}
+	results := make([]result, len(items))
+
+	ctxErr := ForEach(ctx, limiter, items, func(ctx context.Context, i int, item T) {
+		value, err := fn(ctx, item)
+		results[i] = result{value: value, err: err, done: true}
+	})
+
+	var values []R
+	var errs []error
+	for _, res := range results {
+		switch {
+		case !res.done:
+		case res.err != nil:
+			errs = append(errs, res.err)
+		default:
+			values = append(values, res.value)
+		}
+	}
+	if ctxErr != nil {
+		errs = append(errs, ctxErr)
+	}
+
+	return values, errors.Join(errs...)
+}
@@ -0,0 +1,130 @@
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	pkgconfig "github.com/termkit/gama/pkg/config"
+)
+
+// peak records the highest number of concurrent calls between enter and leave.
+type peak struct {
+	current atomic.Int32
+	max     atomic.Int32
+}
+
+func (p *peak) enter() {
+	current := p.current.Add(1)
+	for {
+		max := p.max.Load()
+		if current <= max || p.max.CompareAndSwap(max, current) {
+			return
+		}
+	}
+}
+
+func (p *peak) leave() {
+	p.current.Add(-1)
+}
+
+func TestMap_BoundsWorkersAndKeepsOrder(t *testing.T) {
+	limiter := New(pkgconfig.Concurrency{Workers: 3})
+	items := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+
+	var p peak
+	results, err := Map(context.Background(), limiter, items, func(ctx context.Context, item int) (int, error) {
+		p.enter()
+		defer p.leave()
+		time.Sleep(5 * time.Millisecond)
+		if item%4 == 0 {
+			return 0, errors.New("multiple of four")
+		}
+		return item * 10, nil
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, []int{10, 20, 30, 50, 60, 70, 90, 100}, results)
+	assert.LessOrEqual(t, p.max.Load(), int32(3))
+}
+
+func TestMap_StopsWhenCancelled(t *testing.T) {
+	limiter := New(pkgconfig.Concurrency{Workers: 1})
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var calls atomic.Int32
+	_, err := Map(ctx, limiter, []int{1, 2, 3, 4, 5}, func(ctx context.Context, item int) (int, error) {
+		if calls.Add(1) == 2 {
+			cancel()
+		}
+		return item, nil
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+type fakeClient func(req *http.Request) (*http.Response, error)
+
+func (f fakeClient) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestHTTPClient_PerHostLimit(t *testing.T) {
+	limiter := New(pkgconfig.Concurrency{
+		PerHost: 2,
+		Hosts:   map[string]int{"ghe.example.com": 1},
+	})
+
+	peaks := map[string]*peak{"api.github.com": {}, "ghe.example.com": {}}
+	client := NewHTTPClient(fakeClient(func(req *http.Request) (*http.Response, error) {
+		p := peaks[req.URL.Host]
+		p.enter()
+		time.Sleep(5 * time.Millisecond)
+		p.leave()
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("{}"))}, nil
+	}), limiter)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		for host := range peaks {
+			wg.Add(1)
+			go func(host string) {
+				defer wg.Done()
+				req, _ := http.NewRequest(http.MethodGet, "https://"+host+"/user", nil)
+				resp, err := client.Do(req)
+				if assert.NoError(t, err) {
+					resp.Body.Close()
+				}
+			}(host)
+		}
+	}
+	wg.Wait()
+
+	assert.LessOrEqual(t, peaks["api.github.com"].max.Load(), int32(2))
+	assert.Equal(t, int32(1), peaks["ghe.example.com"].max.Load())
+}
+
+func TestHTTPClient_WaitHonorsContext(t *testing.T) {
+	limiter := New(pkgconfig.Concurrency{PerHost: 1})
+	client := NewHTTPClient(fakeClient(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(""))}, nil
+	}), limiter)
+
+	// The first response is never closed, so its slot stays taken
+	req, _ := http.NewRequest(http.MethodGet, "https://api.github.com/user", nil)
+	_, err := client.Do(req)
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = client.Do(req.WithContext(ctx))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -0,0 +1,48 @@
+package concurrency
+
+import (
+	"io"
+	"net/http"
+)
+
+// HttpClient is the subset of *http.Client used by the github repositories.
+type HttpClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+type limitingClient struct {
+	next    HttpClient
+	limiter *Limiter
+}
+
+// NewHTTPClient wraps the client so that requests wait for a free slot of their
+// host. The slot is held until the response body is closed.
+func NewHTTPClient(next HttpClient, limiter *Limiter) HttpClient {
+	return &limitingClient{next: next, limiter: limiter}
+}
+
+func (c *limitingClient) Do(req *http.Request) (*http.Response, error) {
+	release, err := c.limiter.acquireHost(req.Context(), req.URL.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.next.Do(req)
+	if err != nil {
+		release()
+		return nil, err
+	}
+
+	resp.Body = &releasingBody{ReadCloser: resp.Body, release: release}
+	return resp, nil
+}
+
+type releasingBody struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b *releasingBody) Close() error {
+	defer b.release()
+	return b.ReadCloser.Close()
+}
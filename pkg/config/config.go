@@ -3,7 +3,10 @@ package config
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"runtime"
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -14,13 +17,94 @@ const (
 )
 
 type Config struct {
-	Github Github `mapstructure:"github"`
+	Github      Github      `mapstructure:"github"`
+	Network     Network     `mapstructure:"network"`
+	Concurrency Concurrency `mapstructure:"concurrency"`
+
+	// Debug enables redacted debug logging to the state directory.
+	Debug bool `mapstructure:"debug"`
+
+	// Profiles are named alternatives to the top-level github section.
+	Profiles map[string]Profile `mapstructure:"profiles"`
+
+	// DefaultProfile is the profile used when --profile is not given.
+	DefaultProfile string `mapstructure:"default_profile"`
+
+	// Profile is the name of the active profile, set by WithProfile.
+	Profile string `mapstructure:"-"`
+
+	// defaultGithub is the top-level github section as read, before WithProfile
+	// replaced or resolved Github. It is nil until WithProfile is called.
+	defaultGithub *Github
 }
 
 type Github struct {
-	Token string `mapstructure:"token"`
+	APIURL       string    `mapstructure:"api_url"`
+	Token        string    `mapstructure:"token"`
+	TokenCommand string    `mapstructure:"token_command"`
+	App          GithubApp `mapstructure:"app"`
+
+	// CredentialSources overrides the order in which credential sources are tried.
+	CredentialSources []CredentialSource `mapstructure:"credential_sources"`
+
+	// CredentialSource is the source the token was resolved from, set by WithProfile.
+	CredentialSource CredentialSource `mapstructure:"-"`
+}
+
+// GithubApp holds the credentials of a GitHub App installation. When it is
+// configured, gama authenticates with short-lived installation tokens instead
+// of a personal access token.
+type GithubApp struct {
+	ID             int64  `mapstructure:"id"`
+	InstallationID int64  `mapstructure:"installation_id"`
+	PrivateKeyPath string `mapstructure:"private_key_path"`
 }
 
+// Network configures the HTTP transport shared by every GitHub client.
+type Network struct {
+	// Proxy is the proxy URL, HTTPS_PROXY/HTTP_PROXY are used when it is empty.
+	Proxy string `mapstructure:"proxy"`
+
+	// NoProxy lists hosts, domains (.example.com) and CIDRs that bypass the proxy.
+	NoProxy []string `mapstructure:"no_proxy"`
+
+	// CACertificates are PEM files trusted in addition to the system roots.
+	CACertificates []string `mapstructure:"ca_certificates"`
+
+	// ClientCertificate and ClientKey are PEM files used for mutual TLS.
+	ClientCertificate string `mapstructure:"client_certificate"`
+	ClientKey         string `mapstructure:"client_key"`
+
+	// Timeout limits a whole request, including reading the response body.
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	DialTimeout           time.Duration `mapstructure:"dial_timeout"`
+	TLSHandshakeTimeout   time.Duration `mapstructure:"tls_handshake_timeout"`
+	ResponseHeaderTimeout time.Duration `mapstructure:"response_header_timeout"`
+}
+
+// Concurrency limits how much work gama does at once, so that big accounts
+// don't trip GitHub's secondary rate limits.
+type Concurrency struct {
+	// Workers is the number of jobs a single fan-out runs at once, e.g. counting
+	// the workflows of every repository.
+	Workers int `mapstructure:"workers"`
+
+	// PerHost is the number of requests in flight to a single host, shared by
+	// every fan-out and profile.
+	PerHost int `mapstructure:"per_host"`
+
+	// Hosts overrides PerHost for the given hosts, e.g. a GitHub Enterprise Server.
+	Hosts map[string]int `mapstructure:"hosts"`
+}
+
+// IsConfigured reports whether all GitHub App settings are present.
+func (a GithubApp) IsConfigured() bool {
+	return a.ID != 0 && a.InstallationID != 0 && a.PrivateKeyPath != ""
+}
+
+// LoadConfig reads the config file and the environment. The credentials are
+// not resolved, call WithProfile with the profile to use.
 func LoadConfig() (*Config, error) {
 	configPath, err := os.UserHomeDir()
 	if err != nil {
@@ -31,26 +115,59 @@ func LoadConfig() (*Config, error) {
 	viper.SetConfigName(configName)
 	viper.SetConfigType(configType)
 	viper.SetEnvKeyReplacer(strings.NewReplacer(`.`, `_`))
-	viper.BindEnv("github.token", "GITHUB_TOKEN")
-	viper.AutomaticEnv()
+	// Only these environment variables override the config file. Config keys are
+	// not read from the environment in general (no AutomaticEnv), so that generic
+	// names like DEBUG or GITHUB_TOKEN cannot change settings by accident.
+	// GITHUB_TOKEN is not bound here, it is resolved by the env credential source.
+	viper.BindEnv("debug", "GAMA_DEBUG")
+	viper.BindEnv("github.token_command", "GAMA_TOKEN_COMMAND")
+	viper.BindEnv("github.app.id", "GITHUB_APP_ID")
+	viper.BindEnv("github.app.installation_id", "GITHUB_APP_INSTALLATION_ID")
+	viper.BindEnv("github.app.private_key_path", "GITHUB_APP_PRIVATE_KEY_PATH")
+
+	config := new(Config)
 
 	// Read the config file first
 	if err := viper.ReadInConfig(); err == nil {
-		config := new(Config)
 		if err := viper.Unmarshal(config); err != nil {
 			return nil, fmt.Errorf("failed to unmarshal config file: %w", err)
 		}
-		return config, nil
+	} else {
+		// If config file is not found, try to unmarshal from environment variables
+		if err := viper.Unmarshal(config); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+		}
 	}
 
-	// If config file is not found, try to unmarshal from environment variables
-	config := new(Config)
-	if err := viper.Unmarshal(config); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
-	}
 	return config, nil
 }
 
+// StateDir returns the directory gama keeps its local state in, such as logs,
+// creating it if necessary. It follows $XDG_STATE_HOME and defaults to ~/.local/state/gama.
+func StateDir() (string, error) {
+	var stateDir string
+	if dir := os.Getenv("XDG_STATE_HOME"); dir != "" {
+		stateDir = filepath.Join(dir, "gama")
+	} else if runtime.GOOS == "windows" {
+		dir, err := os.UserCacheDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to get user cache directory: %w", err)
+		}
+		stateDir = filepath.Join(dir, "gama")
+	} else {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to get user home directory: %w", err)
+		}
+		stateDir = filepath.Join(homeDir, ".local", "state", "gama")
+	}
+
+	if err := os.MkdirAll(stateDir, 0o700); err != nil {
+		return "", fmt.Errorf("failed to create state directory: %w", err)
+	}
+	return stateDir, nil
+}
+
 func CheckConfig() error {
 	configPath, err := os.UserHomeDir()
 	if err != nil {
@@ -0,0 +1,227 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CredentialSource is a place gama can obtain a GitHub token from.
+type CredentialSource string
+
+const (
+	// CredentialSourceEnv reads the token from the GITHUB_TOKEN environment variable.
+	CredentialSourceEnv CredentialSource = "env"
+
+	// CredentialSourceConfig reads the token from github.token in the config file.
+	CredentialSourceConfig CredentialSource = "config"
+
+	// CredentialSourceCommand runs github.token_command and reads the token from its stdout.
+	CredentialSourceCommand CredentialSource = "token_command"
+
+	// CredentialSourceGithubApp authenticates as a GitHub App installation.
+	CredentialSourceGithubApp CredentialSource = "app"
+
+	// CredentialSourceGhCli reads the token from the GitHub CLI's hosts.yml.
+	CredentialSourceGhCli CredentialSource = "gh"
+
+	// CredentialSourceNone means no credential source provided a token.
+	CredentialSourceNone CredentialSource = "none"
+
+	// CredentialSourceDemo is used by demo mode, no request leaves the process.
+	CredentialSourceDemo CredentialSource = "demo"
+)
+
+// DefaultCredentialSources is the order credential sources are tried in,
+// unless github.credential_sources overrides it.
+var DefaultCredentialSources = []CredentialSource{
+	CredentialSourceEnv,
+	CredentialSourceConfig,
+	CredentialSourceCommand,
+	CredentialSourceGithubApp,
+	CredentialSourceGhCli,
+}
+
+// DefaultProfileCredentialSources is used by named profiles. GITHUB_TOKEN is left
+// out so that a token meant for one account is never sent to another.
+var DefaultProfileCredentialSources = []CredentialSource{
+	CredentialSourceConfig,
+	CredentialSourceCommand,
+	CredentialSourceGithubApp,
+	CredentialSourceGhCli,
+}
+
+const tokenCommandTimeout = 30 * time.Second
+
+// String returns a human readable description of the source.
+func (s CredentialSource) String() string {
+	switch s {
+	case CredentialSourceEnv:
+		return "GITHUB_TOKEN environment variable"
+	case CredentialSourceConfig:
+		return "github.token in config file"
+	case CredentialSourceCommand:
+		return "github.token_command"
+	case CredentialSourceGithubApp:
+		return "GitHub App installation"
+	case CredentialSourceGhCli:
+		return "GitHub CLI (hosts.yml)"
+	case CredentialSourceNone:
+		return "no credentials found"
+	case CredentialSourceDemo:
+		return "demo mode (no GitHub access)"
+	}
+	return string(s)
+}
+
+// ResolveCredentials walks the credential sources in precedence order and
+// stores the first token found, along with the source it came from.
+func ResolveCredentials(github *Github) error {
+	return resolveCredentials(github, DefaultCredentialSources)
+}
+
+func resolveCredentials(github *Github, defaultSources []CredentialSource) error {
+	sources := github.CredentialSources
+	if len(sources) == 0 {
+		sources = defaultSources
+	}
+
+	for _, source := range sources {
+		token, ok, err := resolveCredentialSource(github, source)
+		if err != nil {
+			return fmt.Errorf("failed to resolve credentials from %s: %w", source, err)
+		}
+		if ok {
+			github.Token = token
+			github.CredentialSource = source
+			return nil
+		}
+	}
+
+	github.Token = ""
+	github.CredentialSource = CredentialSourceNone
+	return nil
+}
+
+func resolveCredentialSource(github *Github, source CredentialSource) (string, bool, error) {
+	switch source {
+	case CredentialSourceEnv:
+		token := strings.TrimSpace(os.Getenv("GITHUB_TOKEN"))
+		return token, token != "", nil
+	case CredentialSourceConfig:
+		token := strings.TrimSpace(github.Token)
+		return token, token != "", nil
+	case CredentialSourceCommand:
+		if github.TokenCommand == "" {
+			return "", false, nil
+		}
+		token, err := runTokenCommand(github.TokenCommand)
+		if err != nil {
+			return "", false, err
+		}
+		return token, true, nil
+	case CredentialSourceGithubApp:
+		// The installation token is minted by the github repository on demand
+		return "", github.App.IsConfigured(), nil
+	case CredentialSourceGhCli:
+		return readGhCliToken(github.Host())
+	}
+	return "", false, fmt.Errorf("unknown credential source %q", source)
+}
+
+// runTokenCommand runs the configured command through the shell and returns its trimmed stdout.
+func runTokenCommand(command string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), tokenCommandTimeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%w: %s", err, msg)
+		}
+		return "", err
+	}
+
+	token := strings.TrimSpace(stdout.String())
+	if token == "" {
+		return "", fmt.Errorf("token command printed nothing")
+	}
+	return token, nil
+}
+
+// readGhCliToken reads the oauth token of the given host from the GitHub CLI's hosts.yml.
+// Tokens kept in the system keyring by newer gh versions are not visible here.
+func readGhCliToken(host string) (string, bool, error) {
+	hostsPath, err := ghCliHostsPath()
+	if err != nil {
+		return "", false, err
+	}
+
+	data, err := os.ReadFile(hostsPath)
+	if os.IsNotExist(err) {
+		return "", false, nil
+	} else if err != nil {
+		return "", false, err
+	}
+
+	var hosts map[string]struct {
+		User       string `yaml:"user"`
+		OAuthToken string `yaml:"oauth_token"`
+		Users      map[string]struct {
+			OAuthToken string `yaml:"oauth_token"`
+		} `yaml:"users"`
+	}
+	if err := yaml.Unmarshal(data, &hosts); err != nil {
+		return "", false, fmt.Errorf("failed to parse %s: %w", hostsPath, err)
+	}
+
+	entry, ok := hosts[host]
+	if !ok {
+		return "", false, nil
+	}
+	if entry.OAuthToken != "" {
+		return entry.OAuthToken, true, nil
+	}
+	if user, ok := entry.Users[entry.User]; ok && user.OAuthToken != "" {
+		return user.OAuthToken, true, nil
+	}
+	return "", false, nil
+}
+
+func ghCliHostsPath() (string, error) {
+	if dir := os.Getenv("GH_CONFIG_DIR"); dir != "" {
+		return filepath.Join(dir, "hosts.yml"), nil
+	}
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "gh", "hosts.yml"), nil
+	}
+	if runtime.GOOS == "windows" {
+		if dir := os.Getenv("AppData"); dir != "" {
+			return filepath.Join(dir, "GitHub CLI", "hosts.yml"), nil
+		}
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".config", "gh", "hosts.yml"), nil
+}
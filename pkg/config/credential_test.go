@@ -0,0 +1,71 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveCredentials_Precedence(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "env-token")
+	t.Setenv("GH_CONFIG_DIR", t.TempDir())
+
+	github := Github{Token: "config-token"}
+	assert.NoError(t, ResolveCredentials(&github))
+	assert.Equal(t, "env-token", github.Token)
+	assert.Equal(t, CredentialSourceEnv, github.CredentialSource)
+
+	github = Github{
+		Token:             "config-token",
+		CredentialSources: []CredentialSource{CredentialSourceConfig, CredentialSourceEnv},
+	}
+	assert.NoError(t, ResolveCredentials(&github))
+	assert.Equal(t, "config-token", github.Token)
+	assert.Equal(t, CredentialSourceConfig, github.CredentialSource)
+}
+
+func TestResolveCredentials_TokenCommand(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "")
+	t.Setenv("GH_CONFIG_DIR", t.TempDir())
+
+	github := Github{TokenCommand: "echo '  command-token  '"}
+	assert.NoError(t, ResolveCredentials(&github))
+	assert.Equal(t, "command-token", github.Token)
+	assert.Equal(t, CredentialSourceCommand, github.CredentialSource)
+
+	github = Github{TokenCommand: "exit 3"}
+	assert.Error(t, ResolveCredentials(&github))
+}
+
+func TestResolveCredentials_GhCli(t *testing.T) {
+	ghDir := t.TempDir()
+	t.Setenv("GITHUB_TOKEN", "")
+	t.Setenv("GH_CONFIG_DIR", ghDir)
+
+	hosts := []byte(`
+github.com:
+    user: octocat
+    git_protocol: https
+    users:
+        octocat:
+            oauth_token: gho_multi_account
+`)
+	assert.NoError(t, os.WriteFile(filepath.Join(ghDir, "hosts.yml"), hosts, 0o600))
+
+	github := Github{}
+	assert.NoError(t, ResolveCredentials(&github))
+	assert.Equal(t, "gho_multi_account", github.Token)
+	assert.Equal(t, CredentialSourceGhCli, github.CredentialSource)
+}
+
+func TestResolveCredentials_None(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "")
+	t.Setenv("GH_CONFIG_DIR", t.TempDir())
+
+	github := Github{}
+	assert.NoError(t, ResolveCredentials(&github))
+	assert.Equal(t, "", github.Token)
+	assert.Equal(t, CredentialSourceNone, github.CredentialSource)
+}
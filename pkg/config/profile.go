@@ -0,0 +1,104 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+const (
+	// DefaultProfileName names the top-level github section of the config file.
+	DefaultProfileName = "default"
+
+	// DefaultAPIURL is the REST API of github.com.
+	DefaultAPIURL = "https://api.github.com"
+)
+
+// Profile is a named set of GitHub settings, e.g. a second account or a GHES instance.
+type Profile struct {
+	Github Github `mapstructure:"github"`
+}
+
+// ProfileNames returns the default profile followed by the named profiles, sorted.
+func (c *Config) ProfileNames() []string {
+	names := []string{DefaultProfileName}
+	for name := range c.Profiles {
+		if name != DefaultProfileName {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names[1:])
+	return names
+}
+
+// WithProfile returns a copy of the config whose github section is the one of
+// the given profile, with its credentials resolved. It can be called on a
+// config returned by WithProfile to switch to another profile.
+func (c *Config) WithProfile(name string) (*Config, error) {
+	if name == "" {
+		name = DefaultProfileName
+	}
+
+	defaultGithub := c.Github
+	if c.defaultGithub != nil {
+		defaultGithub = *c.defaultGithub
+	}
+
+	cfg := *c
+	cfg.Profile = name
+	cfg.defaultGithub = &defaultGithub
+	cfg.Github = defaultGithub
+
+	defaultSources := DefaultCredentialSources
+	if name != DefaultProfileName {
+		profile, ok := c.Profiles[name]
+		if !ok {
+			return nil, fmt.Errorf("profile %q not found, available profiles: %s", name, strings.Join(c.ProfileNames(), ", "))
+		}
+		cfg.Github = profile.Github
+		defaultSources = DefaultProfileCredentialSources
+	}
+
+	if cfg.Github.APIURL == "" {
+		cfg.Github.APIURL = DefaultAPIURL
+	}
+	cfg.Github.APIURL = strings.TrimSuffix(cfg.Github.APIURL, "/")
+
+	if err := resolveCredentials(&cfg.Github, defaultSources); err != nil {
+		return nil, fmt.Errorf("profile %q: %w", name, err)
+	}
+
+	return &cfg, nil
+}
+
+// Host returns the GitHub host the API URL belongs to, e.g. github.com for api.github.com.
+func (g Github) Host() string {
+	apiURL, err := url.Parse(g.APIURL)
+	if g.APIURL == "" || err != nil || apiURL.Host == "" {
+		return "github.com"
+	}
+	return strings.TrimPrefix(apiURL.Host, "api.")
+}
+
+// WebURL returns the base URL of the GitHub web interface for the API URL.
+func (g Github) WebURL() string {
+	apiURL, err := url.Parse(g.APIURL)
+	if g.APIURL == "" || err != nil || apiURL.Host == "" {
+		return "https://github.com"
+	}
+	return apiURL.Scheme + "://" + g.Host()
+}
+
+// DemoConfig returns the config used by demo mode, it never reads the config
+// file or any credential source.
+func DemoConfig() *Config {
+	return &Config{
+		Profile: DefaultProfileName,
+		Github: Github{
+			APIURL:           DefaultAPIURL,
+			Token:            "demo",
+			CredentialSource: CredentialSourceDemo,
+		},
+	}
+}
@@ -0,0 +1,81 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_WithProfile(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "env-token")
+	t.Setenv("GH_CONFIG_DIR", t.TempDir())
+
+	cfg := &Config{
+		Github: Github{Token: "personal-token"},
+		Profiles: map[string]Profile{
+			"work": {Github: Github{Token: "work-token"}},
+			"ghes": {Github: Github{APIURL: "https://ghe.example.com/api/v3/", TokenCommand: "echo ghes-token"}},
+		},
+	}
+
+	assert.Equal(t, []string{"default", "ghes", "work"}, cfg.ProfileNames())
+
+	defaultCfg, err := cfg.WithProfile("")
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultProfileName, defaultCfg.Profile)
+	assert.Equal(t, "env-token", defaultCfg.Github.Token)
+	assert.Equal(t, DefaultAPIURL, defaultCfg.Github.APIURL)
+
+	// named profiles never pick up GITHUB_TOKEN by default
+	workCfg, err := cfg.WithProfile("work")
+	assert.NoError(t, err)
+	assert.Equal(t, "work-token", workCfg.Github.Token)
+	assert.Equal(t, CredentialSourceConfig, workCfg.Github.CredentialSource)
+
+	ghesCfg, err := cfg.WithProfile("ghes")
+	assert.NoError(t, err)
+	assert.Equal(t, "ghes-token", ghesCfg.Github.Token)
+	assert.Equal(t, "https://ghe.example.com/api/v3", ghesCfg.Github.APIURL)
+	assert.Equal(t, "ghe.example.com", ghesCfg.Github.Host())
+	assert.Equal(t, "https://ghe.example.com", ghesCfg.Github.WebURL())
+
+	_, err = cfg.WithProfile("missing")
+	assert.Error(t, err)
+}
+
+func TestConfig_WithProfile_SwitchBack(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "")
+	t.Setenv("GH_CONFIG_DIR", t.TempDir())
+
+	cfg := &Config{
+		Github:         Github{Token: "personal-token"},
+		DefaultProfile: "work",
+		Profiles: map[string]Profile{
+			"work": {Github: Github{APIURL: "https://ghe.example.com/api/v3", TokenCommand: "echo work-token"}},
+		},
+	}
+
+	workCfg, err := cfg.WithProfile(cfg.DefaultProfile)
+	assert.NoError(t, err)
+	assert.Equal(t, "work-token", workCfg.Github.Token)
+	assert.Equal(t, CredentialSourceCommand, workCfg.Github.CredentialSource)
+
+	// switching from the work profile resolves the top-level github section again
+	defaultCfg, err := workCfg.WithProfile(DefaultProfileName)
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultProfileName, defaultCfg.Profile)
+	assert.Equal(t, "personal-token", defaultCfg.Github.Token)
+	assert.Equal(t, CredentialSourceConfig, defaultCfg.Github.CredentialSource)
+	assert.Equal(t, DefaultAPIURL, defaultCfg.Github.APIURL)
+	assert.Empty(t, defaultCfg.Github.TokenCommand)
+
+	againCfg, err := defaultCfg.WithProfile("work")
+	assert.NoError(t, err)
+	assert.Equal(t, workCfg.Github, againCfg.Github)
+}
+
+func TestGithub_Host(t *testing.T) {
+	assert.Equal(t, "github.com", Github{}.Host())
+	assert.Equal(t, "github.com", Github{APIURL: DefaultAPIURL}.Host())
+	assert.Equal(t, "https://github.com", Github{APIURL: DefaultAPIURL}.WebURL())
+}
@@ -0,0 +1,80 @@
+package httpclient
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	pkgconfig "github.com/termkit/gama/pkg/config"
+)
+
+func TestBypassProxy(t *testing.T) {
+	noProxy := []string{"localhost", ".corp.example", "ghe.internal:8443", "10.0.0.0/8"}
+
+	cases := map[string]bool{
+		"https://api.github.com/user":         false,
+		"http://localhost:8080/":              true,
+		"https://git.corp.example/api/v3":     true,
+		"https://corp.example/":               true,
+		"https://ghe.internal:8443/api/v3":    true,
+		"https://ghe.internal/api/v3":         false,
+		"https://10.1.2.3/api/v3":             true,
+		"https://notcorp.example.com/api/v3/": false,
+	}
+
+	for rawURL, expected := range cases {
+		reqURL, err := url.Parse(rawURL)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, bypassProxy(reqURL, noProxy), rawURL)
+	}
+}
+
+func TestNew(t *testing.T) {
+	client, err := New(pkgconfig.Network{
+		Proxy:   "http://proxy.example:3128",
+		NoProxy: []string{"localhost"},
+		Timeout: 5 * time.Second,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 5*time.Second, client.Timeout)
+
+	transport := client.Transport.(*http.Transport)
+
+	req, _ := http.NewRequest(http.MethodGet, "https://api.github.com/user", nil)
+	proxyURL, err := transport.Proxy(req)
+	assert.NoError(t, err)
+	assert.Equal(t, "proxy.example:3128", proxyURL.Host)
+
+	req, _ = http.NewRequest(http.MethodGet, "http://localhost/", nil)
+	proxyURL, err = transport.Proxy(req)
+	assert.NoError(t, err)
+	assert.Nil(t, proxyURL)
+
+	_, err = New(pkgconfig.Network{CACertificates: []string{"/does/not/exist.pem"}})
+	assert.Error(t, err)
+
+	_, err = New(pkgconfig.Network{ClientCertificate: "/only/cert.pem"})
+	assert.Error(t, err)
+}
+
+func TestNew_EnvironmentProxy(t *testing.T) {
+	t.Setenv("HTTPS_PROXY", "http://env-proxy.example:8080")
+	t.Setenv("NO_PROXY", "")
+
+	client, err := New(pkgconfig.Network{NoProxy: []string{"ghe.internal"}})
+	assert.NoError(t, err)
+
+	transport := client.Transport.(*http.Transport)
+
+	req, _ := http.NewRequest(http.MethodGet, "https://api.github.com/user", nil)
+	proxyURL, err := transport.Proxy(req)
+	assert.NoError(t, err)
+	assert.Equal(t, "env-proxy.example:8080", proxyURL.Host)
+
+	req, _ = http.NewRequest(http.MethodGet, "https://ghe.internal/api/v3/user", nil)
+	proxyURL, err = transport.Proxy(req)
+	assert.NoError(t, err)
+	assert.Nil(t, proxyURL)
+}
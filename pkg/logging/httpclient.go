@@ -0,0 +1,52 @@
+package logging
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// HttpClient is the subset of *http.Client used by the github repositories.
+type HttpClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+type tracingClient struct {
+	next HttpClient
+}
+
+// NewHTTPClient wraps the client and logs every request it performs.
+func NewHTTPClient(next HttpClient) HttpClient {
+	return &tracingClient{next: next}
+}
+
+func (c *tracingClient) Do(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := c.next.Do(req)
+	duration := time.Since(start)
+
+	attrs := []any{
+		"method", req.Method,
+		"url", req.URL.String(),
+		"duration", duration.String(),
+	}
+
+	if err != nil {
+		slog.Error("http request failed", append(attrs, "error", err)...)
+		return resp, err
+	}
+
+	attrs = append(attrs, "status", resp.StatusCode)
+	for _, header := range []string{"X-RateLimit-Limit", "X-RateLimit-Remaining", "X-RateLimit-Used", "X-RateLimit-Reset", "X-RateLimit-Resource"} {
+		if value := resp.Header.Get(header); value != "" {
+			attrs = append(attrs, header, value)
+		}
+	}
+
+	if resp.StatusCode >= 400 {
+		slog.Warn("http request", attrs...)
+	} else {
+		slog.Debug("http request", attrs...)
+	}
+	return resp, nil
+}
@@ -0,0 +1,88 @@
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"regexp"
+	"strings"
+)
+
+const redacted = "[REDACTED]"
+
+// sensitiveKeys are attribute keys whose values are never written.
+var sensitiveKeys = []string{"authorization", "token", "secret", "password", "private_key", "cookie"}
+
+// sensitiveValues match credentials that may appear inside any logged string.
+var sensitiveValues = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)\b(bearer|token|basic)\s+[A-Za-z0-9_\-.=:+/]{16,}`),
+	regexp.MustCompile(`gh[pousr]_[A-Za-z0-9]{16,}`),
+	regexp.MustCompile(`github_pat_[A-Za-z0-9_]{16,}`),
+	regexp.MustCompile(`eyJ[A-Za-z0-9_\-]+\.[A-Za-z0-9_\-]+\.[A-Za-z0-9_\-]+`),
+	regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`),
+}
+
+// Setup installs the default slog logger. When enabled, JSON logs are appended
+// to the file at path, otherwise every log is discarded so that nothing is
+// written over the terminal UI.
+func Setup(enabled bool, path string) (io.Closer, error) {
+	if !enabled {
+		slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+		return io.NopCloser(nil), nil
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open debug log: %w", err)
+	}
+
+	slog.SetDefault(slog.New(NewHandler(file, slog.LevelDebug)))
+	slog.Info("debug logging enabled", "pid", os.Getpid())
+
+	return file, nil
+}
+
+// NewHandler returns a JSON handler that redacts secrets from every attribute.
+func NewHandler(w io.Writer, level slog.Level) slog.Handler {
+	return slog.NewJSONHandler(w, &slog.HandlerOptions{
+		Level:       level,
+		ReplaceAttr: redactAttr,
+	})
+}
+
+// Redact masks every credential found in s.
+func Redact(s string) string {
+	for _, pattern := range sensitiveValues {
+		s = pattern.ReplaceAllStringFunc(s, func(match string) string {
+			// keep the scheme of authorization values for context
+			if scheme, _, ok := strings.Cut(match, " "); ok && !strings.ContainsAny(scheme, "_-") {
+				return scheme + " " + redacted
+			}
+			return redacted
+		})
+	}
+	return s
+}
+
+func redactAttr(_ []string, attr slog.Attr) slog.Attr {
+	key := strings.ToLower(attr.Key)
+	for _, sensitive := range sensitiveKeys {
+		if strings.Contains(key, sensitive) {
+			return slog.String(attr.Key, redacted)
+		}
+	}
+
+	switch attr.Value.Kind() {
+	case slog.KindString:
+		return slog.String(attr.Key, Redact(attr.Value.String()))
+	case slog.KindAny:
+		if err, ok := attr.Value.Any().(error); ok {
+			return slog.String(attr.Key, Redact(err.Error()))
+		}
+		if stringer, ok := attr.Value.Any().(fmt.Stringer); ok {
+			return slog.String(attr.Key, Redact(stringer.String()))
+		}
+	}
+	return attr
+}
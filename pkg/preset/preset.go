@@ -0,0 +1,144 @@
+package preset
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Preset is a named set of trigger inputs of a workflow.
+type Preset struct {
+	Name   string            `json:"name"`
+	Inputs map[string]string `json:"inputs"`
+}
+
+// Store keeps presets in a JSON file, keyed by host, repository and workflow
+// path. The host keeps apart the repositories of GitHub Enterprise servers
+// that share a name with one on github.com.
+type Store struct {
+	path string
+	mu   sync.Mutex
+}
+
+// file is the layout of the presets file: host/repository -> workflow path -> presets.
+type file map[string]map[string][]Preset
+
+func NewStore(path string) *Store {
+	return &Store{path: path}
+}
+
+// key is the entry of a repository in the presets file, e.g. github.com/owner/repo.
+func key(host string, repository string) string {
+	return host + "/" + repository
+}
+
+// List returns the presets of a workflow sorted by name.
+func (s *Store) List(host string, repository string, workflowPath string) ([]Preset, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	presets, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+
+	result := slices.Clone(presets[key(host, repository)][workflowPath])
+	slices.SortFunc(result, func(a, b Preset) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return result, nil
+}
+
+// Save stores a preset, replacing the one with the same name.
+func (s *Store) Save(host string, repository string, workflowPath string, preset Preset) error {
+	if strings.TrimSpace(preset.Name) == "" {
+		return errors.New("preset name cannot be empty")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	presets, err := s.read()
+	if err != nil {
+		return err
+	}
+
+	repositoryKey := key(host, repository)
+	if presets[repositoryKey] == nil {
+		presets[repositoryKey] = make(map[string][]Preset)
+	}
+	workflowPresets := slices.DeleteFunc(presets[repositoryKey][workflowPath], func(p Preset) bool {
+		return p.Name == preset.Name
+	})
+	presets[repositoryKey][workflowPath] = append(workflowPresets, preset)
+
+	return s.write(presets)
+}
+
+// Delete removes a preset, deleting a missing preset is not an error.
+func (s *Store) Delete(host string, repository string, workflowPath string, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	presets, err := s.read()
+	if err != nil {
+		return err
+	}
+
+	repositoryKey := key(host, repository)
+	workflowPresets := slices.DeleteFunc(presets[repositoryKey][workflowPath], func(p Preset) bool {
+		return p.Name == name
+	})
+	if len(workflowPresets) == 0 {
+		delete(presets[repositoryKey], workflowPath)
+	} else {
+		presets[repositoryKey][workflowPath] = workflowPresets
+	}
+	if len(presets[repositoryKey]) == 0 {
+		delete(presets, repositoryKey)
+	}
+
+	return s.write(presets)
+}
+
+func (s *Store) read() (file, error) {
+	presets := make(file)
+
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return presets, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read presets: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &presets); err != nil {
+		return nil, fmt.Errorf("failed to parse presets %s: %w", s.path, err)
+	}
+	return presets, nil
+}
+
+func (s *Store) write(presets file) error {
+	data, err := json.MarshalIndent(presets, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return fmt.Errorf("failed to create presets directory: %w", err)
+	}
+
+	// Write to a temporary file first so that a failed write does not lose the existing presets
+	tmpPath := s.path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write presets: %w", err)
+	}
+	if err := os.Rename(tmpPath, s.path); err != nil {
+		return fmt.Errorf("failed to write presets: %w", err)
+	}
+	return nil
+}
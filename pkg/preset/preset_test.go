@@ -0,0 +1,56 @@
+package preset
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStore(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), "state", "presets.json"))
+
+	presets, err := store.List("github.com", "owner/repo", ".github/workflows/deploy.yml")
+	assert.NoError(t, err)
+	assert.Empty(t, presets)
+
+	assert.NoError(t, store.Save("github.com", "owner/repo", ".github/workflows/deploy.yml", Preset{Name: "staging", Inputs: map[string]string{"environment": "staging"}}))
+	assert.NoError(t, store.Save("github.com", "owner/repo", ".github/workflows/deploy.yml", Preset{Name: "production", Inputs: map[string]string{"environment": "production"}}))
+	assert.NoError(t, store.Save("github.com", "owner/repo", ".github/workflows/release.yml", Preset{Name: "minor", Inputs: map[string]string{"bump": "minor"}}))
+
+	// Saving with an existing name replaces the preset
+	assert.NoError(t, store.Save("github.com", "owner/repo", ".github/workflows/deploy.yml", Preset{Name: "staging", Inputs: map[string]string{"environment": "staging", "replicas": "2"}}))
+
+	presets, err = store.List("github.com", "owner/repo", ".github/workflows/deploy.yml")
+	assert.NoError(t, err)
+	assert.Equal(t, []Preset{
+		{Name: "production", Inputs: map[string]string{"environment": "production"}},
+		{Name: "staging", Inputs: map[string]string{"environment": "staging", "replicas": "2"}},
+	}, presets)
+
+	presets, err = NewStore(store.path).List("github.com", "owner/other", ".github/workflows/deploy.yml")
+	assert.NoError(t, err)
+	assert.Empty(t, presets)
+
+	assert.NoError(t, store.Delete("github.com", "owner/repo", ".github/workflows/deploy.yml", "production"))
+	assert.NoError(t, store.Delete("github.com", "owner/repo", ".github/workflows/deploy.yml", "missing"))
+	presets, err = store.List("github.com", "owner/repo", ".github/workflows/deploy.yml")
+	assert.NoError(t, err)
+	assert.Len(t, presets, 1)
+
+	// A repository of the same name on another host has its own presets
+	presets, err = store.List("ghe.example.com", "owner/repo", ".github/workflows/deploy.yml")
+	assert.NoError(t, err)
+	assert.Empty(t, presets)
+
+	assert.Error(t, store.Save("github.com", "owner/repo", ".github/workflows/deploy.yml", Preset{Name: " "}))
+}
+
+func TestStore_Corrupt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "presets.json")
+	assert.NoError(t, os.WriteFile(path, []byte("{"), 0o600))
+
+	_, err := NewStore(path).List("github.com", "owner/repo", ".github/workflows/deploy.yml")
+	assert.ErrorContains(t, err, "failed to parse presets")
+}
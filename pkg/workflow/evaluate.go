@@ -0,0 +1,733 @@
+package workflow
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	py "github.com/termkit/gama/pkg/yaml"
+	"gopkg.in/yaml.v3"
+)
+
+// DynamicError is returned when an expression needs what is only known once
+// the workflow runs, like the outputs of other jobs or the status of steps.
+type DynamicError struct {
+	Reference string // e.g. needs.setup.outputs.targets or hashFiles()
+}
+
+func (e DynamicError) Error() string {
+	return fmt.Sprintf("%s is only known when the workflow runs", e.Reference)
+}
+
+// object is an object of an expression. It keeps the order of its keys, which
+// is the order of the axes of a matrix and of the values in job names.
+type object struct {
+	keys    []string
+	values  map[string]any
+	partial bool // a missing key is only known when the workflow runs
+}
+
+func newObject() *object {
+	return &object{values: make(map[string]any)}
+}
+
+func (o *object) set(key string, value any) {
+	if _, ok := o.values[key]; !ok {
+		o.keys = append(o.keys, key)
+	}
+	o.values[key] = value
+}
+
+// get looks up a key, ignoring case like property names of expressions.
+func (o *object) get(key string) (any, bool) {
+	if value, ok := o.values[key]; ok {
+		return value, true
+	}
+	for _, k := range o.keys {
+		if strings.EqualFold(k, key) {
+			return o.values[k], true
+		}
+	}
+	return nil, false
+}
+
+// filtered is the result of an object filter, e.g. labels.*.name.
+type filtered []any
+
+// evaluator evaluates expressions without running the workflow. Values are
+// nil, bool, float64, string, []any and *object.
+type evaluator struct {
+	contexts map[string]any // by lower case name, a missing context is dynamic
+}
+
+// newDispatchEvaluator returns an evaluator knowing the inputs of a
+// workflow_dispatch event, as inputs and as github.event.inputs.
+func newDispatchEvaluator(inputs map[string]any) *evaluator {
+	typed := newObject()
+	strs := newObject()
+	for _, key := range sortedKeys(inputs) {
+		value := normalizeValue(inputs[key])
+		typed.set(key, value)
+		strs.set(key, toString(value)) // github.event.inputs are always strings
+	}
+
+	event := newObject()
+	event.partial = true
+	event.set("inputs", strs)
+	github := newObject()
+	github.partial = true
+	github.set("event", event)
+	github.set("event_name", "workflow_dispatch")
+
+	return &evaluator{contexts: map[string]any{"inputs": typed, "github": github}}
+}
+
+// with returns a copy of the evaluator knowing one more context.
+func (ev *evaluator) with(name string, value any) *evaluator {
+	contexts := make(map[string]any, len(ev.contexts)+1)
+	for k, v := range ev.contexts {
+		contexts[k] = v
+	}
+	contexts[strings.ToLower(name)] = value
+	return &evaluator{contexts: contexts}
+}
+
+// normalizeValue converts decoded JSON and Go values to values of expressions.
+func normalizeValue(value any) any {
+	switch value := value.(type) {
+	case nil, bool, float64, string, *object:
+		return value
+	case json.Number:
+		f, err := value.Float64()
+		if err != nil {
+			return value.String()
+		}
+		return f
+	case int:
+		return float64(value)
+	case int64:
+		return float64(value)
+	case []any:
+		items := make([]any, len(value))
+		for i, item := range value {
+			items[i] = normalizeValue(item)
+		}
+		return items
+	case map[string]any:
+		o := newObject()
+		for _, key := range sortedKeys(value) {
+			o.set(key, normalizeValue(value[key]))
+		}
+		return o
+	}
+	return fmt.Sprint(value)
+}
+
+// plainValue converts a value of an expression to plain Go values, objects
+// become map[string]any.
+func plainValue(value any) any {
+	switch value := value.(type) {
+	case *object:
+		m := make(map[string]any, len(value.keys))
+		for _, key := range value.keys {
+			m[key] = plainValue(value.values[key])
+		}
+		return m
+	case []any:
+		items := make([]any, len(value))
+		for i, item := range value {
+			items[i] = plainValue(item)
+		}
+		return items
+	case filtered:
+		return plainValue([]any(value))
+	}
+	return value
+}
+
+// node returns the value of a YAML node, evaluating the expressions of its scalars.
+func (ev *evaluator) node(node *yaml.Node) (any, error) {
+	node = py.Resolve(node)
+	if node == nil {
+		return nil, nil
+	}
+
+	switch node.Kind {
+	case yaml.SequenceNode:
+		items := make([]any, 0, len(node.Content))
+		for _, item := range node.Content {
+			value, err := ev.node(item)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, value)
+		}
+		return items, nil
+	case yaml.MappingNode:
+		o := newObject()
+		for _, pair := range py.Pairs(node) {
+			value, err := ev.node(pair.Value)
+			if err != nil {
+				return nil, err
+			}
+			o.set(pair.Key.Value, value)
+		}
+		return o, nil
+	case yaml.ScalarNode:
+		if strings.Contains(node.Value, "${{") {
+			value, err := ev.interpolate(node.Value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", node.Line, err)
+			}
+			return value, nil
+		}
+		switch node.Tag {
+		case "!!null":
+			return nil, nil
+		case "!!bool":
+			return strings.EqualFold(node.Value, "true"), nil
+		case "!!int", "!!float":
+			if f, ok := parseNumber(node.Value); ok {
+				return f, nil
+			}
+		}
+		return node.Value, nil
+	}
+	return nil, fmt.Errorf("line %d: unexpected YAML node", node.Line)
+}
+
+// interpolate evaluates the ${{ }} expressions of a string. A string that is
+// a single expression has the value of the expression, with its type.
+func (ev *evaluator) interpolate(s string) (any, error) {
+	expressions := findExpressions(s)
+	if len(expressions) == 1 && expressions[0].Closed && expressions[0].Offset == 0 && len(s) == len(expressions[0].Source)+5 {
+		return ev.evaluate(expressions[0].Source)
+	}
+
+	var b strings.Builder
+	offset := 0
+	for _, expression := range expressions {
+		if !expression.Closed {
+			return nil, fmt.Errorf("expression %q is not closed", s[expression.Offset:])
+		}
+		value, err := ev.evaluate(expression.Source)
+		if err != nil {
+			return nil, err
+		}
+		b.WriteString(s[offset:expression.Offset])
+		b.WriteString(toString(value))
+		offset = expression.Offset + len(expression.Source) + 5
+	}
+	b.WriteString(s[offset:])
+	return b.String(), nil
+}
+
+// evaluate evaluates the source of an expression.
+func (ev *evaluator) evaluate(source string) (any, error) {
+	e, err := parseExpression(source)
+	if err != nil {
+		return nil, err
+	}
+	return ev.eval(e)
+}
+
+func (ev *evaluator) eval(e expr) (any, error) {
+	switch e := e.(type) {
+	case literalExpr:
+		return e.value, nil
+	case contextExpr:
+		value, ok := ev.contexts[strings.ToLower(e.name)]
+		if !ok {
+			return nil, DynamicError{Reference: e.name}
+		}
+		return value, nil
+	case propertyExpr:
+		target, err := ev.eval(e.target)
+		if err != nil {
+			return nil, extendDynamic(err, e.target, e)
+		}
+		return property(target, e.name, func() string { return referenceName(e) })
+	case indexExpr:
+		target, err := ev.eval(e.target)
+		if err != nil {
+			return nil, extendDynamic(err, e.target, e)
+		}
+		index, err := ev.eval(e.index)
+		if err != nil {
+			return nil, err
+		}
+		if target, ok := asArray(target); ok {
+			if number, ok := index.(float64); ok {
+				if i := int(number); number == math.Trunc(number) && i >= 0 && i < len(target) {
+					return target[i], nil
+				}
+				return nil, nil
+			}
+		}
+		return property(target, toString(index), func() string { return referenceName(e) })
+	case callExpr:
+		args := make([]any, 0, len(e.args))
+		for _, arg := range e.args {
+			value, err := ev.eval(arg)
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, value)
+		}
+		return call(e.name, args)
+	case notExpr:
+		operand, err := ev.eval(e.operand)
+		if err != nil {
+			return nil, err
+		}
+		return !truthy(operand), nil
+	case binaryExpr:
+		left, err := ev.eval(e.left)
+		if err != nil {
+			return nil, err
+		}
+		// && and || return one of their operands and don't evaluate the right one when the left one decides
+		switch e.op {
+		case "&&":
+			if !truthy(left) {
+				return left, nil
+			}
+			return ev.eval(e.right)
+		case "||":
+			if truthy(left) {
+				return left, nil
+			}
+			return ev.eval(e.right)
+		}
+		right, err := ev.eval(e.right)
+		if err != nil {
+			return nil, err
+		}
+		return compare(e.op, left, right), nil
+	}
+	return nil, fmt.Errorf("unexpected expression %T", e)
+}
+
+func property(target any, name string, reference func() string) (any, error) {
+	switch target := target.(type) {
+	case *object:
+		if name == "*" {
+			values := make(filtered, 0, len(target.keys))
+			for _, key := range target.keys {
+				values = append(values, target.values[key])
+			}
+			return values, nil
+		}
+		value, ok := target.get(name)
+		if !ok && target.partial {
+			return nil, DynamicError{Reference: reference()}
+		}
+		return value, nil
+	case []any:
+		if name == "*" {
+			return filtered(target), nil
+		}
+	case filtered:
+		var values filtered
+		for _, item := range target {
+			value, err := property(item, name, reference)
+			if err != nil {
+				return nil, err
+			}
+			if value != nil {
+				values = append(values, value)
+			}
+		}
+		return values, nil
+	}
+	return nil, nil
+}
+
+// extendDynamic reports the whole reference when a property of a dynamic
+// context is looked up, e.g. needs.setup.outputs.targets instead of needs.
+func extendDynamic(err error, target expr, e expr) error {
+	var dynamic DynamicError
+	if errors.As(err, &dynamic) && dynamic.Reference == referenceName(target) {
+		return DynamicError{Reference: referenceName(e)}
+	}
+	return err
+}
+
+// referenceName returns the source of a property reference, e.g. needs.setup.outputs.targets.
+func referenceName(e expr) string {
+	switch e := e.(type) {
+	case contextExpr:
+		return e.name
+	case propertyExpr:
+		return referenceName(e.target) + "." + e.name
+	case indexExpr:
+		if literal, ok := e.index.(literalExpr); ok {
+			if s, ok := literal.value.(string); ok {
+				return referenceName(e.target) + "['" + s + "']"
+			}
+		}
+		return referenceName(e.target) + "[…]"
+	case callExpr:
+		return e.name + "()"
+	}
+	return "expression"
+}
+
+func call(name string, args []any) (any, error) {
+	arity := func(minimum, maximum int) error {
+		if len(args) < minimum || len(args) > maximum {
+			return fmt.Errorf("%s() called with %d arguments", name, len(args))
+		}
+		return nil
+	}
+
+	switch strings.ToLower(name) {
+	case "fromjson":
+		if err := arity(1, 1); err != nil {
+			return nil, err
+		}
+		value, err := decodeOrderedJSON(toString(args[0]))
+		if err != nil {
+			return nil, fmt.Errorf("fromJSON(): %w", err)
+		}
+		return value, nil
+	case "tojson":
+		if err := arity(1, 1); err != nil {
+			return nil, err
+		}
+		var b strings.Builder
+		writeJSON(&b, args[0], "\n")
+		return b.String(), nil
+	case "format":
+		if err := arity(1, math.MaxInt); err != nil {
+			return nil, err
+		}
+		return format(toString(args[0]), args[1:])
+	case "contains":
+		if err := arity(2, 2); err != nil {
+			return nil, err
+		}
+		if items, ok := asArray(args[0]); ok {
+			for _, item := range items {
+				if compare("==", item, args[1]).(bool) {
+					return true, nil
+				}
+			}
+			return false, nil
+		}
+		return strings.Contains(strings.ToLower(toString(args[0])), strings.ToLower(toString(args[1]))), nil
+	case "startswith":
+		if err := arity(2, 2); err != nil {
+			return nil, err
+		}
+		return strings.HasPrefix(strings.ToLower(toString(args[0])), strings.ToLower(toString(args[1]))), nil
+	case "endswith":
+		if err := arity(2, 2); err != nil {
+			return nil, err
+		}
+		return strings.HasSuffix(strings.ToLower(toString(args[0])), strings.ToLower(toString(args[1]))), nil
+	case "join":
+		if err := arity(1, 2); err != nil {
+			return nil, err
+		}
+		separator := ","
+		if len(args) == 2 {
+			separator = toString(args[1])
+		}
+		items, ok := asArray(args[0])
+		if !ok {
+			return toString(args[0]), nil
+		}
+		strs := make([]string, len(items))
+		for i, item := range items {
+			strs[i] = toString(item)
+		}
+		return strings.Join(strs, separator), nil
+	}
+	// success(), hashFiles() and the like depend on the run
+	return nil, DynamicError{Reference: name + "()"}
+}
+
+func asArray(value any) ([]any, bool) {
+	switch value := value.(type) {
+	case []any:
+		return value, true
+	case filtered:
+		return value, true
+	}
+	return nil, false
+}
+
+// format replaces {0}, {1}... by the arguments, {{ and }} are escaped braces.
+func format(s string, args []any) (string, error) {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch {
+		case strings.HasPrefix(s[i:], "{{"), strings.HasPrefix(s[i:], "}}"):
+			b.WriteByte(s[i])
+			i++
+		case s[i] == '{':
+			end := strings.IndexByte(s[i:], '}')
+			if end < 0 {
+				return "", fmt.Errorf("format(): %q has an unclosed {", s)
+			}
+			index, err := strconv.Atoi(s[i+1 : i+end])
+			if err != nil || index < 0 || index >= len(args) {
+				return "", fmt.Errorf("format(): %q has no argument %s", s, s[i:i+end+1])
+			}
+			b.WriteString(toString(args[index]))
+			i += end
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String(), nil
+}
+
+// truthy converts a value to a boolean: false, 0, NaN, ” and null are false.
+func truthy(value any) bool {
+	switch value := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return value
+	case float64:
+		return value != 0 && !math.IsNaN(value)
+	case string:
+		return value != ""
+	}
+	return true
+}
+
+// toNumber converts a value to a number for comparisons of different types.
+func toNumber(value any) float64 {
+	switch value := value.(type) {
+	case nil:
+		return 0
+	case bool:
+		if value {
+			return 1
+		}
+		return 0
+	case float64:
+		return value
+	case string:
+		s := strings.TrimSpace(value)
+		if s == "" {
+			return 0
+		}
+		if f, ok := parseNumber(s); ok {
+			return f
+		}
+	}
+	return math.NaN()
+}
+
+// toString converts a value to the string it is interpolated as.
+func toString(value any) string {
+	switch value := value.(type) {
+	case nil:
+		return ""
+	case bool:
+		return strconv.FormatBool(value)
+	case float64:
+		return formatNumber(value)
+	case string:
+		return value
+	case *object:
+		return "Object"
+	}
+	return "Array"
+}
+
+func formatNumber(f float64) string {
+	if f == math.Trunc(f) && math.Abs(f) < 1e15 {
+		return strconv.FormatFloat(f, 'f', 0, 64)
+	}
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// compare applies a comparison operator. Values of different types are
+// compared as numbers, strings ignoring case. Objects and arrays are only
+// equal to themselves.
+func compare(op string, left, right any) any {
+	ls, lok := left.(string)
+	rs, rok := right.(string)
+	var c int
+	switch {
+	case lok && rok:
+		c = strings.Compare(strings.ToLower(ls), strings.ToLower(rs))
+	case isContainer(left) || isContainer(right):
+		equal := isContainer(left) && isContainer(right) && sameContainer(left, right)
+		switch op {
+		case "==":
+			return equal
+		case "!=":
+			return !equal
+		}
+		return false
+	default:
+		l, r := toNumber(left), toNumber(right)
+		if math.IsNaN(l) || math.IsNaN(r) {
+			return op == "!="
+		}
+		switch {
+		case l < r:
+			c = -1
+		case l > r:
+			c = 1
+		}
+	}
+
+	switch op {
+	case "==":
+		return c == 0
+	case "!=":
+		return c != 0
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	case ">=":
+		return c >= 0
+	}
+	return false
+}
+
+func isContainer(value any) bool {
+	switch value.(type) {
+	case *object, []any, filtered:
+		return true
+	}
+	return false
+}
+
+func sameContainer(left, right any) bool {
+	if l, ok := left.(*object); ok {
+		r, ok := right.(*object)
+		return ok && l == r
+	}
+	l, _ := asArray(left)
+	r, _ := asArray(right)
+	return len(l) > 0 && len(l) == len(r) && &l[0] == &r[0]
+}
+
+// decodeOrderedJSON decodes JSON keeping the order of the keys of objects.
+func decodeOrderedJSON(s string) (any, error) {
+	decoder := json.NewDecoder(strings.NewReader(s))
+	decoder.UseNumber()
+
+	value, err := decodeJSONValue(decoder)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, errors.New("unexpected data after the JSON value")
+	}
+	return value, nil
+}
+
+func decodeJSONValue(decoder *json.Decoder) (any, error) {
+	t, err := decoder.Token()
+	if err == io.EOF {
+		return nil, errors.New("empty JSON")
+	} else if err != nil {
+		return nil, err
+	}
+
+	switch t := t.(type) {
+	case json.Delim:
+		switch t {
+		case '{':
+			o := newObject()
+			for decoder.More() {
+				key, err := decoder.Token()
+				if err != nil {
+					return nil, err
+				}
+				value, err := decodeJSONValue(decoder)
+				if err != nil {
+					return nil, err
+				}
+				o.set(key.(string), value)
+			}
+			_, err := decoder.Token() // }
+			return o, err
+		case '[':
+			items := []any{}
+			for decoder.More() {
+				value, err := decodeJSONValue(decoder)
+				if err != nil {
+					return nil, err
+				}
+				items = append(items, value)
+			}
+			_, err := decoder.Token() // ]
+			return items, err
+		}
+	case json.Number:
+		return normalizeValue(t), nil
+	case string, bool, nil:
+		return t, nil
+	}
+	return nil, fmt.Errorf("unexpected JSON token %v", t)
+}
+
+// writeJSON writes a value as JSON, indented by two spaces after each
+// newline when newline is not empty, compact otherwise.
+func writeJSON(b *strings.Builder, value any, newline string) {
+	indent := ""
+	if newline != "" {
+		indent = newline + "  "
+	}
+	separator, colon := ",", ":"
+	if newline != "" {
+		colon = ": "
+	}
+
+	switch value := value.(type) {
+	case *object:
+		if len(value.keys) == 0 {
+			b.WriteString("{}")
+			return
+		}
+		b.WriteString("{")
+		for i, key := range value.keys {
+			if i > 0 {
+				b.WriteString(separator)
+			}
+			b.WriteString(indent)
+			k, _ := json.Marshal(key)
+			b.Write(k)
+			b.WriteString(colon)
+			writeJSON(b, value.values[key], indent)
+		}
+		b.WriteString(newline + "}")
+	case []any, filtered:
+		items, _ := asArray(value)
+		if len(items) == 0 {
+			b.WriteString("[]")
+			return
+		}
+		b.WriteString("[")
+		for i, item := range items {
+			if i > 0 {
+				b.WriteString(separator)
+			}
+			b.WriteString(indent)
+			writeJSON(b, item, indent)
+		}
+		b.WriteString(newline + "]")
+	case float64:
+		b.WriteString(formatNumber(value))
+	default:
+		data, _ := json.Marshal(value)
+		b.Write(data)
+	}
+}
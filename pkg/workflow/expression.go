@@ -0,0 +1,400 @@
+package workflow
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ExpressionError is a syntax error of a ${{ }} expression.
+type ExpressionError struct {
+	Offset  int // byte of the expression where the error was found
+	Message string
+}
+
+func (e ExpressionError) Error() string {
+	return e.Message
+}
+
+// expr is a node of a parsed expression.
+type expr interface{}
+
+type (
+	literalExpr  struct{ value any } // nil, bool, float64 or string
+	contextExpr  struct{ name string }
+	propertyExpr struct {
+		target expr
+		name   string // * for object filters
+	}
+	indexExpr struct {
+		target expr
+		index  expr
+	}
+	callExpr struct {
+		name string
+		args []expr
+	}
+	notExpr    struct{ operand expr }
+	binaryExpr struct {
+		op          string
+		left, right expr
+	}
+)
+
+// embeddedExpression is a ${{ }} expression in a string.
+type embeddedExpression struct {
+	Offset int    // byte of the string where ${{ starts
+	Source string // the expression between the braces
+	Closed bool
+}
+
+// findExpressions returns the ${{ }} expressions of a string. A }} inside a
+// string literal doesn't end the expression.
+func findExpressions(s string) []embeddedExpression {
+	var found []embeddedExpression
+	for offset := 0; ; {
+		start := strings.Index(s[offset:], "${{")
+		if start < 0 {
+			return found
+		}
+		start += offset
+
+		end, closed := expressionEnd(s, start+3)
+		found = append(found, embeddedExpression{Offset: start, Source: s[start+3 : end], Closed: closed})
+		if !closed {
+			return found
+		}
+		offset = end + 2
+	}
+}
+
+// expressionEnd returns the index of the }} closing the expression starting at start.
+func expressionEnd(s string, start int) (int, bool) {
+	inString := false
+	for i := start; i < len(s); i++ {
+		switch {
+		case s[i] == '\'':
+			inString = !inString // an escaped quote toggles twice
+		case !inString && strings.HasPrefix(s[i:], "}}"):
+			return i, true
+		}
+	}
+	return len(s), false
+}
+
+// parseExpression parses the syntax of an expression of GitHub Actions.
+func parseExpression(source string) (expr, error) {
+	tokens, err := tokenizeExpression(source)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 1 {
+		return nil, ExpressionError{Message: "expression is empty"}
+	}
+
+	p := &expressionParser{tokens: tokens}
+	e, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if next := p.peek(); next.kind != tokenEnd {
+		return nil, p.unexpected(next)
+	}
+	return e, nil
+}
+
+type tokenKind int
+
+const (
+	tokenEnd tokenKind = iota
+	tokenLiteral
+	tokenIdentifier
+	tokenOperator
+)
+
+type token struct {
+	kind   tokenKind
+	text   string
+	value  any // value of literals
+	offset int
+}
+
+func tokenizeExpression(source string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(source); {
+		c := source[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '\'':
+			var value strings.Builder
+			end := -1
+			for j := i + 1; j < len(source); j++ {
+				if source[j] != '\'' {
+					value.WriteByte(source[j])
+					continue
+				}
+				if j+1 < len(source) && source[j+1] == '\'' {
+					value.WriteByte('\'')
+					j++
+					continue
+				}
+				end = j
+				break
+			}
+			if end < 0 {
+				return nil, ExpressionError{Offset: i, Message: "string is not terminated"}
+			}
+			tokens = append(tokens, token{kind: tokenLiteral, text: source[i : end+1], value: value.String(), offset: i})
+			i = end + 1
+		case isDigit(c) || (c == '-' || c == '.') && i+1 < len(source) && isDigit(source[i+1]):
+			j := i + 1
+			for j < len(source) && (isIdentifierChar(source[j]) || source[j] == '.' || (source[j] == '-' || source[j] == '+') && (source[j-1] == 'e' || source[j-1] == 'E')) {
+				j++
+			}
+			text := source[i:j]
+			value, ok := parseNumber(text)
+			if !ok {
+				return nil, ExpressionError{Offset: i, Message: fmt.Sprintf("%q is not a number", text)}
+			}
+			tokens = append(tokens, token{kind: tokenLiteral, text: text, value: value, offset: i})
+			i = j
+		case isIdentifierStart(c):
+			j := i + 1
+			for j < len(source) && isIdentifierChar(source[j]) {
+				j++
+			}
+			text := source[i:j]
+			t := token{kind: tokenIdentifier, text: text, offset: i}
+			switch text {
+			case "null":
+				t = token{kind: tokenLiteral, text: text, offset: i}
+			case "true", "false":
+				t = token{kind: tokenLiteral, text: text, value: text == "true", offset: i}
+			}
+			tokens = append(tokens, t)
+			i = j
+		default:
+			operator := ""
+			for _, candidate := range []string{"==", "!=", "<=", ">=", "&&", "||", "<", ">", "!", "(", ")", "[", "]", ".", ",", "*"} {
+				if strings.HasPrefix(source[i:], candidate) {
+					operator = candidate
+					break
+				}
+			}
+			if operator == "" {
+				if c == '"' {
+					return nil, ExpressionError{Offset: i, Message: "strings must use single quotes"}
+				}
+				if c == '=' || c == '&' || c == '|' {
+					return nil, ExpressionError{Offset: i, Message: fmt.Sprintf("unknown operator %q, use %q", c, strings.Repeat(string(c), 2))}
+				}
+				return nil, ExpressionError{Offset: i, Message: fmt.Sprintf("unexpected character %q", c)}
+			}
+			tokens = append(tokens, token{kind: tokenOperator, text: operator, offset: i})
+			i += len(operator)
+		}
+	}
+
+	return append(tokens, token{kind: tokenEnd, offset: len(source)}), nil
+}
+
+func parseNumber(text string) (float64, bool) {
+	if strings.HasPrefix(text, "0x") {
+		value, err := strconv.ParseInt(text[2:], 16, 64)
+		return float64(value), err == nil
+	}
+	if strings.HasPrefix(text, "0o") {
+		value, err := strconv.ParseInt(text[2:], 8, 64)
+		return float64(value), err == nil
+	}
+	value, err := strconv.ParseFloat(text, 64)
+	return value, err == nil
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isIdentifierStart(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_'
+}
+
+func isIdentifierChar(c byte) bool {
+	return isIdentifierStart(c) || isDigit(c) || c == '-'
+}
+
+// expressionParser is a recursive descent parser, one method per precedence level.
+type expressionParser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *expressionParser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *expressionParser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEnd {
+		p.pos++
+	}
+	return t
+}
+
+func (p *expressionParser) accept(operators ...string) (string, bool) {
+	t := p.peek()
+	if t.kind != tokenOperator {
+		return "", false
+	}
+	for _, operator := range operators {
+		if t.text == operator {
+			p.pos++
+			return operator, true
+		}
+	}
+	return "", false
+}
+
+func (p *expressionParser) expect(operator string) error {
+	if _, ok := p.accept(operator); !ok {
+		t := p.peek()
+		if t.kind == tokenEnd {
+			return ExpressionError{Offset: t.offset, Message: fmt.Sprintf("missing %q", operator)}
+		}
+		return ExpressionError{Offset: t.offset, Message: fmt.Sprintf("expected %q, found %q", operator, t.text)}
+	}
+	return nil
+}
+
+func (p *expressionParser) unexpected(t token) error {
+	if t.kind == tokenEnd {
+		return ExpressionError{Offset: t.offset, Message: "expression ends unexpectedly"}
+	}
+	return ExpressionError{Offset: t.offset, Message: fmt.Sprintf("unexpected %q", t.text)}
+}
+
+func (p *expressionParser) binary(operand func() (expr, error), operators ...string) (expr, error) {
+	left, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.accept(operators...)
+		if !ok {
+			return left, nil
+		}
+		right, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		left = binaryExpr{op: op, left: left, right: right}
+	}
+}
+
+func (p *expressionParser) or() (expr, error) {
+	return p.binary(p.and, "||")
+}
+
+func (p *expressionParser) and() (expr, error) {
+	return p.binary(p.equality, "&&")
+}
+
+func (p *expressionParser) equality() (expr, error) {
+	return p.binary(p.comparison, "==", "!=")
+}
+
+func (p *expressionParser) comparison() (expr, error) {
+	return p.binary(p.unary, "<", "<=", ">", ">=")
+}
+
+func (p *expressionParser) unary() (expr, error) {
+	if _, ok := p.accept("!"); ok {
+		operand, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return notExpr{operand: operand}, nil
+	}
+	return p.postfix()
+}
+
+func (p *expressionParser) postfix() (expr, error) {
+	e, err := p.primary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		if _, ok := p.accept("."); ok {
+			t := p.next()
+			switch {
+			case t.kind == tokenIdentifier:
+				e = propertyExpr{target: e, name: t.text}
+			case t.kind == tokenLiteral && (t.text == "null" || t.text == "true" || t.text == "false"):
+				e = propertyExpr{target: e, name: t.text}
+			case t.kind == tokenOperator && t.text == "*":
+				e = propertyExpr{target: e, name: "*"}
+			default:
+				return nil, ExpressionError{Offset: t.offset, Message: "expected a property name after \".\""}
+			}
+			continue
+		}
+		if _, ok := p.accept("["); ok {
+			var index expr
+			if _, ok := p.accept("*"); ok {
+				index = literalExpr{value: "*"}
+			} else if index, err = p.or(); err != nil {
+				return nil, err
+			}
+			if err := p.expect("]"); err != nil {
+				return nil, err
+			}
+			e = indexExpr{target: e, index: index}
+			continue
+		}
+		return e, nil
+	}
+}
+
+func (p *expressionParser) primary() (expr, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenLiteral:
+		return literalExpr{value: t.value}, nil
+	case tokenIdentifier:
+		if _, ok := p.accept("("); !ok {
+			return contextExpr{name: t.text}, nil
+		}
+		call := callExpr{name: t.text}
+		if _, ok := p.accept(")"); ok {
+			return call, nil
+		}
+		for {
+			arg, err := p.or()
+			if err != nil {
+				return nil, err
+			}
+			call.args = append(call.args, arg)
+			if _, ok := p.accept(","); !ok {
+				break
+			}
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		return call, nil
+	case tokenOperator:
+		if t.text == "(" {
+			e, err := p.or()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			return e, nil
+		}
+	}
+	return nil, p.unexpected(t)
+}
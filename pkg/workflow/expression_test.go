@@ -0,0 +1,75 @@
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseExpression(t *testing.T) {
+	valid := []string{
+		"github.ref == 'refs/heads/main'",
+		"success() && !cancelled()",
+		"contains(github.event.pull_request.labels.*.name, 'deploy')",
+		"format('{0}-{1}', matrix.os, matrix.node-version)",
+		"fromJSON(inputs.targets)[0]",
+		"steps.build.outputs['artifact-name'] != ''",
+		"(github.event_name == 'push' || github.event_name == 'workflow_dispatch') && inputs.count >= 1.5",
+		"'it''s'",
+		"null",
+		"0xff",
+	}
+	for _, source := range valid {
+		_, err := parseExpression(source)
+		assert.NoError(t, err, source)
+	}
+
+	invalid := map[string]ExpressionError{
+		"":                             {Offset: 0, Message: "expression is empty"},
+		"github.ref = 'main'":          {Offset: 11, Message: `unknown operator '=', use "=="`},
+		"inputs.name == \"main\"":      {Offset: 15, Message: "strings must use single quotes"},
+		"contains(github.ref, 'main'":  {Offset: 27, Message: `missing ")"`},
+		"github.ref == 'main":          {Offset: 14, Message: "string is not terminated"},
+		"success() &&":                 {Offset: 12, Message: "expression ends unexpectedly"},
+		"github.":                      {Offset: 7, Message: `expected a property name after "."`},
+		"matrix.os matrix.arch":        {Offset: 10, Message: `unexpected "matrix"`},
+		"steps.build.outputs['a' == 1": {Offset: 28, Message: `missing "]"`},
+		"1.2.3":                        {Offset: 0, Message: `"1.2.3" is not a number`},
+	}
+	for source, expected := range invalid {
+		_, err := parseExpression(source)
+		assert.Equal(t, expected, err, source)
+	}
+}
+
+func TestParseExpression_Tree(t *testing.T) {
+	e, err := parseExpression("!a.b || fromJSON('[1]')[0] == 1 && c")
+	assert.NoError(t, err)
+
+	assert.Equal(t, binaryExpr{
+		op:   "||",
+		left: notExpr{operand: propertyExpr{target: contextExpr{name: "a"}, name: "b"}},
+		right: binaryExpr{
+			op: "&&",
+			left: binaryExpr{
+				op:    "==",
+				left:  indexExpr{target: callExpr{name: "fromJSON", args: []expr{literalExpr{value: "[1]"}}}, index: literalExpr{value: float64(0)}},
+				right: literalExpr{value: float64(1)},
+			},
+			right: contextExpr{name: "c"},
+		},
+	}, e)
+}
+
+func TestFindExpressions(t *testing.T) {
+	assert.Equal(t, []embeddedExpression{
+		{Offset: 5, Source: " inputs.name ", Closed: true},
+		{Offset: 24, Source: " format('}}') ", Closed: true},
+	}, findExpressions("echo ${{ inputs.name }} ${{ format('}}') }}"))
+
+	assert.Equal(t, []embeddedExpression{
+		{Offset: 0, Source: " github.ref", Closed: false},
+	}, findExpressions("${{ github.ref"))
+
+	assert.Empty(t, findExpressions("echo $HOME"))
+}
@@ -0,0 +1,411 @@
+package workflow
+
+import (
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	py "github.com/termkit/gama/pkg/yaml"
+	"gopkg.in/yaml.v3"
+)
+
+// Graph is the dependency graph of the jobs of a workflow. Jobs are placed in
+// layers from left to right, a job is in the layer after the last of its needs.
+type Graph struct {
+	Jobs   []*GraphJob   // in the order of the workflow
+	Layers [][]*GraphJob // top to bottom, ordered to reduce crossing edges
+
+	// nodes are the layers with a dummy node on every layer an edge crosses,
+	// so that edges only connect adjacent layers
+	nodes [][]*graphNode
+}
+
+// GraphJob is a job of the graph.
+type GraphJob struct {
+	ID    string
+	Name  string // the name: of the job, its ID when it has none
+	Needs []*GraphJob
+	Layer int
+}
+
+type graphNode struct {
+	job      *GraphJob // nil for dummy nodes
+	parents  []*graphNode
+	children []*graphNode
+}
+
+// barycenterSweeps is how many times the layers are reordered down and up.
+const barycenterSweeps = 8
+
+// NewGraph builds the dependency graph of a workflow file. Needs of jobs that
+// do not exist and needs closing a cycle are left out, Lint reports them.
+func NewGraph(content []byte) (*Graph, error) {
+	workflow, err := py.ParseWorkflow(content)
+	if err != nil {
+		return nil, err
+	}
+
+	graph := &Graph{}
+	jobs := make(map[string]*GraphJob)
+	for _, job := range workflow.Jobs {
+		graphJob := &GraphJob{ID: job.ID, Name: job.ID}
+		if name := py.Lookup(job.Node, "name"); name != nil && name.Kind == yaml.ScalarNode && name.Value != "" {
+			graphJob.Name = name.Value
+		}
+		graph.Jobs = append(graph.Jobs, graphJob)
+		jobs[job.ID] = graphJob
+	}
+
+	// Depth first search, the layer of a job is known once its needs are done
+	const (
+		unvisited = iota
+		onPath
+		done
+	)
+	state := make(map[string]int)
+	var visit func(job *py.Job)
+	visit = func(job *py.Job) {
+		state[job.ID] = onPath
+		graphJob := jobs[job.ID]
+
+		for _, need := range job.Needs {
+			next := workflow.Job(need.Value)
+			if next == nil || state[next.ID] == onPath || slices.Contains(graphJob.Needs, jobs[next.ID]) {
+				continue
+			}
+			if state[next.ID] == unvisited {
+				visit(next)
+			}
+			graphJob.Needs = append(graphJob.Needs, jobs[next.ID])
+			graphJob.Layer = max(graphJob.Layer, jobs[next.ID].Layer+1)
+		}
+
+		state[job.ID] = done
+	}
+	for _, job := range workflow.Jobs {
+		if state[job.ID] == unvisited {
+			visit(job)
+		}
+	}
+
+	graph.layout()
+	return graph, nil
+}
+
+// Job returns the job with the ID, nil when there is none.
+func (g *Graph) Job(id string) *GraphJob {
+	for _, job := range g.Jobs {
+		if job.ID == id {
+			return job
+		}
+	}
+	return nil
+}
+
+// JobOfRun returns the job of a job of a workflow run, which is named after
+// the job, followed by the values of its matrix, e.g. "test (ubuntu, 20)", or
+// by the jobs of the reusable workflow it calls, e.g. "deploy / apply". It
+// returns nil when no job has the name.
+func (g *Graph) JobOfRun(name string) *GraphJob {
+	var found *GraphJob
+	var foundLength int
+	for _, job := range g.Jobs {
+		for _, jobName := range []string{job.Name, job.ID} {
+			if name == jobName || strings.HasPrefix(name, jobName+" (") || strings.HasPrefix(name, jobName+" / ") {
+				// The longest name is the most specific, e.g. "build" and "build (arm)"
+				if len(jobName) > foundLength {
+					found, foundLength = job, len(jobName)
+				}
+			}
+		}
+	}
+	return found
+}
+
+// layout places the jobs in layers and orders the layers.
+func (g *Graph) layout() {
+	nodes := make(map[*GraphJob]*graphNode)
+	for _, job := range g.Jobs {
+		node := &graphNode{job: job}
+		nodes[job] = node
+		for len(g.nodes) <= job.Layer {
+			g.nodes = append(g.nodes, nil)
+		}
+		g.nodes[job.Layer] = append(g.nodes[job.Layer], node)
+	}
+
+	// Edges crossing layers go through a dummy node on each of them
+	for _, job := range g.Jobs {
+		for _, need := range job.Needs {
+			parent := nodes[need]
+			for layer := need.Layer + 1; layer < job.Layer; layer++ {
+				dummy := &graphNode{parents: []*graphNode{parent}}
+				parent.children = append(parent.children, dummy)
+				g.nodes[layer] = append(g.nodes[layer], dummy)
+				parent = dummy
+			}
+			parent.children = append(parent.children, nodes[job])
+			nodes[job].parents = append(nodes[job].parents, parent)
+		}
+	}
+
+	// Barycenter heuristic: a node moves to the mean position of its
+	// neighbours in the previous layer, the order with the least crossings wins
+	best, bestCrossings := g.cloneNodes(), g.crossings()
+	for sweep := 0; sweep < barycenterSweeps && bestCrossings > 0; sweep++ {
+		for layer := 1; layer < len(g.nodes); layer++ {
+			sortByBarycenter(g.nodes[layer], g.nodes[layer-1], func(n *graphNode) []*graphNode { return n.parents })
+		}
+		for layer := len(g.nodes) - 2; layer >= 0; layer-- {
+			sortByBarycenter(g.nodes[layer], g.nodes[layer+1], func(n *graphNode) []*graphNode { return n.children })
+		}
+		if crossings := g.crossings(); crossings < bestCrossings {
+			best, bestCrossings = g.cloneNodes(), crossings
+		}
+	}
+	g.nodes = best
+
+	g.Layers = make([][]*GraphJob, len(g.nodes))
+	for i, layer := range g.nodes {
+		for _, node := range layer {
+			if node.job != nil {
+				g.Layers[i] = append(g.Layers[i], node.job)
+			}
+		}
+	}
+}
+
+func (g *Graph) cloneNodes() [][]*graphNode {
+	clone := make([][]*graphNode, len(g.nodes))
+	for i, layer := range g.nodes {
+		clone[i] = slices.Clone(layer)
+	}
+	return clone
+}
+
+// crossings counts the pairs of edges crossing between adjacent layers.
+func (g *Graph) crossings() int {
+	var count int
+	for layer := 0; layer+1 < len(g.nodes); layer++ {
+		type edge struct{ from, to int }
+		var edges []edge
+		for from, node := range g.nodes[layer] {
+			for _, child := range node.children {
+				edges = append(edges, edge{from, slices.Index(g.nodes[layer+1], child)})
+			}
+		}
+		for i, a := range edges {
+			for _, b := range edges[i+1:] {
+				if (a.from-b.from)*(a.to-b.to) < 0 {
+					count++
+				}
+			}
+		}
+	}
+	return count
+}
+
+// sortByBarycenter orders the nodes of a layer by the mean position of their
+// neighbours in the adjacent layer, nodes without neighbours keep their place.
+func sortByBarycenter(layer []*graphNode, adjacent []*graphNode, neighbours func(*graphNode) []*graphNode) {
+	barycenters := make(map[*graphNode]float64, len(layer))
+	for i, node := range layer {
+		barycenters[node] = float64(i)
+		if len(neighbours(node)) == 0 {
+			continue
+		}
+		var sum float64
+		for _, neighbour := range neighbours(node) {
+			sum += float64(slices.Index(adjacent, neighbour))
+		}
+		barycenters[node] = sum / float64(len(neighbours(node)))
+	}
+	slices.SortStableFunc(layer, func(a, b *graphNode) int {
+		switch {
+		case barycenters[a] < barycenters[b]:
+			return -1
+		case barycenters[a] > barycenters[b]:
+			return 1
+		}
+		return 0
+	})
+}
+
+// GraphDrawing is a graph drawn from left to right with box-drawing characters.
+type GraphDrawing struct {
+	Lines  []string
+	Labels []GraphLabel // in the order of the jobs of the graph
+}
+
+// GraphLabel is where the label of a job is drawn, in runes of its line.
+type GraphLabel struct {
+	Job    *GraphJob
+	Line   int
+	Column int
+	Width  int
+}
+
+// Directions of the edges in a cell of a drawing
+const (
+	edgeUp = 1 << iota
+	edgeDown
+	edgeLeft
+	edgeRight
+	edgeArrow
+)
+
+var edgeRunes = map[int]rune{
+	edgeUp: '│', edgeDown: '│', edgeUp | edgeDown: '│',
+	edgeLeft: '─', edgeRight: '─', edgeLeft | edgeRight: '─',
+	edgeDown | edgeRight: '┌', edgeDown | edgeLeft: '┐', edgeUp | edgeRight: '└', edgeUp | edgeLeft: '┘',
+	edgeUp | edgeDown | edgeRight: '├', edgeUp | edgeDown | edgeLeft: '┤',
+	edgeLeft | edgeRight | edgeDown: '┬', edgeLeft | edgeRight | edgeUp: '┴',
+	edgeUp | edgeDown | edgeLeft | edgeRight: '┼',
+}
+
+// Draw draws the graph with the label of every job, its name when label is nil.
+// Labels are measured in runes, they should not contain wide characters.
+func (g *Graph) Draw(label func(job *GraphJob) string) *GraphDrawing {
+	if label == nil {
+		label = func(job *GraphJob) string { return job.Name }
+	}
+	labels := make(map[*GraphJob]string, len(g.Jobs))
+	for _, job := range g.Jobs {
+		labels[job] = label(job)
+	}
+
+	// Nodes are on every other row, layers centered on the largest one
+	rows := make(map[*graphNode]int)
+	var height int
+	for _, layer := range g.nodes {
+		height = max(height, 2*len(layer)-1)
+	}
+	for _, layer := range g.nodes {
+		offset := height/2 - (len(layer) - 1)
+		for i, node := range layer {
+			rows[node] = offset + 2*i
+		}
+	}
+
+	// A layer is a space, the labels and the space before the edges. Between
+	// layers, every node with edges to other rows gets its own vertical track.
+	type span struct{ start, width int }
+	var layers, gaps []span
+	var width int
+	for i, layer := range g.nodes {
+		var labelWidth int
+		for _, node := range layer {
+			if node.job != nil {
+				labelWidth = max(labelWidth, utf8.RuneCountInString(labels[node.job]))
+			}
+		}
+		layers = append(layers, span{width, labelWidth + 2})
+		width += labelWidth + 2
+
+		if i+1 < len(g.nodes) {
+			var tracks int
+			for _, node := range layer {
+				if hasTrack(node, rows) {
+					tracks++
+				}
+			}
+			gaps = append(gaps, span{width, 2*tracks + 2})
+			width += 2*tracks + 2
+		}
+	}
+
+	cells := make([][]int, height)
+	text := make([][]rune, height)
+	for row := range cells {
+		cells[row] = make([]int, width)
+		text[row] = []rune(strings.Repeat(" ", width))
+	}
+	horizontal := func(row int, from int, to int) {
+		for x := from; x <= to; x++ {
+			if x > from {
+				cells[row][x] |= edgeLeft
+			}
+			if x < to {
+				cells[row][x] |= edgeRight
+			}
+		}
+	}
+
+	drawing := &GraphDrawing{}
+	for i, layer := range g.nodes {
+		for _, node := range layer {
+			row, start, end := rows[node], layers[i].start, layers[i].start+layers[i].width-1
+			switch {
+			case node.job == nil:
+				horizontal(row, start-1, end+1)
+			default:
+				name := []rune(labels[node.job])
+				copy(text[row][start+1:], name)
+				drawing.Labels = append(drawing.Labels, GraphLabel{Job: node.job, Line: row, Column: start + 1, Width: len(name)})
+				if len(node.children) > 0 {
+					horizontal(row, start+len(name)+2, end+1)
+				}
+			}
+		}
+
+		if i == len(gaps) {
+			continue
+		}
+
+		gap := gaps[i]
+		track := gap.start + 1
+		for _, node := range layer {
+			row := rows[node]
+			if !hasTrack(node, rows) {
+				if len(node.children) > 0 {
+					horizontal(row, gap.start, gap.start+gap.width-1)
+				}
+				continue
+			}
+
+			horizontal(row, gap.start, track)
+			for _, child := range node.children {
+				childRow := rows[child]
+				for y := min(row, childRow); y <= max(row, childRow); y++ {
+					if y > min(row, childRow) {
+						cells[y][track] |= edgeUp
+					}
+					if y < max(row, childRow) {
+						cells[y][track] |= edgeDown
+					}
+				}
+				horizontal(childRow, track, gap.start+gap.width-1)
+			}
+			track += 2
+		}
+		for _, child := range g.nodes[i+1] {
+			if child.job != nil && len(child.parents) > 0 {
+				cells[rows[child]][gap.start+gap.width-1] |= edgeArrow
+			}
+		}
+	}
+
+	for row := range cells {
+		for x, cell := range cells[row] {
+			switch {
+			case cell&edgeArrow != 0:
+				text[row][x] = '▶'
+			case cell != 0:
+				text[row][x] = edgeRunes[cell]
+			}
+		}
+		drawing.Lines = append(drawing.Lines, strings.TrimRight(string(text[row]), " "))
+	}
+
+	slices.SortStableFunc(drawing.Labels, func(a, b GraphLabel) int {
+		return slices.Index(g.Jobs, a.Job) - slices.Index(g.Jobs, b.Job)
+	})
+	return drawing
+}
+
+// hasTrack tells whether a node has edges to other rows.
+func hasTrack(node *graphNode, rows map[*graphNode]int) bool {
+	return slices.ContainsFunc(node.children, func(child *graphNode) bool {
+		return rows[child] != rows[node]
+	})
+}
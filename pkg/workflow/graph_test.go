@@ -0,0 +1,99 @@
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewGraph(t *testing.T) {
+	var data = []byte(`on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+  lint:
+    runs-on: ubuntu-latest
+  test:
+    needs: build
+    runs-on: ubuntu-latest
+  deploy:
+    name: Deploy
+    needs: [test, lint, missing]
+    runs-on: ubuntu-latest
+  a:
+    needs: b
+  b:
+    needs: a
+`)
+
+	graph, err := NewGraph(data)
+	assert.NoError(t, err)
+
+	var ids [][]string
+	for _, layer := range graph.Layers {
+		var layerIDs []string
+		for _, job := range layer {
+			layerIDs = append(layerIDs, job.ID)
+		}
+		ids = append(ids, layerIDs)
+	}
+	assert.Equal(t, [][]string{{"build", "lint", "b"}, {"test", "a"}, {"deploy"}}, ids)
+
+	deploy := graph.Job("deploy")
+	assert.Equal(t, "Deploy", deploy.Name)
+	assert.Equal(t, []*GraphJob{graph.Job("test"), graph.Job("lint")}, deploy.Needs)
+	assert.Empty(t, graph.Job("b").Needs, "the need closing the cycle is left out")
+	assert.Nil(t, graph.Job("missing"))
+
+	_, err = NewGraph([]byte("jobs: [\n"))
+	assert.Error(t, err)
+}
+
+func TestGraph_JobOfRun(t *testing.T) {
+	graph, err := NewGraph([]byte(`jobs:
+  build:
+    name: Build
+  build-arm:
+    name: Build (arm)
+  deploy:
+    uses: ./.github/workflows/deploy.yml
+`))
+	assert.NoError(t, err)
+
+	assert.Equal(t, graph.Job("build"), graph.JobOfRun("Build"))
+	assert.Equal(t, graph.Job("build"), graph.JobOfRun("Build (ubuntu-latest, 20)"))
+	assert.Equal(t, graph.Job("build-arm"), graph.JobOfRun("Build (arm)"))
+	assert.Equal(t, graph.Job("deploy"), graph.JobOfRun("deploy / apply"))
+	assert.Nil(t, graph.JobOfRun("Lint"))
+}
+
+func TestGraph_Draw(t *testing.T) {
+	graph, err := NewGraph([]byte(`jobs:
+  plan: {}
+  apply:
+    needs: plan
+  notify:
+    name: Notify team
+    needs: [plan, apply]
+`))
+	assert.NoError(t, err)
+
+	drawing := graph.Draw(nil)
+	assert.Equal(t, []string{
+		"       ┌─▶ apply ─┐",
+		" plan ─┤          └─┬─▶ Notify team",
+		"       └────────────┘",
+	}, drawing.Lines)
+	assert.Equal(t, []GraphLabel{
+		{Job: graph.Job("plan"), Line: 1, Column: 1, Width: 4},
+		{Job: graph.Job("apply"), Line: 0, Column: 11, Width: 5},
+		{Job: graph.Job("notify"), Line: 1, Column: 24, Width: 11},
+	}, drawing.Labels)
+
+	drawing = graph.Draw(func(job *GraphJob) string { return "✓ " + job.ID })
+	assert.Equal(t, []string{
+		"         ┌─▶ ✓ apply ─┐",
+		" ✓ plan ─┤            └─┬─▶ ✓ notify",
+		"         └──────────────┘",
+	}, drawing.Lines)
+}
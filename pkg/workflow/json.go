@@ -0,0 +1,134 @@
+package workflow
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// flattenJSON walks a decoded JSON tree and calls add for every leaf with its
+// path, where object keys are strings and array indexes are ints. Empty
+// objects and arrays are leaves too, so that they are not lost.
+func flattenJSON(path []any, node any, add func(path []any, valueType string, value string)) {
+	switch node := node.(type) {
+	case map[string]any:
+		if len(node) == 0 {
+			add(path, "object", "{}")
+			return
+		}
+		keys := make([]string, 0, len(node))
+		for key := range node {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+		for _, key := range keys {
+			flattenJSON(appendPath(path, key), node[key], add)
+		}
+	case []any:
+		if len(node) == 0 {
+			add(path, "array", "[]")
+			return
+		}
+		for index, item := range node {
+			flattenJSON(appendPath(path, index), item, add)
+		}
+	case string:
+		add(path, "string", node)
+	case json.Number:
+		add(path, "number", node.String())
+	case bool:
+		add(path, "boolean", strconv.FormatBool(node))
+	case nil:
+		add(path, "null", "null")
+	}
+}
+
+func appendPath(path []any, element any) []any {
+	return append(slices.Clone(path), element)
+}
+
+// formatPath returns the dotted form of a path, e.g. services.0.image.
+func formatPath(path []any) string {
+	elements := make([]string, len(path))
+	for i, element := range path {
+		elements[i] = fmt.Sprint(element)
+	}
+	return strings.Join(elements, ".")
+}
+
+// setPath sets value at path below node, creating the objects and arrays on the way.
+func setPath(node any, path []any, value any) any {
+	if len(path) == 0 {
+		return value
+	}
+
+	switch element := path[0].(type) {
+	case string:
+		object, _ := node.(map[string]any)
+		if object == nil {
+			object = make(map[string]any)
+		}
+		object[element] = setPath(object[element], path[1:], value)
+		return object
+	case int:
+		array, _ := node.([]any)
+		for len(array) <= element {
+			array = append(array, nil)
+		}
+		array[element] = setPath(array[element], path[1:], value)
+		return array
+	}
+	return node
+}
+
+// convertKeyValue converts the value of a JSON leaf back to the type it had in the default.
+func convertKeyValue(kv PrettyKeyValue) (any, error) {
+	value := kv.Value
+	if value == "" && kv.Type != "string" {
+		value = kv.Default
+	}
+
+	switch kv.Type {
+	case "number":
+		if !isNumber(value) {
+			return nil, fmt.Errorf("%s: %q is not a number", kv.FullKey(), value)
+		}
+		return json.Number(value), nil
+	case "boolean":
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %q is not a boolean", kv.FullKey(), value)
+		}
+		return parsed, nil
+	case "null":
+		// Anything typed over a null is taken as JSON, or as a string when it is not JSON
+		if parsed, ok := decodeJSON(value); ok {
+			return parsed, nil
+		}
+		return value, nil
+	case "object", "array":
+		parsed, ok := decodeJSON(value)
+		if !ok {
+			return nil, fmt.Errorf("%s: %q is not valid JSON", kv.FullKey(), value)
+		}
+		return parsed, nil
+	}
+	return value, nil
+}
+
+func decodeJSON(value string) (any, bool) {
+	decoder := json.NewDecoder(strings.NewReader(value))
+	decoder.UseNumber()
+
+	var parsed any
+	if err := decoder.Decode(&parsed); err != nil {
+		return nil, false
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, false
+	}
+	return parsed, true
+}
@@ -0,0 +1,341 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	py "github.com/termkit/gama/pkg/yaml"
+	"gopkg.in/yaml.v3"
+)
+
+// Severity is how serious a finding is.
+type Severity int
+
+const (
+	SeverityInfo Severity = iota
+	SeverityWarning
+	SeverityError
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	}
+	return "info"
+}
+
+// Finding is a problem found in a workflow file. Line and Column start at 1.
+type Finding struct {
+	Rule     string
+	Severity Severity
+	Line     int
+	Column   int
+	Message  string
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("%d:%d: %s: %s [%s]", f.Line, f.Column, f.Severity, f.Message, f.Rule)
+}
+
+// Lint rules
+const (
+	RuleSyntax        = "syntax"
+	RuleUnknownKey    = "unknown-key"
+	RuleRequiredKey   = "required-key"
+	RuleRunsOn        = "runs-on"
+	RuleNeeds         = "needs"
+	RuleNeedsCycle    = "needs-cycle"
+	RuleExpression    = "expression"
+	RuleInputType     = "input-type"
+	RuleInputDefault  = "input-default"
+	RuleChoiceOptions = "choice-options"
+)
+
+var (
+	workflowKeys = []string{"name", "run-name", "on", "permissions", "env", "defaults", "concurrency", "jobs"}
+	jobKeys      = []string{"name", "needs", "permissions", "runs-on", "environment", "concurrency", "outputs", "env", "defaults",
+		"if", "steps", "timeout-minutes", "strategy", "continue-on-error", "container", "services", "uses", "with", "secrets"}
+
+	inputTypes = map[string][]string{
+		"workflow_dispatch": {"string", "number", "boolean", "choice", "environment"},
+		"workflow_call":     {"string", "number", "boolean"},
+	}
+
+	syntaxErrorLine = regexp.MustCompile(`^line (\d+): `)
+)
+
+// Lint checks a workflow file without running it and returns the findings
+// ordered by their position, leaving out the ones suppressed by comments.
+func Lint(content []byte) []Finding {
+	workflow, err := py.ParseWorkflow(content)
+	if err != nil {
+		return []Finding{syntaxFinding(err)}
+	}
+
+	l := &linter{lines: strings.Split(string(content), "\n")}
+	l.keys(workflow)
+	l.jobs(workflow)
+	l.needs(workflow)
+	l.expressions(workflow.Node)
+	l.inputs(workflow, "workflow_dispatch")
+	l.inputs(workflow, "workflow_call")
+
+	sortFindings(l.findings)
+	return suppress(l.findings, l.lines)
+}
+
+// HasErrors reports whether any finding is an error.
+func HasErrors(findings []Finding) bool {
+	return slices.ContainsFunc(findings, func(f Finding) bool {
+		return f.Severity == SeverityError
+	})
+}
+
+func sortFindings(findings []Finding) {
+	slices.SortStableFunc(findings, func(a, b Finding) int {
+		if a.Line != b.Line {
+			return a.Line - b.Line
+		}
+		return a.Column - b.Column
+	})
+}
+
+func syntaxFinding(err error) Finding {
+	finding := Finding{Rule: RuleSyntax, Severity: SeverityError, Line: 1, Column: 1, Message: strings.TrimPrefix(err.Error(), "yaml: ")}
+
+	// The line is part of the message of YAML errors, e.g. line 3: did not find expected node content
+	if match := syntaxErrorLine.FindStringSubmatch(finding.Message); match != nil {
+		finding.Line, _ = strconv.Atoi(match[1])
+		finding.Message = finding.Message[len(match[0]):]
+	}
+	return finding
+}
+
+type linter struct {
+	lines    []string
+	findings []Finding
+}
+
+func (l *linter) report(node *yaml.Node, rule string, message string) {
+	l.findings = append(l.findings, Finding{Rule: rule, Severity: SeverityError, Line: node.Line, Column: node.Column, Message: message})
+}
+
+func (l *linter) keys(workflow *py.Workflow) {
+	for _, pair := range py.Pairs(workflow.Node) {
+		if !slices.Contains(workflowKeys, pair.Key.Value) {
+			l.report(pair.Key, RuleUnknownKey, fmt.Sprintf("unknown key %q, expected one of %s", pair.Key.Value, strings.Join(workflowKeys, ", ")))
+		}
+	}
+
+	for _, required := range []string{"on", "jobs"} {
+		if py.Lookup(workflow.Node, required) == nil {
+			l.report(workflow.Node, RuleRequiredKey, fmt.Sprintf("%q section is missing", required))
+		}
+	}
+}
+
+func (l *linter) jobs(workflow *py.Workflow) {
+	if jobs := py.Lookup(workflow.Node, "jobs"); jobs != nil && jobs.Kind != yaml.MappingNode {
+		l.report(jobs, RuleSyntax, "jobs must be a mapping of job IDs to jobs")
+	}
+
+	for _, job := range workflow.Jobs {
+		if job.Node.Kind != yaml.MappingNode {
+			l.report(job.Key, RuleSyntax, fmt.Sprintf("job %q must be a mapping", job.ID))
+			continue
+		}
+
+		for _, pair := range py.Pairs(job.Node) {
+			if !slices.Contains(jobKeys, pair.Key.Value) {
+				l.report(pair.Key, RuleUnknownKey, fmt.Sprintf("unknown key %q in job %q", pair.Key.Value, job.ID))
+			}
+		}
+
+		if job.RunsOn == nil && job.Uses == nil {
+			l.report(job.Key, RuleRunsOn, fmt.Sprintf("job %q has no runs-on", job.ID))
+		}
+	}
+}
+
+func (l *linter) needs(workflow *py.Workflow) {
+	for _, job := range workflow.Jobs {
+		for _, need := range job.Needs {
+			if workflow.Job(need.Value) == nil {
+				l.report(need, RuleNeeds, fmt.Sprintf("job %q needs %q, which does not exist", job.ID, need.Value))
+			}
+		}
+	}
+
+	// Depth first search, an edge to a job on the path closes a cycle
+	const (
+		unvisited = iota
+		onPath
+		done
+	)
+	state := make(map[string]int)
+	var path []string
+
+	var visit func(job *py.Job)
+	visit = func(job *py.Job) {
+		state[job.ID] = onPath
+		path = append(path, job.ID)
+
+		for _, need := range job.Needs {
+			next := workflow.Job(need.Value)
+			switch {
+			case next == nil:
+			case state[next.ID] == onPath:
+				cycle := append(slices.Clone(path[slices.Index(path, next.ID):]), next.ID)
+				l.report(need, RuleNeedsCycle, "dependency cycle: "+strings.Join(cycle, " → "))
+			case state[next.ID] == unvisited:
+				visit(next)
+			}
+		}
+
+		path = path[:len(path)-1]
+		state[job.ID] = done
+	}
+
+	for _, job := range workflow.Jobs {
+		if state[job.ID] == unvisited {
+			visit(job)
+		}
+	}
+}
+
+// expressions checks the syntax of the ${{ }} expressions in every value,
+// and of the if: conditions which may be written without braces.
+func (l *linter) expressions(node *yaml.Node) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			l.expressions(key)
+			if key.Value == "if" && value.Kind == yaml.ScalarNode && !strings.Contains(value.Value, "${{") {
+				if _, err := parseExpression(value.Value); err != nil {
+					l.expressionError(value, 0, err)
+				}
+				continue
+			}
+			l.expressions(value)
+		}
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			l.expressions(item)
+		}
+	case yaml.ScalarNode:
+		for _, expression := range findExpressions(node.Value) {
+			if !expression.Closed {
+				l.expressionError(node, expression.Offset, ExpressionError{Message: "expression is not closed with }}"})
+				continue
+			}
+			if _, err := parseExpression(expression.Source); err != nil {
+				l.expressionError(node, expression.Offset+3, err)
+			}
+		}
+	}
+}
+
+func (l *linter) expressionError(node *yaml.Node, offset int, err error) {
+	var expressionError ExpressionError
+	if errors.As(err, &expressionError) {
+		offset += expressionError.Offset
+	}
+
+	line, column := scalarPosition(l.lines, node, offset)
+	l.findings = append(l.findings, Finding{
+		Rule:     RuleExpression,
+		Severity: SeverityError,
+		Line:     line,
+		Column:   column,
+		Message:  "invalid expression: " + err.Error(),
+	})
+}
+
+// scalarPosition returns where the byte at offset of the value of a scalar is in the file.
+// Quoted and folded values don't keep the bytes of the file, they are looked up
+// in the line instead.
+func scalarPosition(lines []string, node *yaml.Node, offset int) (int, int) {
+	before := node.Value[:min(offset, len(node.Value))]
+	line := node.Line + strings.Count(before, "\n")
+	if node.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0 {
+		line++ // the value starts below the indicator
+	}
+	if node.Style == 0 && line == node.Line {
+		return line, node.Column + offset
+	}
+
+	// Look the rest of the line up from the offset in the file
+	if line-1 < len(lines) {
+		rest := node.Value[len(before):]
+		if end := strings.IndexByte(rest, '\n'); end >= 0 {
+			rest = rest[:end]
+		}
+		lastLine := before[strings.LastIndexByte(before, '\n')+1:]
+		if index := strings.Index(lines[line-1], lastLine+rest); index >= 0 && lastLine+rest != "" {
+			return line, index + len(lastLine) + 1
+		}
+	}
+	return line, node.Column
+}
+
+func (l *linter) inputs(workflow *py.Workflow, event string) {
+	types := inputTypes[event]
+
+	for _, input := range workflow.Inputs(event) {
+		if input.Node == nil {
+			continue
+		}
+
+		inputType := "string"
+		if input.Type != nil {
+			inputType = input.Type.Value
+		}
+		switch {
+		case input.Type == nil && event == "workflow_call":
+			l.report(input.Key, RuleInputType, fmt.Sprintf("input %q of workflow_call has no type", input.Name))
+			continue
+		case !slices.Contains(types, inputType):
+			l.report(input.Type, RuleInputType, fmt.Sprintf("input %q has unknown type %q, expected one of %s", input.Name, inputType, strings.Join(types, ", ")))
+			continue
+		}
+
+		if inputType == "choice" {
+			l.choice(input)
+			continue
+		}
+
+		if py.IsNull(input.Default) || input.Default.Kind != yaml.ScalarNode || strings.Contains(input.Default.Value, "${{") {
+			continue
+		}
+		switch value := input.Default.Value; {
+		case inputType == "boolean" && value != "true" && value != "false":
+			l.report(input.Default, RuleInputDefault, fmt.Sprintf("default %q of boolean input %q must be true or false", value, input.Name))
+		case inputType == "number" && !isNumber(value):
+			l.report(input.Default, RuleInputDefault, fmt.Sprintf("default %q of number input %q is not a number", value, input.Name))
+		}
+	}
+}
+
+func (l *linter) choice(input py.Input) {
+	if input.Options == nil || input.Options.Kind != yaml.SequenceNode || len(input.Options.Content) == 0 {
+		l.report(input.Key, RuleChoiceOptions, fmt.Sprintf("choice input %q has no options", input.Name))
+		return
+	}
+
+	var options []string
+	for _, option := range input.Options.Content {
+		options = append(options, py.Resolve(option).Value)
+	}
+
+	if !py.IsNull(input.Default) && !slices.Contains(options, input.Default.Value) {
+		l.report(input.Default, RuleChoiceOptions, fmt.Sprintf("default %q of choice input %q is not one of its options", input.Default.Value, input.Name))
+	}
+}
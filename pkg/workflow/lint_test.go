@@ -0,0 +1,145 @@
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLint(t *testing.T) {
+	var data = []byte(`name: Deploy
+on:
+  workflow_dispatch:
+    inputs:
+      region:
+        type: choice
+        options: [eu, us]
+        default: asia
+      replicas:
+        type: number
+        default: three
+      dry_run:
+        type: boolean
+        default: "yes"
+      zone:
+        type: choice
+      target:
+        type: text
+  workflow_call:
+    inputs:
+      version:
+        default: v1
+triggers: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    timeout: 10
+    steps:
+      - run: echo ${{ inputs.region = 'eu' }}
+      - run: |
+          echo one
+          echo ${{ github.ref == 'main }}
+  test:
+    needs: [build, lint]
+    if: success() &&
+    steps:
+      - run: make test
+  deploy:
+    needs: [test, release]
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo "${{ github.sha"
+  release:
+    needs: deploy
+    uses: ./.github/workflows/release.yml
+`)
+
+	assert.Equal(t, []Finding{
+		{Rule: RuleChoiceOptions, Severity: SeverityError, Line: 8, Column: 18, Message: `default "asia" of choice input "region" is not one of its options`},
+		{Rule: RuleInputDefault, Severity: SeverityError, Line: 11, Column: 18, Message: `default "three" of number input "replicas" is not a number`},
+		{Rule: RuleInputDefault, Severity: SeverityError, Line: 14, Column: 18, Message: `default "yes" of boolean input "dry_run" must be true or false`},
+		{Rule: RuleChoiceOptions, Severity: SeverityError, Line: 15, Column: 7, Message: `choice input "zone" has no options`},
+		{Rule: RuleInputType, Severity: SeverityError, Line: 18, Column: 15, Message: `input "target" has unknown type "text", expected one of string, number, boolean, choice, environment`},
+		{Rule: RuleInputType, Severity: SeverityError, Line: 21, Column: 7, Message: `input "version" of workflow_call has no type`},
+		{Rule: RuleUnknownKey, Severity: SeverityError, Line: 23, Column: 1, Message: `unknown key "triggers", expected one of name, run-name, on, permissions, env, defaults, concurrency, jobs`},
+		{Rule: RuleUnknownKey, Severity: SeverityError, Line: 27, Column: 5, Message: `unknown key "timeout" in job "build"`},
+		{Rule: RuleExpression, Severity: SeverityError, Line: 29, Column: 37, Message: `invalid expression: unknown operator '=', use "=="`},
+		{Rule: RuleExpression, Severity: SeverityError, Line: 32, Column: 16, Message: `invalid expression: expression is not closed with }}`},
+		{Rule: RuleRunsOn, Severity: SeverityError, Line: 33, Column: 3, Message: `job "test" has no runs-on`},
+		{Rule: RuleNeeds, Severity: SeverityError, Line: 34, Column: 20, Message: `job "test" needs "lint", which does not exist`},
+		{Rule: RuleExpression, Severity: SeverityError, Line: 35, Column: 21, Message: `invalid expression: expression ends unexpectedly`},
+		{Rule: RuleExpression, Severity: SeverityError, Line: 42, Column: 20, Message: `invalid expression: expression is not closed with }}`},
+		{Rule: RuleNeedsCycle, Severity: SeverityError, Line: 44, Column: 12, Message: `dependency cycle: deploy → release → deploy`},
+	}, Lint(data))
+}
+
+func TestLint_Valid(t *testing.T) {
+	var data = []byte(`name: CI
+on:
+  push:
+  workflow_dispatch:
+    inputs:
+      level:
+        type: choice
+        options: [info, debug]
+        default: info
+      count:
+        type: number
+        default: 2
+      verbose:
+        type: boolean
+        default: false
+permissions:
+  contents: read
+jobs:
+  build:
+    runs-on: ${{ matrix.os }}
+    if: github.event_name == 'push' || inputs.verbose
+    strategy:
+      matrix:
+        os: [ubuntu-latest, macos-latest]
+    steps:
+      - uses: actions/checkout@v4
+      - run: make LEVEL=${{ inputs.level }} COUNT=${{ inputs.count }}
+  deploy:
+    needs: build
+    uses: ./.github/workflows/deploy.yml
+    secrets: inherit
+`)
+
+	assert.Empty(t, Lint(data))
+}
+
+func TestLint_Syntax(t *testing.T) {
+	findings := Lint([]byte("on: push\njobs:\n  build: [\n"))
+	assert.Len(t, findings, 1)
+	assert.Equal(t, RuleSyntax, findings[0].Rule)
+	assert.Equal(t, 3, findings[0].Line)
+	assert.True(t, HasErrors(findings))
+
+	assert.Equal(t, []Finding{
+		{Rule: RuleSyntax, Severity: SeverityError, Line: 1, Column: 1, Message: "workflow must be a mapping"},
+	}, Lint([]byte("- push\n")))
+
+	assert.Equal(t, []Finding{
+		{Rule: RuleRequiredKey, Severity: SeverityError, Line: 1, Column: 1, Message: `"jobs" section is missing`},
+	}, Lint([]byte("on: push\n")))
+}
+
+func TestFinding_String(t *testing.T) {
+	finding := Finding{Rule: RuleNeeds, Severity: SeverityError, Line: 3, Column: 12, Message: "job \"test\" needs \"lint\", which does not exist"}
+	assert.Equal(t, `3:12: error: job "test" needs "lint", which does not exist [needs]`, finding.String())
+}
+
+func TestLint_Suppressed(t *testing.T) {
+	var data = []byte(`on: push
+x-templates: {} # gama:ignore unknown-key
+jobs:
+  build:
+    # gama:ignore
+    needs: generated
+    runs-on: ubuntu-latest
+`)
+
+	assert.Empty(t, Lint(data))
+}
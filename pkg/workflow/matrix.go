@@ -0,0 +1,357 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	py "github.com/termkit/gama/pkg/yaml"
+	"gopkg.in/yaml.v3"
+)
+
+// maxMatrixJobs is the number of jobs a matrix can generate at most.
+const maxMatrixJobs = 256
+
+// MatrixValue is a key of a combination of a matrix with its value: nil, a
+// bool, a float64, a string, a []any or a map[string]any.
+type MatrixValue struct {
+	Key   string
+	Value any
+}
+
+// MatrixCombination is a job of a matrix. The values are in the order of the
+// axes, then of the keys added by include.
+type MatrixCombination []MatrixValue
+
+// Get returns the value of a key and whether the combination has it.
+func (c MatrixCombination) Get(key string) (any, bool) {
+	for _, value := range c {
+		if value.Key == key {
+			return value.Value, true
+		}
+	}
+	return nil, false
+}
+
+// Values returns the values as they are shown in job names.
+func (c MatrixCombination) Values() []string {
+	values := make([]string, len(c))
+	for i, value := range c {
+		values[i] = formatMatrixValue(value.Value)
+	}
+	return values
+}
+
+// String returns the values joined like GitHub does in job names, e.g. ubuntu-latest, 20.
+func (c MatrixCombination) String() string {
+	return strings.Join(c.Values(), ", ")
+}
+
+func formatMatrixValue(value any) string {
+	switch value.(type) {
+	case map[string]any, []any:
+		var b strings.Builder
+		writeJSON(&b, normalizeValue(value), "")
+		return b.String()
+	}
+	return toString(value)
+}
+
+// ExpandedJob is a job of a workflow with the jobs a run of the workflow
+// will have for it, one per combination of its matrix.
+type ExpandedJob struct {
+	ID           string
+	Name         string              // name of the job, its ID when it has no name
+	Matrix       bool                // whether the job has a strategy.matrix
+	Combinations []MatrixCombination // nil without a matrix
+	Names        []string            // names of the jobs of a run
+	Err          error               // the matrix cannot be expanded, e.g. it depends on the outputs of other jobs
+}
+
+// ExpandJobs expands the matrices of the jobs of a workflow file, in the order
+// of the jobs. inputs are the values of the workflow_dispatch inputs, like
+// pw.Pretty.ToPayload returns them, missing inputs get their default. The
+// expansion of a job failing is reported in its Err.
+func ExpandJobs(content []byte, inputs map[string]any) ([]ExpandedJob, error) {
+	workflow, err := py.ParseWorkflow(content)
+	if err != nil {
+		return nil, err
+	}
+
+	values := dispatchDefaults(workflow)
+	for key, value := range inputs {
+		values[key] = value
+	}
+	ev := newDispatchEvaluator(values)
+
+	var jobs []ExpandedJob
+	for _, job := range workflow.Jobs {
+		expanded := ExpandedJob{ID: job.ID, Name: job.ID}
+		name := py.Lookup(job.Node, "name")
+		if name != nil && name.Kind == yaml.ScalarNode && name.Value != "" {
+			expanded.Name = name.Value
+		}
+
+		matrix := matrixNode(job)
+		if matrix == nil {
+			expanded.Names = []string{jobName(ev, expanded.Name, nil)}
+			jobs = append(jobs, expanded)
+			continue
+		}
+
+		expanded.Matrix = true
+		expanded.Combinations, expanded.Err = ev.matrix(matrix)
+		if expanded.Err == nil && len(expanded.Combinations) > maxMatrixJobs {
+			expanded.Err = fmt.Errorf("line %d: matrix generates %d jobs, at most %d are allowed", matrix.Line, len(expanded.Combinations), maxMatrixJobs)
+		}
+		if expanded.Err != nil {
+			expanded.Combinations = nil
+		}
+		for _, combination := range expanded.Combinations {
+			expanded.Names = append(expanded.Names, jobName(ev, expanded.Name, combination))
+		}
+		jobs = append(jobs, expanded)
+	}
+	return jobs, nil
+}
+
+// dispatchDefaults returns the defaults of the workflow_dispatch inputs typed
+// like the inputs context has them.
+func dispatchDefaults(workflow *py.Workflow) map[string]any {
+	defaults := make(map[string]any)
+	for _, input := range workflow.Inputs("workflow_dispatch") {
+		var value string
+		if input.Default != nil && input.Default.Kind == yaml.ScalarNode {
+			value = input.Default.Value
+		}
+		var inputType string
+		if input.Type != nil {
+			inputType = input.Type.Value
+		}
+
+		switch inputType {
+		case "boolean":
+			defaults[input.Name] = value == "true"
+		case "number":
+			if f, ok := parseNumber(value); ok {
+				defaults[input.Name] = f
+			} else {
+				defaults[input.Name] = nil
+			}
+		default:
+			defaults[input.Name] = value
+		}
+	}
+	return defaults
+}
+
+func matrixNode(job *py.Job) *yaml.Node {
+	matrix := py.Lookup(job.Strategy, "matrix")
+	if py.IsNull(matrix) {
+		return nil
+	}
+	return matrix
+}
+
+// jobName returns the name of a job of a run. GitHub appends the values of the
+// combination unless the name uses the matrix context.
+func jobName(ev *evaluator, name string, combination MatrixCombination) string {
+	usesMatrix := false
+	for _, expression := range findExpressions(name) {
+		usesMatrix = usesMatrix || strings.Contains(strings.ToLower(expression.Source), "matrix")
+	}
+
+	if strings.Contains(name, "${{") {
+		matrix := newObject()
+		for _, value := range combination {
+			matrix.set(value.Key, normalizeValue(value.Value))
+		}
+		if value, err := ev.with("matrix", matrix).interpolate(name); err == nil {
+			name = toString(value)
+		}
+	}
+	if combination == nil || usesMatrix || len(combination) == 0 {
+		return name
+	}
+	return fmt.Sprintf("%s (%s)", name, combination)
+}
+
+// matrix expands a strategy.matrix like GitHub does: the combinations of the
+// axes, in the order of the axes and of their values, less the combinations
+// matching an entry of exclude, then include. Each entry of include is added
+// to every combination whose axes it doesn't change, where it may overwrite
+// the values added by the previous entries. An entry that matches no
+// combination is a combination of its own.
+func (ev *evaluator) matrix(node *yaml.Node) ([]MatrixCombination, error) {
+	value, err := ev.node(node)
+	if err != nil {
+		return nil, err
+	}
+	matrix, ok := value.(*object)
+	if !ok {
+		return nil, fmt.Errorf("line %d: matrix must be a mapping", node.Line)
+	}
+
+	var axes []string
+	var include, exclude []*object
+	combinations := []MatrixCombination{{}}
+	for _, key := range matrix.keys {
+		value := matrix.values[key]
+		switch key {
+		case "include", "exclude":
+			entries, err := matrixEntries(key, value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", node.Line, err)
+			}
+			if key == "include" {
+				include = entries
+			} else {
+				exclude = entries
+			}
+			continue
+		}
+
+		items, ok := asArray(value)
+		if !ok {
+			return nil, fmt.Errorf("line %d: matrix value %q must be a list", node.Line, key)
+		}
+		if len(items) == 0 {
+			return nil, fmt.Errorf("line %d: matrix value %q does not contain any values", node.Line, key)
+		}
+		axes = append(axes, key)
+
+		var next []MatrixCombination
+		for _, combination := range combinations {
+			for _, item := range items {
+				next = append(next, append(combination[:len(combination):len(combination)], MatrixValue{Key: key, Value: plainValue(item)}))
+			}
+		}
+		combinations = next
+	}
+	if len(axes) == 0 {
+		combinations = nil
+	}
+
+	for _, entry := range exclude {
+		for _, key := range entry.keys {
+			if !containsKey(axes, key) {
+				return nil, fmt.Errorf("line %d: exclude key %q does not match any key of the matrix", node.Line, key)
+			}
+		}
+		kept := combinations[:0]
+		for _, combination := range combinations {
+			if !matchesEntry(combination, entry, entry.keys) {
+				kept = append(kept, combination)
+			}
+		}
+		combinations = kept
+	}
+
+	original := len(combinations) // include only extends the combinations of the axes
+	for _, entry := range include {
+		matched := false
+		for i := 0; i < original; i++ {
+			var entryAxes []string
+			for _, key := range entry.keys {
+				if containsKey(axes, key) {
+					entryAxes = append(entryAxes, key)
+				}
+			}
+			if !matchesEntry(combinations[i], entry, entryAxes) {
+				continue
+			}
+
+			matched = true
+			combination := combinations[i]
+			for _, key := range entry.keys {
+				value := plainValue(entry.values[key])
+				if index := indexOfKey(combination, key); index >= 0 {
+					combination[index].Value = value // an axis keeps its value, it matched
+				} else {
+					combination = append(combination, MatrixValue{Key: key, Value: value})
+				}
+			}
+			combinations[i] = combination
+		}
+
+		if !matched {
+			var combination MatrixCombination
+			for _, key := range entry.keys {
+				combination = append(combination, MatrixValue{Key: key, Value: plainValue(entry.values[key])})
+			}
+			combinations = append(combinations, combination)
+		}
+	}
+
+	if len(combinations) == 0 {
+		return nil, fmt.Errorf("line %d: matrix does not generate any job", node.Line)
+	}
+	return combinations, nil
+}
+
+func matrixEntries(key string, value any) ([]*object, error) {
+	items, ok := asArray(value)
+	if !ok {
+		return nil, fmt.Errorf("%s must be a list of mappings", key)
+	}
+	entries := make([]*object, 0, len(items))
+	for _, item := range items {
+		entry, ok := item.(*object)
+		if !ok {
+			return nil, fmt.Errorf("%s must be a list of mappings", key)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// matchesEntry reports whether the combination has the values of the entry for the keys.
+func matchesEntry(combination MatrixCombination, entry *object, keys []string) bool {
+	for _, key := range keys {
+		value, ok := combination.Get(key)
+		if !ok || !reflect.DeepEqual(value, plainValue(entry.values[key])) {
+			return false
+		}
+	}
+	return true
+}
+
+func containsKey(keys []string, key string) bool {
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+func indexOfKey(combination MatrixCombination, key string) int {
+	for i, value := range combination {
+		if value.Key == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// IsDynamic reports whether the job cannot be expanded because its matrix
+// depends on what is only known when the workflow runs.
+func (j ExpandedJob) IsDynamic() bool {
+	var dynamic DynamicError
+	return errors.As(j.Err, &dynamic)
+}
+
+// ParseMatrixJobName splits the name of a job of a run into the name of its
+// job and the values of its combination, e.g. "Build (ubuntu-latest, 20)".
+// ok is false for a name without values.
+func ParseMatrixJobName(name string) (job string, values []string, ok bool) {
+	if !strings.HasSuffix(name, ")") {
+		return name, nil, false
+	}
+	open := strings.LastIndex(name, " (")
+	if open < 0 {
+		return name, nil, false
+	}
+	return name[:open], strings.Split(name[open+2:len(name)-1], ", "), true
+}
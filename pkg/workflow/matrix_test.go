@@ -0,0 +1,239 @@
+package workflow
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func expandedNames(t *testing.T, content string, inputs map[string]any) map[string][]string {
+	jobs, err := ExpandJobs([]byte(content), inputs)
+	assert.NoError(t, err)
+
+	names := make(map[string][]string)
+	for _, job := range jobs {
+		assert.NoError(t, job.Err, job.ID)
+		names[job.ID] = job.Names
+	}
+	return names
+}
+
+func TestExpandJobs_Include(t *testing.T) {
+	// The example of the GitHub documentation on expanding or adding matrix configurations
+	names := expandedNames(t, `on: push
+jobs:
+  example:
+    strategy:
+      matrix:
+        fruit: [apple, pear]
+        animal: [cat, dog]
+        include:
+          - color: green
+          - color: pink
+            animal: cat
+          - fruit: apple
+            shape: circle
+          - fruit: banana
+          - fruit: banana
+            animal: cat
+`, nil)
+
+	assert.Equal(t, []string{
+		"example (apple, cat, pink, circle)",
+		"example (apple, dog, green, circle)",
+		"example (pear, cat, pink)",
+		"example (pear, dog, green)",
+		"example (banana)",
+		"example (banana, cat)",
+	}, names["example"])
+}
+
+func TestExpandJobs_Exclude(t *testing.T) {
+	jobs, err := ExpandJobs([]byte(`on: push
+jobs:
+  test:
+    name: Test
+    runs-on: ${{ matrix.os }}
+    strategy:
+      matrix:
+        os: [ubuntu-latest, windows-latest]
+        node: [18, 20]
+        exclude:
+          - os: windows-latest
+            node: 18
+        include:
+          - os: windows-latest
+            experimental: true
+          - os: macos-latest
+            node: 20
+  lint:
+    runs-on: ubuntu-latest
+`), nil)
+	assert.NoError(t, err)
+	assert.Len(t, jobs, 2)
+
+	test := jobs[0]
+	assert.True(t, test.Matrix)
+	assert.Equal(t, []string{
+		"Test (ubuntu-latest, 18)",
+		"Test (ubuntu-latest, 20)",
+		"Test (windows-latest, 20, true)",
+		"Test (macos-latest, 20)",
+	}, test.Names)
+	assert.Equal(t, MatrixCombination{{Key: "os", Value: "windows-latest"}, {Key: "node", Value: 20.0}, {Key: "experimental", Value: true}}, test.Combinations[2])
+
+	lint := jobs[1]
+	assert.False(t, lint.Matrix)
+	assert.Nil(t, lint.Combinations)
+	assert.Equal(t, []string{"lint"}, lint.Names)
+}
+
+func TestExpandJobs_Expressions(t *testing.T) {
+	content := `on:
+  workflow_dispatch:
+    inputs:
+      platforms:
+        type: string
+        default: '["ios", "android"]'
+      release:
+        type: boolean
+        default: false
+jobs:
+  literal:
+    strategy:
+      matrix:
+        version: ${{ fromJSON('[1.2, 2]') }}
+  inputs:
+    name: Build ${{ matrix.platform }}${{ inputs.release && ' (release)' || '' }}
+    strategy:
+      matrix:
+        platform: ${{ fromJSON(inputs.platforms) }}
+  whole:
+    strategy:
+      matrix: ${{ fromJSON('{"b":["x"],"a":[1,2],"include":[{"a":1,"c":{"k":"v"}}]}') }}
+  event:
+    strategy:
+      matrix:
+        target: ['${{ github.event.inputs.release }}', "${{ format('{0}-{1}', 'v', 2) }}"]
+`
+	names := expandedNames(t, content, nil)
+	assert.Equal(t, []string{"literal (1.2)", "literal (2)"}, names["literal"])
+	assert.Equal(t, []string{"Build ios", "Build android"}, names["inputs"])
+	assert.Equal(t, []string{`whole (x, 1, {"k":"v"})`, "whole (x, 2)"}, names["whole"], "the keys keep the order of the JSON")
+	assert.Equal(t, []string{"event (false)", "event (v-2)"}, names["event"])
+
+	names = expandedNames(t, content, map[string]any{"platforms": `["web"]`, "release": true})
+	assert.Equal(t, []string{"Build web (release)"}, names["inputs"])
+	assert.Equal(t, []string{"event (true)", "event (v-2)"}, names["event"])
+}
+
+func TestExpandJobs_Errors(t *testing.T) {
+	jobs, err := ExpandJobs([]byte(`on: push
+jobs:
+  setup:
+    runs-on: ubuntu-latest
+  dynamic:
+    needs: setup
+    strategy:
+      matrix:
+        target: ${{ fromJSON(needs.setup.outputs.targets) }}
+  empty:
+    strategy:
+      matrix:
+        os: []
+  unknown:
+    strategy:
+      matrix:
+        os: [linux]
+        exclude:
+          - arch: arm
+  invalid:
+    strategy:
+      matrix:
+        os: ${{ fromJSON('[linux') }}
+  large:
+    strategy:
+      matrix:
+        a: [1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17]
+        b: [1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16]
+`), nil)
+	assert.NoError(t, err)
+
+	errs := make(map[string]string)
+	for _, job := range jobs {
+		if job.Err != nil {
+			errs[job.ID] = job.Err.Error()
+			assert.Nil(t, job.Names, job.ID)
+		}
+	}
+	assert.Equal(t, map[string]string{
+		"dynamic": "line 9: needs.setup.outputs.targets is only known when the workflow runs",
+		"empty":   `line 13: matrix value "os" does not contain any values`,
+		"unknown": `line 17: exclude key "arch" does not match any key of the matrix`,
+		"invalid": "line 23: fromJSON(): invalid character 'l' looking for beginning of value",
+		"large":   "line 27: matrix generates 272 jobs, at most 256 are allowed",
+	}, errs)
+	assert.True(t, jobs[1].IsDynamic())
+	assert.False(t, jobs[2].IsDynamic())
+
+	_, err = ExpandJobs([]byte("jobs: [\n"), nil)
+	assert.Error(t, err)
+}
+
+func TestParseMatrixJobName(t *testing.T) {
+	job, values, ok := ParseMatrixJobName("Build (ubuntu-latest, 20)")
+	assert.True(t, ok)
+	assert.Equal(t, "Build", job)
+	assert.Equal(t, []string{"ubuntu-latest", "20"}, values)
+
+	_, _, ok = ParseMatrixJobName("Build")
+	assert.False(t, ok)
+}
+
+func TestEvaluator(t *testing.T) {
+	ev := newDispatchEvaluator(map[string]any{"count": json.Number("3"), "name": "Main"})
+
+	expected := map[string]any{
+		"inputs.count > 2":                            true,
+		"inputs.name == 'main'":                       true,
+		"inputs.NAME":                                 "Main",
+		"inputs.missing":                              nil,
+		"github.event.inputs.count == '3'":            true,
+		"'3' == 3":                                    true,
+		"null == 0":                                   true,
+		"!inputs.missing && 'yes'":                    "yes",
+		"contains(fromJSON('[\"a\",\"b\"]'), 'B')":    true,
+		"startsWith('refs/heads/main', 'refs/')":      true,
+		"join(fromJSON('[1, true, \"x\"]'), '-')":     "1-true-x",
+		"format('{0}{{{1}}}', 'a', 1)":                "a{1}",
+		"toJSON(fromJSON('{\"a\":[1]}'))":             "{\n  \"a\": [\n    1\n  ]\n}",
+		"fromJSON('{\"a\":{\"b\":2}}').a.b":           2.0,
+		"join(fromJSON('[{\"n\":1},{\"n\":2}]').*.n)": "1,2",
+		"fromJSON('[10, 20]')[1]":                     20.0,
+		"fromJSON('{\"k\": 1}')['K']":                 1.0,
+		"github.event_name == 'workflow_dispatch'":    true,
+		"fromJSON('[]') == fromJSON('[]')":            false,
+		"'abc' < 'ABD'":                               true,
+		"fromJSON('1e3')":                             1000.0,
+		"format('{0}', fromJSON('{}'))":               "Object",
+		"inputs.count == 3 && inputs.name != 'dev'":   true,
+	}
+	for source, value := range expected {
+		result, err := ev.evaluate(source)
+		if assert.NoError(t, err, source) {
+			assert.Equal(t, value, plainValue(result), source)
+		}
+	}
+
+	dynamic := map[string]string{
+		"github.sha":              "github.sha",
+		"needs.build.result":      "needs.build.result",
+		"hashFiles('go.sum')":     "hashFiles()",
+		"steps['x'].outputs.path": "steps['x'].outputs.path",
+	}
+	for source, reference := range dynamic {
+		_, err := ev.evaluate(source)
+		assert.Equal(t, DynamicError{Reference: reference}, err, source)
+	}
+}
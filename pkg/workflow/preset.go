@@ -0,0 +1,75 @@
+package workflow
+
+import (
+	"slices"
+)
+
+// PresetCheck lists the differences between a preset and the inputs of a workflow.
+type PresetCheck struct {
+	// Unknown are preset inputs the workflow no longer has
+	Unknown []string
+	// MissingRequired are required inputs without a default the preset has no value for
+	MissingRequired []string
+}
+
+func (c PresetCheck) IsEmpty() bool {
+	return len(c.Unknown) == 0 && len(c.MissingRequired) == 0
+}
+
+// Values returns the set values keyed by input, JSON values by their dotted path.
+func (p *Pretty) Values() map[string]string {
+	values := make(map[string]string)
+	p.each(func(key string, value *string, _ bool, _ string) {
+		if *value != "" {
+			values[key] = *value
+		}
+	})
+	return values
+}
+
+// ApplyValues sets the given values, as returned by Values, and reports the
+// values that have no input and the required inputs that were not given and
+// have no default to fall back to.
+func (p *Pretty) ApplyValues(values map[string]string) PresetCheck {
+	var check PresetCheck
+
+	known := make(map[string]bool)
+	p.each(func(key string, value *string, required bool, defaultValue string) {
+		known[key] = true
+		if v, ok := values[key]; ok {
+			*value = v
+		} else {
+			*value = ""
+			if required && defaultValue == "" {
+				check.MissingRequired = append(check.MissingRequired, key)
+			}
+		}
+	})
+
+	for key := range values {
+		if !known[key] {
+			check.Unknown = append(check.Unknown, key)
+		}
+	}
+
+	slices.Sort(check.Unknown)
+	slices.Sort(check.MissingRequired)
+	return check
+}
+
+// each calls fn with the key, a pointer to the value, the required flag and the
+// default of every item.
+func (p *Pretty) each(fn func(key string, value *string, required bool, defaultValue string)) {
+	for i := range p.Choices {
+		fn(p.Choices[i].Key, &p.Choices[i].Value, p.Choices[i].Required, p.Choices[i].Default)
+	}
+	for i := range p.Inputs {
+		fn(p.Inputs[i].Key, &p.Inputs[i].Value, p.Inputs[i].Required, p.Inputs[i].Default)
+	}
+	for i := range p.Boolean {
+		fn(p.Boolean[i].Key, &p.Boolean[i].Value, p.Boolean[i].Required, p.Boolean[i].Default)
+	}
+	for i := range p.KeyVals {
+		fn(p.KeyVals[i].FullKey(), &p.KeyVals[i].Value, false, p.KeyVals[i].Default)
+	}
+}
@@ -0,0 +1,69 @@
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPretty_ApplyValues(t *testing.T) {
+	pretty := Pretty{
+		Choices: []PrettyChoice{
+			{ID: 0, Key: "region", Values: []string{"eu", "us"}, Value: "us"},
+		},
+		Inputs: []PrettyInput{
+			{ID: 1, Key: "version", Required: true, Value: "v1"},
+			{ID: 2, Key: "ticket", Required: true},
+		},
+		Boolean: []PrettyInput{
+			{ID: 3, Key: "dry_run", Type: "boolean", Value: "true"},
+		},
+		KeyVals: []PrettyKeyValue{
+			{ID: 4, Parent: stringPtr("components"), Key: "api.ref", Value: "main"},
+		},
+	}
+
+	assert.Equal(t, map[string]string{
+		"region":             "us",
+		"version":            "v1",
+		"dry_run":            "true",
+		"components.api.ref": "main",
+	}, pretty.Values())
+
+	check := pretty.ApplyValues(map[string]string{
+		"region":             "eu",
+		"ticket":             "OPS-1",
+		"components.api.ref": "v2",
+		"removed":            "x",
+	})
+	assert.Equal(t, PresetCheck{Unknown: []string{"removed"}, MissingRequired: []string{"version"}}, check)
+	assert.Equal(t, map[string]string{
+		"region":             "eu",
+		"ticket":             "OPS-1",
+		"components.api.ref": "v2",
+	}, pretty.Values())
+
+	check = pretty.ApplyValues(map[string]string{"version": "v2", "ticket": "OPS-2"})
+	assert.True(t, check.IsEmpty())
+}
+
+func TestPretty_ApplyValues_RequiredDefault(t *testing.T) {
+	pretty := Pretty{
+		Choices: []PrettyChoice{
+			{ID: 0, Key: "environment", Required: true, Values: []string{"staging", "production"}, Default: "staging"},
+		},
+		Inputs: []PrettyInput{
+			{ID: 1, Key: "version", Required: true, Default: "latest"},
+			{ID: 2, Key: "ticket", Required: true, Value: "OPS-1"},
+		},
+	}
+	assert.Empty(t, pretty.Validate())
+
+	// required inputs left at their default are not saved, nor missing when loaded
+	values := pretty.Values()
+	assert.Equal(t, map[string]string{"ticket": "OPS-1"}, values)
+	assert.True(t, pretty.ApplyValues(values).IsEmpty())
+
+	check := pretty.ApplyValues(map[string]string{})
+	assert.Equal(t, []string{"ticket"}, check.MissingRequired)
+}
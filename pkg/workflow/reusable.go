@@ -0,0 +1,142 @@
+package workflow
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	py "github.com/termkit/gama/pkg/yaml"
+	"gopkg.in/yaml.v3"
+)
+
+// WorkflowReference is a reusable workflow called by a job, written either
+// ./.github/workflows/x.yml for a workflow of the same repository and commit,
+// or owner/repo/.github/workflows/x.yml@ref.
+type WorkflowReference struct {
+	Job        string // ID of the calling job
+	Line       int    // line of the uses: of the job
+	Uses       string // the reference as written
+	Repository string // owner/repo, empty for a workflow of the same repository
+	Path       string // path of the workflow file in the repository
+	Ref        string // empty for a workflow of the same repository
+}
+
+// Local tells whether the reference is to a workflow of the same repository and commit.
+func (r WorkflowReference) Local() bool {
+	return r.Repository == ""
+}
+
+// ParseWorkflowReference parses the uses: of a job calling a reusable
+// workflow, ok is false when it is not a reference to a workflow file.
+func ParseWorkflowReference(uses string) (reference WorkflowReference, ok bool) {
+	reference.Uses = uses
+
+	if local, found := strings.CutPrefix(uses, "./"); found {
+		reference.Path = local
+		return reference, isWorkflowPath(local)
+	}
+
+	target, ref, found := strings.Cut(uses, "@")
+	if !found || ref == "" {
+		return reference, false
+	}
+	parts := strings.SplitN(target, "/", 3)
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" {
+		return reference, false
+	}
+
+	reference.Repository = parts[0] + "/" + parts[1]
+	reference.Path = parts[2]
+	reference.Ref = ref
+	return reference, isWorkflowPath(reference.Path)
+}
+
+func isWorkflowPath(p string) bool {
+	return path.Dir(p) == ".github/workflows" && (path.Ext(p) == ".yml" || path.Ext(p) == ".yaml")
+}
+
+// WorkflowReferences returns the reusable workflows called by the jobs of a
+// workflow file in the order of the jobs.
+func WorkflowReferences(content []byte) ([]WorkflowReference, error) {
+	workflow, err := py.ParseWorkflow(content)
+	if err != nil {
+		return nil, err
+	}
+
+	var references []WorkflowReference
+	for _, job := range workflow.Jobs {
+		if job.Uses == nil || job.Uses.Kind != yaml.ScalarNode {
+			continue
+		}
+		if reference, ok := ParseWorkflowReference(job.Uses.Value); ok {
+			reference.Job = job.ID
+			reference.Line = job.Uses.Line
+			references = append(references, reference)
+		}
+	}
+	return references, nil
+}
+
+// RuleWorkflowCall is the rule of the findings of CheckWorkflowCall.
+const RuleWorkflowCall = "workflow-call"
+
+// CheckWorkflowCall compares what a job of a workflow passes to the reusable
+// workflow it calls with the interface of that workflow: required inputs and
+// secrets that are missing, and inputs and secrets the workflow doesn't have.
+func CheckWorkflowCall(content []byte, job string, call py.WorkflowCall) ([]Finding, error) {
+	workflow, err := py.ParseWorkflow(content)
+	if err != nil {
+		return nil, err
+	}
+	caller := workflow.Job(job)
+	if caller == nil || caller.Uses == nil {
+		return nil, fmt.Errorf("job %q does not call a workflow", job)
+	}
+
+	var findings []Finding
+	report := func(node *yaml.Node, message string) {
+		findings = append(findings, Finding{Rule: RuleWorkflowCall, Severity: SeverityError, Line: node.Line, Column: node.Column, Message: message})
+	}
+
+	with := py.Lookup(caller.Node, "with")
+	for _, name := range sortedKeys(call.Inputs) {
+		if input := call.Inputs[name]; input.Required && input.Default == nil && py.Lookup(with, name) == nil {
+			report(caller.Uses, fmt.Sprintf("required input %q is not passed", name))
+		}
+	}
+	for _, pair := range py.Pairs(with) {
+		if _, ok := call.Inputs[pair.Key.Value]; !ok {
+			report(pair.Key, fmt.Sprintf("%q is not an input of the called workflow", pair.Key.Value))
+		}
+	}
+
+	// secrets: inherit passes every secret of the caller
+	secrets := py.Lookup(caller.Node, "secrets")
+	if secrets == nil || secrets.Kind != yaml.ScalarNode || secrets.Value != "inherit" {
+		// Secret names are not case sensitive
+		passed := py.Pairs(secrets)
+		for _, name := range sortedKeys(call.Secrets) {
+			if call.Secrets[name].Required && !slices.ContainsFunc(passed, func(pair py.Pair) bool { return strings.EqualFold(pair.Key.Value, name) }) {
+				report(caller.Uses, fmt.Sprintf("required secret %q is not passed", name))
+			}
+		}
+		for _, pair := range passed {
+			if !slices.ContainsFunc(sortedKeys(call.Secrets), func(name string) bool { return strings.EqualFold(pair.Key.Value, name) }) {
+				report(pair.Key, fmt.Sprintf("%q is not a secret of the called workflow", pair.Key.Value))
+			}
+		}
+	}
+
+	sortFindings(findings)
+	return suppress(findings, strings.Split(string(content), "\n")), nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
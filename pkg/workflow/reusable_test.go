@@ -0,0 +1,96 @@
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	py "github.com/termkit/gama/pkg/yaml"
+)
+
+func TestParseWorkflowReference(t *testing.T) {
+	reference, ok := ParseWorkflowReference("./.github/workflows/notify.yml")
+	assert.True(t, ok)
+	assert.True(t, reference.Local())
+	assert.Equal(t, ".github/workflows/notify.yml", reference.Path)
+
+	reference, ok = ParseWorkflowReference("octo-org/shared/.github/workflows/deploy.yaml@v2")
+	assert.True(t, ok)
+	assert.False(t, reference.Local())
+	assert.Equal(t, WorkflowReference{
+		Uses:       "octo-org/shared/.github/workflows/deploy.yaml@v2",
+		Repository: "octo-org/shared",
+		Path:       ".github/workflows/deploy.yaml",
+		Ref:        "v2",
+	}, reference)
+
+	for _, uses := range []string{
+		"actions/checkout@v4",
+		"./.github/actions/setup",
+		"octo-org/shared/.github/workflows/deploy.yml",
+		"octo-org/.github/workflows/deploy.yml@main",
+		"docker://alpine:3.19",
+	} {
+		_, ok := ParseWorkflowReference(uses)
+		assert.False(t, ok, uses)
+	}
+}
+
+func TestWorkflowReferences(t *testing.T) {
+	references, err := WorkflowReferences([]byte(`jobs:
+  build:
+    runs-on: ubuntu-latest
+  notify:
+    uses: ./.github/workflows/notify.yml
+  deploy:
+    uses: octo-org/shared/.github/workflows/deploy.yml@main
+`))
+	assert.NoError(t, err)
+	assert.Equal(t, []WorkflowReference{
+		{Job: "notify", Line: 5, Uses: "./.github/workflows/notify.yml", Path: ".github/workflows/notify.yml"},
+		{Job: "deploy", Line: 7, Uses: "octo-org/shared/.github/workflows/deploy.yml@main", Repository: "octo-org/shared", Path: ".github/workflows/deploy.yml", Ref: "main"},
+	}, references)
+}
+
+func TestCheckWorkflowCall(t *testing.T) {
+	call := py.WorkflowCall{
+		Inputs: map[string]py.WorkflowInput{
+			"message":  {Required: true},
+			"channel":  {Required: true, Default: "#general"},
+			"priority": {},
+		},
+		Secrets: map[string]py.WorkflowSecret{
+			"WEBHOOK": {Required: true},
+			"TOKEN":   {},
+		},
+	}
+	var data = []byte(`jobs:
+  notify:
+    uses: ./.github/workflows/notify.yml
+    with:
+      level: high
+    secrets:
+      token: ${{ secrets.TOKEN }}
+      api_key: ${{ secrets.API_KEY }}
+  inherit:
+    uses: ./.github/workflows/notify.yml
+    with:
+      message: done
+    secrets: inherit
+`)
+
+	findings, err := CheckWorkflowCall(data, "notify", call)
+	assert.NoError(t, err)
+	assert.Equal(t, []Finding{
+		{Rule: RuleWorkflowCall, Severity: SeverityError, Line: 3, Column: 11, Message: `required input "message" is not passed`},
+		{Rule: RuleWorkflowCall, Severity: SeverityError, Line: 3, Column: 11, Message: `required secret "WEBHOOK" is not passed`},
+		{Rule: RuleWorkflowCall, Severity: SeverityError, Line: 5, Column: 7, Message: `"level" is not an input of the called workflow`},
+		{Rule: RuleWorkflowCall, Severity: SeverityError, Line: 8, Column: 7, Message: `"api_key" is not a secret of the called workflow`},
+	}, findings)
+
+	findings, err = CheckWorkflowCall(data, "inherit", call)
+	assert.NoError(t, err)
+	assert.Empty(t, findings)
+
+	_, err = CheckWorkflowCall(data, "build", call)
+	assert.Error(t, err)
+}
@@ -0,0 +1,255 @@
+package workflow
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	py "github.com/termkit/gama/pkg/yaml"
+	"gopkg.in/yaml.v3"
+)
+
+// SecurityRule is a check of risky patterns in a workflow. Rules report
+// findings with their own severity, the rule ID is filled in by CheckSecurity.
+type SecurityRule struct {
+	ID          string
+	Description string
+	Check       func(c *SecurityCheck)
+}
+
+// SecurityCheck is the workflow given to a rule.
+type SecurityCheck struct {
+	Workflow *py.Workflow
+
+	lines    []string
+	rule     string
+	findings []Finding
+}
+
+// Report adds a finding at the node.
+func (c *SecurityCheck) Report(node *yaml.Node, severity Severity, message string) {
+	c.findings = append(c.findings, Finding{Rule: c.rule, Severity: severity, Line: node.Line, Column: node.Column, Message: message})
+}
+
+// ReportAt adds a finding at the byte at offset of the value of a scalar node.
+func (c *SecurityCheck) ReportAt(node *yaml.Node, offset int, severity Severity, message string) {
+	line, column := scalarPosition(c.lines, node, offset)
+	c.findings = append(c.findings, Finding{Rule: c.rule, Severity: severity, Line: line, Column: column, Message: message})
+}
+
+// Security rules
+const (
+	RulePullRequestTarget = "pull-request-target-checkout"
+	RuleUnpinnedAction    = "unpinned-action"
+	RulePermissions       = "permissions"
+	RuleScriptInjection   = "script-injection"
+)
+
+// SecurityRules are the rules run by CheckSecurity, in the order they are run.
+var SecurityRules = []SecurityRule{
+	{
+		ID:          RulePullRequestTarget,
+		Description: "pull_request_target runs with secrets and a write token, checking out the pull request runs its code with them",
+		Check:       checkPullRequestTarget,
+	},
+	{
+		ID:          RuleUnpinnedAction,
+		Description: "actions referenced by a tag or branch can be changed under the workflow, a commit SHA can't",
+		Check:       checkUnpinnedActions,
+	},
+	{
+		ID:          RulePermissions,
+		Description: "the GITHUB_TOKEN should only be granted the permissions the jobs need",
+		Check:       checkPermissions,
+	},
+	{
+		ID:          RuleScriptInjection,
+		Description: "event data interpolated into a script is run as code, pass it through env: instead",
+		Check:       checkScriptInjection,
+	},
+}
+
+// CheckSecurity runs the security rules on a workflow file and returns the
+// findings ordered by their position, leaving out the ones suppressed by
+// comments. The error is set when the file is not a valid workflow.
+func CheckSecurity(content []byte) ([]Finding, error) {
+	workflow, err := py.ParseWorkflow(content)
+	if err != nil {
+		return nil, err
+	}
+
+	check := &SecurityCheck{Workflow: workflow, lines: strings.Split(string(content), "\n")}
+	for _, rule := range SecurityRules {
+		check.rule = rule.ID
+		rule.Check(check)
+	}
+
+	sortFindings(check.findings)
+	return suppress(check.findings, check.lines), nil
+}
+
+var (
+	// References to the code of the pull request
+	pullRequestHeadPattern = regexp.MustCompile(`github\.event\.pull_request\.(head\.|merge_commit_sha)|github\.head_ref|refs/pull/`)
+	gitCheckoutPattern     = regexp.MustCompile(`\bgit\s+(checkout|fetch|switch)\b`)
+	ghCheckoutPattern      = regexp.MustCompile(`\bgh\s+pr\s+checkout\b`)
+)
+
+func checkPullRequestTarget(c *SecurityCheck) {
+	if _, ok := c.Workflow.Trigger("pull_request_target"); !ok {
+		return
+	}
+
+	for _, job := range c.Workflow.Jobs {
+		for _, step := range job.Steps {
+			if step.Uses != nil && strings.HasPrefix(step.Uses.Value, "actions/checkout@") {
+				if ref := py.Lookup(step.With, "ref"); ref != nil && pullRequestHeadPattern.MatchString(ref.Value) {
+					c.Report(ref, SeverityError, fmt.Sprintf("job %q checks out the pull request in a pull_request_target workflow, its code runs with the secrets of the repository", job.ID))
+				}
+			}
+			if step.Run != nil && (ghCheckoutPattern.MatchString(step.Run.Value) ||
+				gitCheckoutPattern.MatchString(step.Run.Value) && pullRequestHeadPattern.MatchString(step.Run.Value)) {
+				c.Report(step.Run, SeverityError, fmt.Sprintf("job %q checks out the pull request in a pull_request_target workflow, its code runs with the secrets of the repository", job.ID))
+			}
+		}
+	}
+}
+
+var (
+	commitSHAPattern = regexp.MustCompile(`^[0-9a-f]{40}$`)
+	versionPattern   = regexp.MustCompile(`^v?\d+(\.\d+)*$`)
+
+	// Actions maintained by GitHub, their tags are as trusted as the runner
+	githubOwners = []string{"actions", "github"}
+)
+
+func checkUnpinnedActions(c *SecurityCheck) {
+	for _, job := range c.Workflow.Jobs {
+		if job.Uses != nil {
+			c.checkPinned(job.Uses)
+		}
+		for _, step := range job.Steps {
+			if step.Uses != nil {
+				c.checkPinned(step.Uses)
+			}
+		}
+	}
+}
+
+func (c *SecurityCheck) checkPinned(uses *yaml.Node) {
+	reference := uses.Value
+	switch {
+	case strings.HasPrefix(reference, "./") || strings.Contains(reference, "${{"):
+		return // local actions are versioned with the workflow
+	case strings.HasPrefix(reference, "docker://"):
+		if !strings.Contains(reference, "@sha256:") {
+			c.Report(uses, SeverityWarning, fmt.Sprintf("image %s is not pinned to a digest", strings.TrimPrefix(reference, "docker://")))
+		}
+		return
+	}
+
+	action, ref, ok := strings.Cut(reference, "@")
+	if !ok || commitSHAPattern.MatchString(ref) {
+		return
+	}
+
+	owner, _, _ := strings.Cut(action, "/")
+	switch {
+	case !versionPattern.MatchString(ref):
+		c.Report(uses, SeverityError, fmt.Sprintf("%s is used at branch %s, anyone who can push to it changes what runs, pin it to a commit SHA", action, ref))
+	case slices.Contains(githubOwners, owner):
+		c.Report(uses, SeverityInfo, fmt.Sprintf("%s is used at tag %s, pin it to a commit SHA", action, ref))
+	default:
+		c.Report(uses, SeverityWarning, fmt.Sprintf("%s is used at tag %s, which can be moved to other code, pin it to a commit SHA", action, ref))
+	}
+}
+
+// untrustedTriggers are the events anyone can cause that run with the
+// GITHUB_TOKEN and secrets of the repository, on data they control.
+var untrustedTriggers = []string{"pull_request_target", "workflow_run", "issue_comment", "issues", "discussion_comment"}
+
+func checkPermissions(c *SecurityCheck) {
+	var untrusted string
+	for _, event := range untrustedTriggers {
+		if _, ok := c.Workflow.Trigger(event); ok {
+			untrusted = event
+			break
+		}
+	}
+
+	if permissions := c.Workflow.Permissions; permissions != nil && permissions.Value == "write-all" {
+		c.Report(permissions, SeverityError, "permissions: write-all grants the GITHUB_TOKEN write access to everything, list the scopes the jobs need")
+	}
+	for _, scope := range writeScopes(c.Workflow.Permissions) {
+		if untrusted != "" {
+			c.Report(scope, SeverityError, fmt.Sprintf("%s: write is granted to every job of a %s workflow, which anyone can trigger, grant it only to the jobs that need it", scope.Value, untrusted))
+		} else {
+			c.Report(scope, SeverityWarning, fmt.Sprintf("%s: write is granted to every job, grant it only to the jobs that need it", scope.Value))
+		}
+	}
+
+	for _, job := range c.Workflow.Jobs {
+		switch {
+		case job.Permissions != nil && job.Permissions.Value == "write-all":
+			c.Report(job.Permissions, SeverityError, fmt.Sprintf("job %q grants the GITHUB_TOKEN write access to everything, list the scopes it needs", job.ID))
+		case job.Permissions == nil && c.Workflow.Permissions == nil:
+			c.Report(job.Key, SeverityWarning, fmt.Sprintf("job %q has no permissions, the GITHUB_TOKEN gets the default permissions of the repository", job.ID))
+		case untrusted != "":
+			for _, scope := range writeScopes(job.Permissions) {
+				c.Report(scope, SeverityWarning, fmt.Sprintf("job %q has %s: write in a %s workflow, which anyone can trigger, make sure it never runs their code", job.ID, scope.Value, untrusted))
+			}
+		}
+	}
+}
+
+// writeScopes returns the keys of the scopes a permissions mapping grants write access to.
+func writeScopes(permissions *yaml.Node) []*yaml.Node {
+	if permissions == nil || permissions.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	var scopes []*yaml.Node
+	for i := 0; i+1 < len(permissions.Content); i += 2 {
+		if permissions.Content[i+1].Value == "write" {
+			scopes = append(scopes, permissions.Content[i])
+		}
+	}
+	return scopes
+}
+
+var (
+	eventDataPattern = regexp.MustCompile(`github\.event\.[\w.*-]+|github\.head_ref`)
+
+	// Event fields written by whoever opens the pull request, issue or comment
+	untrustedEventData = regexp.MustCompile(`(^github\.head_ref|\.(title|body|message|label|ref|head_branch|page_name|default_branch|(author|committer)\.(name|email)))$`)
+)
+
+func checkScriptInjection(c *SecurityCheck) {
+	for _, job := range c.Workflow.Jobs {
+		for _, step := range job.Steps {
+			if step.Run != nil {
+				c.checkInterpolation(step.Run, "run:")
+			}
+			if step.Uses != nil && strings.HasPrefix(step.Uses.Value, "actions/github-script@") {
+				if script := py.Lookup(step.With, "script"); script != nil {
+					c.checkInterpolation(script, "script:")
+				}
+			}
+		}
+	}
+}
+
+func (c *SecurityCheck) checkInterpolation(script *yaml.Node, key string) {
+	for _, expression := range findExpressions(script.Value) {
+		for _, loc := range eventDataPattern.FindAllStringIndex(expression.Source, -1) {
+			data := expression.Source[loc[0]:loc[1]]
+			offset := expression.Offset + 3 + loc[0]
+			if untrustedEventData.MatchString(data) {
+				c.ReportAt(script, offset, SeverityError, fmt.Sprintf("%s is interpolated into %s and can inject commands, pass it through env: instead", data, key))
+			} else {
+				c.ReportAt(script, offset, SeverityWarning, fmt.Sprintf("%s is interpolated into %s, pass it through env: instead", data, key))
+			}
+		}
+	}
+}
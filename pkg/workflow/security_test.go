@@ -0,0 +1,137 @@
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckSecurity(t *testing.T) {
+	var data = []byte(`name: PR Preview
+on:
+  pull_request_target:
+    types: [opened, synchronize]
+
+jobs:
+  preview:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+        with:
+          ref: ${{ github.event.pull_request.head.sha }}
+      - run: |
+          echo "Building ${{ github.event.pull_request.title }}"
+          echo "Number ${{ github.event.number }}"
+      - uses: some-org/preview-action@main
+      - uses: some-org/comment-action@v2.1
+      - uses: some-org/pinned-action@8f4b7f84864484a7bf31766abe9204da3cbe65b3
+      - uses: ./.github/actions/local
+      - uses: docker://alpine:3.19
+      - run: gh pr checkout ${{ github.event.pull_request.number }}
+  deploy:
+    permissions: write-all
+    uses: org/shared/.github/workflows/deploy.yml@v1
+`)
+
+	findings, err := CheckSecurity(data)
+	assert.NoError(t, err)
+	assert.Equal(t, []Finding{
+		{Rule: RulePermissions, Severity: SeverityWarning, Line: 7, Column: 3, Message: `job "preview" has no permissions, the GITHUB_TOKEN gets the default permissions of the repository`},
+		{Rule: RuleUnpinnedAction, Severity: SeverityInfo, Line: 10, Column: 15, Message: "actions/checkout is used at tag v4, pin it to a commit SHA"},
+		{Rule: RulePullRequestTarget, Severity: SeverityError, Line: 12, Column: 16, Message: `job "preview" checks out the pull request in a pull_request_target workflow, its code runs with the secrets of the repository`},
+		{Rule: RuleScriptInjection, Severity: SeverityError, Line: 14, Column: 30, Message: "github.event.pull_request.title is interpolated into run: and can inject commands, pass it through env: instead"},
+		{Rule: RuleScriptInjection, Severity: SeverityWarning, Line: 15, Column: 28, Message: "github.event.number is interpolated into run:, pass it through env: instead"},
+		{Rule: RuleUnpinnedAction, Severity: SeverityError, Line: 16, Column: 15, Message: "some-org/preview-action is used at branch main, anyone who can push to it changes what runs, pin it to a commit SHA"},
+		{Rule: RuleUnpinnedAction, Severity: SeverityWarning, Line: 17, Column: 15, Message: "some-org/comment-action is used at tag v2.1, which can be moved to other code, pin it to a commit SHA"},
+		{Rule: RuleUnpinnedAction, Severity: SeverityWarning, Line: 20, Column: 15, Message: "image alpine:3.19 is not pinned to a digest"},
+		{Rule: RulePullRequestTarget, Severity: SeverityError, Line: 21, Column: 14, Message: `job "preview" checks out the pull request in a pull_request_target workflow, its code runs with the secrets of the repository`},
+		{Rule: RuleScriptInjection, Severity: SeverityWarning, Line: 21, Column: 33, Message: "github.event.pull_request.number is interpolated into run:, pass it through env: instead"},
+		{Rule: RulePermissions, Severity: SeverityError, Line: 23, Column: 18, Message: `job "deploy" grants the GITHUB_TOKEN write access to everything, list the scopes it needs`},
+		{Rule: RuleUnpinnedAction, Severity: SeverityWarning, Line: 24, Column: 11, Message: "org/shared/.github/workflows/deploy.yml is used at tag v1, which can be moved to other code, pin it to a commit SHA"},
+	}, findings)
+}
+
+func TestCheckSecurity_Permissions(t *testing.T) {
+	var data = []byte(`on:
+  pull_request_target:
+permissions:
+  contents: write
+  pull-requests: read
+jobs:
+  label:
+    runs-on: ubuntu-latest
+    permissions:
+      issues: write
+    steps:
+      - run: echo label
+`)
+
+	findings, err := CheckSecurity(data)
+	assert.NoError(t, err)
+	assert.Equal(t, []Finding{
+		{Rule: RulePermissions, Severity: SeverityError, Line: 4, Column: 3, Message: "contents: write is granted to every job of a pull_request_target workflow, which anyone can trigger, grant it only to the jobs that need it"},
+		{Rule: RulePermissions, Severity: SeverityWarning, Line: 10, Column: 7, Message: `job "label" has issues: write in a pull_request_target workflow, which anyone can trigger, make sure it never runs their code`},
+	}, findings)
+
+	// Outside untrusted triggers only the scopes granted to every job are reported
+	findings, err = CheckSecurity([]byte(`on: push
+permissions:
+  contents: write
+jobs:
+  release:
+    runs-on: ubuntu-latest
+    permissions:
+      packages: write
+    steps:
+      - run: make release
+`))
+	assert.NoError(t, err)
+	assert.Equal(t, []Finding{
+		{Rule: RulePermissions, Severity: SeverityWarning, Line: 3, Column: 3, Message: "contents: write is granted to every job, grant it only to the jobs that need it"},
+	}, findings)
+}
+
+func TestCheckSecurity_Safe(t *testing.T) {
+	var data = []byte(`on:
+  pull_request_target:
+permissions:
+  contents: read
+jobs:
+  label:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@b4ffde65f46336ab88eb53be808477a3936bae11
+      - env:
+          TITLE: ${{ github.event.pull_request.title }}
+        run: echo "$TITLE"
+`)
+
+	findings, err := CheckSecurity(data)
+	assert.NoError(t, err)
+	assert.Empty(t, findings)
+
+	_, err = CheckSecurity([]byte("- push\n"))
+	assert.Error(t, err)
+}
+
+func TestCheckSecurity_Suppressed(t *testing.T) {
+	var data = []byte(`# gama:ignore-file permissions
+on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: some-org/action@main # gama:ignore unpinned-action
+      # gama:ignore
+      - uses: other-org/action@main
+      - uses: third-org/action@main # gama:ignore script-injection, permissions
+      - run: echo ${{ github.event.head_commit.message }}
+`)
+
+	findings, err := CheckSecurity(data)
+	assert.NoError(t, err)
+	assert.Equal(t, []Finding{
+		{Rule: RuleUnpinnedAction, Severity: SeverityError, Line: 10, Column: 15, Message: "third-org/action is used at branch main, anyone who can push to it changes what runs, pin it to a commit SHA"},
+		{Rule: RuleScriptInjection, Severity: SeverityError, Line: 11, Column: 23, Message: "github.event.head_commit.message is interpolated into run: and can inject commands, pass it through env: instead"},
+	}, findings)
+}
@@ -0,0 +1,58 @@
+package workflow
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Findings are suppressed by comments in the workflow file:
+//
+//	# gama:ignore                 all findings on this line, or on the next line when the comment is alone on its line
+//	# gama:ignore rule-a, rule-b  only the findings of the rules
+//	# gama:ignore-file rule-a     the findings of the rule anywhere in the file
+var suppressionPattern = regexp.MustCompile(`#\s*gama:ignore(-file)?(?:\s+([\w\s,-]*))?$`)
+
+type suppression struct {
+	rules []string // empty for every rule
+}
+
+func (s suppression) matches(rule string) bool {
+	return len(s.rules) == 0 || slices.Contains(s.rules, rule)
+}
+
+// suppress leaves out the findings suppressed by comments of the lines.
+func suppress(findings []Finding, lines []string) []Finding {
+	var fileSuppressions []suppression
+	lineSuppressions := make(map[int][]suppression) // by line, starting at 1
+
+	for i, line := range lines {
+		match := suppressionPattern.FindStringSubmatch(strings.TrimRight(line, " \t\r"))
+		if match == nil {
+			continue
+		}
+
+		s := suppression{rules: strings.FieldsFunc(match[2], func(r rune) bool {
+			return r == ',' || r == ' ' || r == '\t'
+		})}
+		switch {
+		case match[1] != "":
+			fileSuppressions = append(fileSuppressions, s)
+		case strings.HasPrefix(strings.TrimSpace(line), "#"):
+			lineSuppressions[i+2] = append(lineSuppressions[i+2], s) // a comment line applies to the next line
+		default:
+			lineSuppressions[i+1] = append(lineSuppressions[i+1], s)
+		}
+	}
+
+	if len(fileSuppressions) == 0 && len(lineSuppressions) == 0 {
+		return findings
+	}
+
+	return slices.DeleteFunc(findings, func(f Finding) bool {
+		matches := func(s suppression) bool {
+			return s.matches(f.Rule)
+		}
+		return slices.ContainsFunc(lineSuppressions[f.Line], matches) || slices.ContainsFunc(fileSuppressions, matches)
+	})
+}
@@ -0,0 +1,101 @@
+package workflow
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+)
+
+// ValidationError is an invalid value of the trigger form.
+type ValidationError struct {
+	ID      int // ID of the Pretty item
+	Key     string
+	Message string
+}
+
+func (e ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Key, e.Message)
+}
+
+// Validate checks every value against the type and required flag of its input.
+// Empty values are checked through their default, which is what gets sent.
+func (p *Pretty) Validate() []ValidationError {
+	var errs []ValidationError
+
+	for _, choice := range p.Choices {
+		value := orDefault(choice.Value, choice.Default)
+		switch {
+		case value == "" && choice.Required:
+			errs = append(errs, ValidationError{ID: choice.ID, Key: choice.Key, Message: "required"})
+		case value != "" && !slices.Contains(choice.Values, value):
+			errs = append(errs, ValidationError{ID: choice.ID, Key: choice.Key, Message: fmt.Sprintf("%q is not an option", value)})
+		}
+	}
+
+	for _, input := range append(slices.Clone(p.Inputs), p.Boolean...) {
+		if err := validateInput(input); err != "" {
+			errs = append(errs, ValidationError{ID: input.ID, Key: input.Key, Message: err})
+		}
+	}
+
+	for _, kv := range p.KeyVals {
+		if kv.Parent == nil {
+			continue
+		}
+		if _, err := convertKeyValue(kv); err != nil {
+			errs = append(errs, ValidationError{ID: kv.ID, Key: kv.FullKey(), Message: keyValueMessage(kv.Type)})
+		}
+	}
+
+	slices.SortFunc(errs, func(a, b ValidationError) int {
+		return a.ID - b.ID
+	})
+
+	return errs
+}
+
+func validateInput(input PrettyInput) string {
+	value := orDefault(input.Value, input.Default)
+	if value == "" {
+		if input.Required {
+			return "required"
+		}
+		return ""
+	}
+
+	switch input.Type {
+	case "number":
+		if !isNumber(value) {
+			return "must be a number"
+		}
+	case "boolean":
+		if value != "true" && value != "false" {
+			return "must be true or false"
+		}
+	}
+	return ""
+}
+
+func keyValueMessage(valueType string) string {
+	switch valueType {
+	case "number":
+		return "must be a number"
+	case "boolean":
+		return "must be true or false"
+	}
+	return "must be valid JSON"
+}
+
+func orDefault(value string, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
+
+// isNumber reports whether value is a number that can be sent as a JSON number.
+func isNumber(value string) bool {
+	_, err := strconv.ParseFloat(value, 64)
+	return err == nil && json.Valid([]byte(value))
+}
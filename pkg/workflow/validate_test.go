@@ -0,0 +1,44 @@
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPretty_Validate(t *testing.T) {
+	pretty := Pretty{
+		Choices: []PrettyChoice{
+			{ID: 0, Key: "region", Type: "choice", Values: []string{"eu", "us"}, Default: "eu"},
+			{ID: 1, Key: "target", Type: "environment", Required: true, Values: []string{"staging"}},
+		},
+		Inputs: []PrettyInput{
+			{ID: 2, Key: "version", Type: "string", Required: true},
+			{ID: 3, Key: "replicas", Type: "number", Default: "3", Value: "three"},
+			{ID: 4, Key: "note", Type: "string"},
+		},
+		Boolean: []PrettyInput{
+			{ID: 5, Key: "dry_run", Type: "boolean", Default: "false", Value: "yes"},
+		},
+	}
+
+	assert.Equal(t, []ValidationError{
+		{ID: 1, Key: "target", Message: "required"},
+		{ID: 2, Key: "version", Message: "required"},
+		{ID: 3, Key: "replicas", Message: "must be a number"},
+		{ID: 5, Key: "dry_run", Message: "must be true or false"},
+	}, pretty.Validate())
+
+	pretty.Choices[0].SetValue("asia")
+	pretty.Choices[1].SetValue("staging")
+	pretty.Inputs[0].SetValue("v1.2.3")
+	pretty.Inputs[1].SetValue("-1.5")
+	pretty.Boolean[0].SetValue("true")
+
+	assert.Equal(t, []ValidationError{
+		{ID: 0, Key: "region", Message: `"asia" is not an option`},
+	}, pretty.Validate())
+
+	pretty.Choices[0].SetValue("")
+	assert.Empty(t, pretty.Validate())
+}
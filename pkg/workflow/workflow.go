@@ -2,7 +2,8 @@ package workflow
 
 import (
 	"encoding/json"
-	"reflect"
+	"fmt"
+	"slices"
 	"strconv"
 
 	py "github.com/termkit/gama/pkg/yaml"
@@ -33,7 +34,9 @@ type Content struct {
 
 type KeyValue struct {
 	Default string
-	Key     string
+	Key     string // dotted path of the leaf
+	Path    []any  // object keys and array indexes leading to the leaf
+	Type    string // string, number, boolean, null, object or array
 	Value   string
 }
 
@@ -56,15 +59,17 @@ func ParseWorkflow(content py.WorkflowContent) (*Workflow, error) {
 	}
 
 	for key, value := range content.On.WorkflowDispatch.Inputs {
-		if value.JSONContent != nil && len(value.JSONContent) > 0 {
+		if value.JSONContent != nil {
 			var keyValue []KeyValue
-			for k, v := range value.JSONContent {
+			flattenJSON(nil, value.JSONContent, func(path []any, valueType string, defaultValue string) {
 				keyValue = append(keyValue, KeyValue{
-					Key:     k,
+					Key:     formatPath(path),
+					Path:    path,
+					Type:    valueType,
 					Value:   "",
-					Default: v,
+					Default: defaultValue,
 				})
-			}
+			})
 
 			w.Content[key] = Content{
 				Description: value.Description,
@@ -91,17 +96,38 @@ func ParseWorkflow(content py.WorkflowContent) (*Workflow, error) {
 			}
 		}
 
+		if value.Type == "environment" {
+			// The options are the deployment environments of the repository, see SetEnvironments
+			defaultValue, _ := value.Default.(string)
+			w.Content[key] = Content{
+				Description: value.Description,
+				Type:        "environment",
+				Required:    value.Required,
+				Choice: &Choice{
+					Default: defaultValue,
+					Value:   "",
+				},
+			}
+		}
+
 		if value.Type == "string" || value.Type == "number" || value.Type == "" {
+			inputType := value.Type
+			if inputType == "" {
+				inputType = "string"
+			}
+
 			defaultValue := ""
-			if value.Default != nil {
-				_, ok := value.Default.(string)
-				if ok {
-					defaultValue = value.Default.(string)
-				}
+			switch def := value.Default.(type) {
+			case nil:
+			case string:
+				defaultValue = def
+			default:
+				// numbers are written without quotes
+				defaultValue = fmt.Sprint(def)
 			}
 			w.Content[key] = Content{
 				Description: value.Description,
-				Type:        "input",
+				Type:        inputType,
 				Required:    value.Required,
 				Value: &Value{
 					Default: defaultValue,
@@ -134,6 +160,25 @@ func ParseWorkflow(content py.WorkflowContent) (*Workflow, error) {
 	return w, nil
 }
 
+// HasEnvironmentInputs reports whether any input is of type environment.
+func (w *Workflow) HasEnvironmentInputs() bool {
+	for _, content := range w.Content {
+		if content.Type == "environment" {
+			return true
+		}
+	}
+	return false
+}
+
+// SetEnvironments sets the deployment environments of the repository as the options of environment inputs.
+func (w *Workflow) SetEnvironments(environments []string) {
+	for _, content := range w.Content {
+		if content.Type == "environment" {
+			content.Choice.Options = environments
+		}
+	}
+}
+
 func (w *Workflow) ToPretty() *Pretty {
 	var pretty Pretty
 	var id int
@@ -144,19 +189,34 @@ func (w *Workflow) ToPretty() *Pretty {
 					ID:      id,
 					Parent:  stringPtr(parent),
 					Key:     v.Key,
+					Path:    v.Path,
+					Type:    v.Type,
 					Value:   "",
 					Default: v.Default,
 				})
 				id++
 			}
 		}
-		if data.Choice != nil {
+		if data.Choice != nil && data.Type == "environment" && len(data.Choice.Options) == 0 {
+			// Without deployment environments to choose from, the name is typed in
+			pretty.Inputs = append(pretty.Inputs, PrettyInput{
+				ID:       id,
+				Key:      parent,
+				Type:     data.Type,
+				Required: data.Required,
+				Value:    "",
+				Default:  data.Choice.Default,
+			})
+			id++
+		} else if data.Choice != nil {
 			pretty.Choices = append(pretty.Choices, PrettyChoice{
-				ID:      id,
-				Key:     parent,
-				Value:   "",
-				Values:  data.Choice.Options,
-				Default: data.Choice.Default,
+				ID:       id,
+				Key:      parent,
+				Type:     data.Type,
+				Required: data.Required,
+				Value:    "",
+				Values:   data.Choice.Options,
+				Default:  data.Choice.Default,
 			})
 			id++
 		}
@@ -169,10 +229,12 @@ func (w *Workflow) ToPretty() *Pretty {
 				}
 			}
 			pretty.Inputs = append(pretty.Inputs, PrettyInput{
-				ID:      id,
-				Key:     parent,
-				Value:   "",
-				Default: defaultValue,
+				ID:       id,
+				Key:      parent,
+				Type:     data.Type,
+				Required: data.Required,
+				Value:    "",
+				Default:  defaultValue,
 			})
 			id++
 		}
@@ -185,10 +247,12 @@ func (w *Workflow) ToPretty() *Pretty {
 				}
 			}
 			pretty.Boolean = append(pretty.Boolean, PrettyInput{
-				ID:      id,
-				Key:     parent,
-				Value:   "",
-				Default: defaultValue,
+				ID:       id,
+				Key:      parent,
+				Type:     data.Type,
+				Required: data.Required,
+				Value:    "",
+				Default:  defaultValue,
 			})
 			id++
 		}
@@ -197,43 +261,90 @@ func (w *Workflow) ToPretty() *Pretty {
 	return &pretty
 }
 
-func (p *Pretty) ToJson() (string, error) {
-	// Create a map to hold the aggregated data
-	result := make(map[string]interface{})
+// ToPayload returns the inputs of the dispatch request. Empty values get the
+// default of their input, as GitHub would take an empty string as the value.
+// Values are converted to the type of their input, numbers and booleans
+// without a value nor a default are left out.
+func (p *Pretty) ToPayload() (map[string]any, error) {
+	result := make(map[string]any)
 
-	// Process KeyVals
+	// Process KeyVals, JSON inputs are rebuilt from their leaves and sent as a string
+	trees := make(map[string]any)
 	for _, kv := range p.KeyVals {
-		if kv.Parent != nil {
-			parent := *kv.Parent
-			if _, ok := result[parent]; !ok {
-				result[parent] = make(map[string]any)
-			}
-			result[parent].(map[string]any)[kv.Key] = kv.Value
-		} else {
+		kv.Value = orDefault(kv.Value, kv.Default)
+		if kv.Parent == nil {
 			result[kv.Key] = kv.Value
+			continue
+		}
+		value, err := convertKeyValue(kv)
+		if err != nil {
+			return nil, err
+		}
+		keyPath := kv.Path
+		if keyPath == nil {
+			keyPath = []any{kv.Key}
+		}
+		trees[*kv.Parent] = setPath(trees[*kv.Parent], keyPath, value)
+	}
+	for parent, tree := range trees {
+		str, err := json.Marshal(tree)
+		if err != nil {
+			return nil, err
 		}
+		result[parent] = string(str)
 	}
 
 	// Process Choices
 	for _, c := range p.Choices {
-		result[c.Key] = c.Value
+		result[c.Key] = orDefault(c.Value, c.Default)
 	}
 
-	// Process Inputs
-	for _, i := range p.Inputs {
-		result[i.Key] = i.Value
+	// Process Inputs and Boolean
+	for _, i := range append(slices.Clone(p.Inputs), p.Boolean...) {
+		i.Value = orDefault(i.Value, i.Default)
+		value, ok, err := convertInput(i)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			result[i.Key] = value
+		}
 	}
 
-	// Process Boolean
-	for _, b := range p.Boolean {
-		result[b.Key] = b.Value
+	return result, nil
+}
+
+func convertInput(input PrettyInput) (any, bool, error) {
+	switch input.Type {
+	case "number":
+		if input.Value == "" {
+			return nil, false, nil
+		}
+		if !isNumber(input.Value) {
+			return nil, false, fmt.Errorf("%s: %q is not a number", input.Key, input.Value)
+		}
+		return json.Number(input.Value), true, nil
+	case "boolean":
+		if input.Value == "" {
+			return nil, false, nil
+		}
+		value, err := strconv.ParseBool(input.Value)
+		if err != nil {
+			return nil, false, fmt.Errorf("%s: %q is not a boolean", input.Key, input.Value)
+		}
+		return value, true, nil
 	}
+	return input.Value, true, nil
+}
 
-	if err := convertJsonToString(result); err != nil {
+// ToJson returns the dispatch inputs as a JSON object.
+func (p *Pretty) ToJson() (string, error) {
+	payload, err := p.ToPayload()
+	if err != nil {
 		return "", err
 	}
 
-	modifiedJSON, err := json.Marshal(result)
+	modifiedJSON, err := json.Marshal(payload)
 	if err != nil {
 		return "", err
 	}
@@ -241,20 +352,6 @@ func (p *Pretty) ToJson() (string, error) {
 	return string(modifiedJSON), nil
 }
 
-func convertJsonToString(m map[string]interface{}) error {
-	for k, v := range m {
-		if reflect.TypeOf(v).Kind() == reflect.Map {
-			// Convert map to a JSON string
-			str, err := json.Marshal(v)
-			if err != nil {
-				return err
-			}
-			m[k] = string(str)
-		}
-	}
-	return nil
-}
-
 type Pretty struct {
 	Choices []PrettyChoice
 	Inputs  []PrettyInput
@@ -263,11 +360,13 @@ type Pretty struct {
 }
 
 type PrettyChoice struct {
-	ID      int
-	Key     string
-	Value   string
-	Values  []string
-	Default string
+	ID       int
+	Key      string
+	Type     string // choice or environment
+	Required bool
+	Value    string
+	Values   []string
+	Default  string
 }
 
 func (c *PrettyChoice) SetValue(value string) {
@@ -275,10 +374,12 @@ func (c *PrettyChoice) SetValue(value string) {
 }
 
 type PrettyInput struct {
-	ID      int
-	Key     string
-	Value   string
-	Default string
+	ID       int
+	Key      string
+	Type     string // string, number, boolean or environment
+	Required bool
+	Value    string
+	Default  string
 }
 
 func (i *PrettyInput) SetValue(value string) {
@@ -288,11 +389,21 @@ func (i *PrettyInput) SetValue(value string) {
 type PrettyKeyValue struct {
 	ID      int
 	Parent  *string
-	Key     string
+	Key     string // dotted path below Parent
+	Path    []any
+	Type    string // string, number, boolean, null, object or array
 	Value   string
 	Default string
 }
 
+// FullKey is the dotted path of the value including its input, e.g. components.api.ref.
+func (kv *PrettyKeyValue) FullKey() string {
+	if kv.Parent == nil {
+		return kv.Key
+	}
+	return *kv.Parent + "." + kv.Key
+}
+
 func (kv *PrettyKeyValue) SetValue(value string) {
 	kv.Value = value
 }
@@ -1,6 +1,7 @@
 package workflow
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -71,3 +72,181 @@ on:
 
 	t.Log(w)
 }
+
+func TestParseWorkflow_Environment(t *testing.T) {
+	var data = []byte(`
+on:
+  workflow_dispatch:
+    inputs:
+      target:
+        description: 'Target environment'
+        type: environment
+        default: staging
+`)
+
+	var workflow py.WorkflowContent
+	assert.NoError(t, yaml.Unmarshal(data, &workflow))
+
+	w, err := ParseWorkflow(workflow)
+	assert.NoError(t, err)
+	assert.True(t, w.HasEnvironmentInputs())
+
+	// Without environments the name is typed in
+	pretty := w.ToPretty()
+	assert.Empty(t, pretty.Choices)
+	assert.Equal(t, "staging", pretty.Inputs[0].Default)
+
+	w.SetEnvironments([]string{"staging", "production"})
+	pretty = w.ToPretty()
+	assert.Empty(t, pretty.Inputs)
+	assert.Equal(t, []string{"staging", "production"}, pretty.Choices[0].Values)
+	assert.Equal(t, "staging", pretty.Choices[0].Default)
+
+	pretty.Choices[0].SetValue("production")
+	payload, err := pretty.ToJson()
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"target": "production"}`, payload)
+}
+
+func TestPretty_ToPayload(t *testing.T) {
+	pretty := Pretty{
+		Choices: []PrettyChoice{
+			{ID: 0, Key: "region", Type: "choice", Value: "eu"},
+			{ID: 1, Key: "target", Type: "environment", Value: "staging"},
+		},
+		Inputs: []PrettyInput{
+			{ID: 2, Key: "version", Type: "string", Value: `v1 "beta"` + "\n\\"},
+			{ID: 3, Key: "replicas", Type: "number", Value: "-1.5"},
+			{ID: 4, Key: "timeout", Type: "number"},
+			{ID: 5, Key: "cluster", Type: "environment", Value: "prod"},
+		},
+		Boolean: []PrettyInput{
+			{ID: 6, Key: "dry_run", Type: "boolean", Value: "true"},
+			{ID: 7, Key: "verbose", Type: "boolean"},
+		},
+		KeyVals: []PrettyKeyValue{
+			{ID: 8, Parent: stringPtr("components"), Key: "api", Value: `"quoted"`},
+			{ID: 9, Parent: stringPtr("components"), Key: "web", Value: "on"},
+		},
+	}
+
+	payload, err := pretty.ToPayload()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"region":     "eu",
+		"target":     "staging",
+		"version":    `v1 "beta"` + "\n\\",
+		"replicas":   json.Number("-1.5"),
+		"cluster":    "prod",
+		"dry_run":    true,
+		"components": `{"api":"\"quoted\"","web":"on"}`,
+	}, payload)
+
+	body, err := pretty.ToJson()
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"region": "eu",
+		"target": "staging",
+		"version": "v1 \"beta\"\n\\",
+		"replicas": -1.5,
+		"cluster": "prod",
+		"dry_run": true,
+		"components": "{\"api\":\"\\\"quoted\\\"\",\"web\":\"on\"}"
+	}`, body)
+
+	pretty.Inputs[1].SetValue("NaN")
+	_, err = pretty.ToPayload()
+	assert.ErrorContains(t, err, "replicas")
+
+	pretty.Inputs[1].SetValue("2")
+	pretty.Boolean[0].SetValue("yes")
+	_, err = pretty.ToPayload()
+	assert.ErrorContains(t, err, "dry_run")
+}
+
+func TestPretty_ToPayload_Defaults(t *testing.T) {
+	// The form of a fan-out, whose values are not filled with their defaults
+	pretty := Pretty{
+		Choices: []PrettyChoice{
+			{ID: 0, Key: "region", Type: "choice", Default: "eu"},
+		},
+		Inputs: []PrettyInput{
+			{ID: 1, Key: "version", Type: "string", Default: "latest"},
+			{ID: 2, Key: "note", Type: "string"},
+			{ID: 3, Key: "replicas", Type: "number", Default: "2"},
+		},
+		Boolean: []PrettyInput{
+			{ID: 4, Key: "dry_run", Type: "boolean", Default: "true"},
+		},
+		KeyVals: []PrettyKeyValue{
+			{ID: 5, Parent: stringPtr("components"), Key: "api", Type: "string", Default: "main"},
+			{ID: 6, Parent: stringPtr("components"), Key: "web", Type: "string", Value: "v2", Default: "main"},
+		},
+	}
+
+	payload, err := pretty.ToPayload()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"region":     "eu",
+		"version":    "latest",
+		"note":       "",
+		"replicas":   json.Number("2"),
+		"dry_run":    true,
+		"components": `{"api":"main","web":"v2"}`,
+	}, payload)
+}
+
+func TestParseWorkflow_JSONTree(t *testing.T) {
+	var data = []byte(`
+on:
+  workflow_dispatch:
+    inputs:
+      components:
+        default: '{"api": {"ref": "main", "replicas": 2.50, "canary": false, "owner": null}, "regions": ["eu", "us"], "extra": {}}'
+`)
+
+	var workflow py.WorkflowContent
+	assert.NoError(t, yaml.Unmarshal(data, &workflow))
+
+	w, err := ParseWorkflow(workflow)
+	assert.NoError(t, err)
+
+	pretty := w.ToPretty()
+	var keys, types, defaults []string
+	for _, kv := range pretty.KeyVals {
+		keys = append(keys, kv.FullKey())
+		types = append(types, kv.Type)
+		defaults = append(defaults, kv.Default)
+	}
+	assert.Equal(t, []string{
+		"components.api.canary", "components.api.owner", "components.api.ref", "components.api.replicas",
+		"components.extra", "components.regions.0", "components.regions.1",
+	}, keys)
+	assert.Equal(t, []string{"boolean", "null", "string", "number", "object", "string", "string"}, types)
+	assert.Equal(t, []string{"false", "null", "main", "2.50", "{}", "eu", "us"}, defaults)
+
+	// Unchanged values are sent back with their original types
+	for i, kv := range pretty.KeyVals {
+		pretty.KeyVals[i].SetValue(kv.Default)
+	}
+	payload, err := pretty.ToJson()
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"components": "{\"api\":{\"canary\":false,\"owner\":null,\"ref\":\"main\",\"replicas\":2.50},\"extra\":{},\"regions\":[\"eu\",\"us\"]}"}`, payload)
+
+	pretty.KeyVals[0].SetValue("true")
+	pretty.KeyVals[1].SetValue(`{"team": "core"}`)
+	pretty.KeyVals[3].SetValue("4")
+	pretty.KeyVals[6].SetValue("ap")
+	values, err := pretty.ToPayload()
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"api":{"canary":true,"owner":{"team":"core"},"ref":"main","replicas":4},"extra":{},"regions":["eu","ap"]}`, values["components"].(string))
+
+	pretty.KeyVals[3].SetValue("four")
+	pretty.KeyVals[4].SetValue("{")
+	assert.Equal(t, []ValidationError{
+		{ID: 3, Key: "components.api.replicas", Message: "must be a number"},
+		{ID: 4, Key: "components.extra", Message: "must be valid JSON"},
+	}, pretty.Validate())
+	_, err = pretty.ToPayload()
+	assert.ErrorContains(t, err, "components.api.replicas")
+}
@@ -0,0 +1,225 @@
+package yaml
+
+import (
+	"errors"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Workflow is the syntax tree of a workflow file. The parts keep the nodes
+// they were read from, so that problems can be reported with their line and
+// column. It is built for invalid workflows too, missing parts are nil.
+type Workflow struct {
+	Node        *yaml.Node // mapping of the document
+	Name        string
+	On          *yaml.Node
+	Permissions *yaml.Node
+	Jobs        []*Job
+}
+
+// Job is a job of the jobs: section in the order of the file.
+type Job struct {
+	ID          string
+	Key         *yaml.Node // the job ID
+	Node        *yaml.Node // mapping of the job
+	Needs       []*yaml.Node
+	RunsOn      *yaml.Node
+	Uses        *yaml.Node // reusable workflow called by the job
+	If          *yaml.Node
+	Permissions *yaml.Node
+	Strategy    *yaml.Node
+	Steps       []*Step
+}
+
+// Step is a step of a job.
+type Step struct {
+	Node *yaml.Node // mapping of the step
+	Name *yaml.Node
+	Uses *yaml.Node
+	Run  *yaml.Node
+	If   *yaml.Node
+	With *yaml.Node
+}
+
+// Input is an input of a workflow_dispatch or workflow_call trigger.
+type Input struct {
+	Name    string
+	Key     *yaml.Node
+	Node    *yaml.Node // mapping of the input, nil when it has no configuration
+	Type    *yaml.Node
+	Default *yaml.Node
+	Options *yaml.Node
+}
+
+// Pair is a key of a mapping with its value.
+type Pair struct {
+	Key   *yaml.Node
+	Value *yaml.Node
+}
+
+// ParseWorkflow parses the syntax tree of a workflow file. Only YAML syntax
+// errors and a document that is not a mapping are errors, the content of the
+// workflow is not validated.
+func ParseWorkflow(data []byte) (*Workflow, error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+	if len(document.Content) == 0 {
+		return nil, errors.New("workflow is empty")
+	}
+
+	root := Resolve(document.Content[0])
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("line %d: workflow must be a mapping", root.Line)
+	}
+
+	workflow := &Workflow{
+		Node:        root,
+		On:          Lookup(root, "on"),
+		Permissions: Lookup(root, "permissions"),
+	}
+	if name := Lookup(root, "name"); name != nil && name.Kind == yaml.ScalarNode {
+		workflow.Name = name.Value
+	}
+
+	for _, pair := range Pairs(Lookup(root, "jobs")) {
+		workflow.Jobs = append(workflow.Jobs, parseJob(pair))
+	}
+
+	return workflow, nil
+}
+
+func parseJob(pair Pair) *Job {
+	job := &Job{
+		ID:          pair.Key.Value,
+		Key:         pair.Key,
+		Node:        pair.Value,
+		RunsOn:      Lookup(pair.Value, "runs-on"),
+		Uses:        Lookup(pair.Value, "uses"),
+		If:          Lookup(pair.Value, "if"),
+		Permissions: Lookup(pair.Value, "permissions"),
+		Strategy:    Lookup(pair.Value, "strategy"),
+	}
+
+	// needs: build or needs: [build, test]
+	if needs := Lookup(pair.Value, "needs"); needs != nil {
+		switch needs.Kind {
+		case yaml.ScalarNode:
+			job.Needs = append(job.Needs, needs)
+		case yaml.SequenceNode:
+			for _, item := range needs.Content {
+				job.Needs = append(job.Needs, Resolve(item))
+			}
+		}
+	}
+
+	if steps := Lookup(pair.Value, "steps"); steps != nil && steps.Kind == yaml.SequenceNode {
+		for _, item := range steps.Content {
+			item = Resolve(item)
+			job.Steps = append(job.Steps, &Step{
+				Node: item,
+				Name: Lookup(item, "name"),
+				Uses: Lookup(item, "uses"),
+				Run:  Lookup(item, "run"),
+				If:   Lookup(item, "if"),
+				With: Lookup(item, "with"),
+			})
+		}
+	}
+
+	return job
+}
+
+// Job returns the job with the ID, nil when there is none.
+func (w *Workflow) Job(id string) *Job {
+	for _, job := range w.Jobs {
+		if job.ID == id {
+			return job
+		}
+	}
+	return nil
+}
+
+// Trigger returns the configuration of the event in the on: section and
+// whether the workflow is triggered by the event. The configuration is nil
+// for events given by name only.
+func (w *Workflow) Trigger(event string) (*yaml.Node, bool) {
+	if w.On == nil {
+		return nil, false
+	}
+
+	switch w.On.Kind {
+	case yaml.ScalarNode:
+		return nil, w.On.Value == event
+	case yaml.SequenceNode:
+		for _, item := range w.On.Content {
+			if Resolve(item).Value == event {
+				return nil, true
+			}
+		}
+	case yaml.MappingNode:
+		for _, pair := range Pairs(w.On) {
+			if pair.Key.Value == event {
+				return pair.Value, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// Inputs returns the inputs of the workflow_dispatch or workflow_call event in the order of the file.
+func (w *Workflow) Inputs(event string) []Input {
+	trigger, _ := w.Trigger(event)
+
+	var inputs []Input
+	for _, pair := range Pairs(Lookup(trigger, "inputs")) {
+		input := Input{Name: pair.Key.Value, Key: pair.Key}
+		if pair.Value.Kind == yaml.MappingNode {
+			input.Node = pair.Value
+			input.Type = Lookup(pair.Value, "type")
+			input.Default = Lookup(pair.Value, "default")
+			input.Options = Lookup(pair.Value, "options")
+		}
+		inputs = append(inputs, input)
+	}
+	return inputs
+}
+
+// Pairs returns the keys of a mapping node with their values, nil for any other node.
+func Pairs(node *yaml.Node) []Pair {
+	node = Resolve(node)
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	pairs := make([]Pair, 0, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		pairs = append(pairs, Pair{Key: node.Content[i], Value: Resolve(node.Content[i+1])})
+	}
+	return pairs
+}
+
+// Lookup returns the value of the key in a mapping node, nil when it is missing.
+func Lookup(node *yaml.Node, key string) *yaml.Node {
+	for _, pair := range Pairs(node) {
+		if pair.Key.Value == key {
+			return pair.Value
+		}
+	}
+	return nil
+}
+
+// Resolve returns the node an alias refers to, any other node as it is.
+func Resolve(node *yaml.Node) *yaml.Node {
+	for node != nil && node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	return node
+}
+
+// IsNull reports whether the node is missing or an explicit null.
+func IsNull(node *yaml.Node) bool {
+	return node == nil || node.Kind == yaml.ScalarNode && node.Tag == "!!null"
+}
@@ -0,0 +1,97 @@
+package yaml
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseWorkflow(t *testing.T) {
+	var data = []byte(`name: CI
+on:
+  workflow_dispatch:
+    inputs:
+      version:
+        type: string
+        default: v1
+      flag:
+permissions:
+  contents: read
+jobs:
+  build:
+    runs-on: &runner ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - name: Build
+        run: make
+        if: success()
+  test:
+    needs: build
+    runs-on: *runner
+  deploy:
+    needs: [build, test]
+    uses: ./.github/workflows/deploy.yml
+`)
+
+	workflow, err := ParseWorkflow(data)
+	assert.NoError(t, err)
+	assert.Equal(t, "CI", workflow.Name)
+	assert.Equal(t, 10, workflow.Permissions.Line)
+
+	assert.Len(t, workflow.Jobs, 3)
+	build := workflow.Job("build")
+	assert.Equal(t, 12, build.Key.Line)
+	assert.Equal(t, 3, build.Key.Column)
+	assert.Equal(t, "ubuntu-latest", build.RunsOn.Value)
+	assert.Len(t, build.Steps, 2)
+	assert.Equal(t, "actions/checkout@v4", build.Steps[0].Uses.Value)
+	assert.Nil(t, build.Steps[0].Run)
+	assert.Equal(t, "make", build.Steps[1].Run.Value)
+	assert.Equal(t, "success()", build.Steps[1].If.Value)
+
+	test := workflow.Job("test")
+	assert.Len(t, test.Needs, 1)
+	assert.Equal(t, "build", test.Needs[0].Value)
+	assert.Equal(t, "ubuntu-latest", test.RunsOn.Value)
+
+	deploy := workflow.Job("deploy")
+	assert.Len(t, deploy.Needs, 2)
+	assert.Equal(t, "test", deploy.Needs[1].Value)
+	assert.Equal(t, 23, deploy.Needs[1].Line)
+	assert.Equal(t, "./.github/workflows/deploy.yml", deploy.Uses.Value)
+	assert.Nil(t, workflow.Job("release"))
+
+	_, ok := workflow.Trigger("workflow_dispatch")
+	assert.True(t, ok)
+	_, ok = workflow.Trigger("push")
+	assert.False(t, ok)
+
+	inputs := workflow.Inputs("workflow_dispatch")
+	assert.Len(t, inputs, 2)
+	assert.Equal(t, "version", inputs[0].Name)
+	assert.Equal(t, "v1", inputs[0].Default.Value)
+	assert.Equal(t, "flag", inputs[1].Name)
+	assert.Nil(t, inputs[1].Node)
+}
+
+func TestParseWorkflow_Triggers(t *testing.T) {
+	workflow, err := ParseWorkflow([]byte("on: [push, workflow_dispatch]\n"))
+	assert.NoError(t, err)
+
+	config, ok := workflow.Trigger("workflow_dispatch")
+	assert.True(t, ok)
+	assert.Nil(t, config)
+	assert.Empty(t, workflow.Inputs("workflow_dispatch"))
+	assert.Empty(t, workflow.Jobs)
+}
+
+func TestParseWorkflow_Invalid(t *testing.T) {
+	_, err := ParseWorkflow([]byte(""))
+	assert.EqualError(t, err, "workflow is empty")
+
+	_, err = ParseWorkflow([]byte("- push\n"))
+	assert.EqualError(t, err, "line 1: workflow must be a mapping")
+
+	_, err = ParseWorkflow([]byte("on: push\njobs:\n  build: [\n"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,231 @@
+package yaml
+
+import (
+	"regexp"
+	"strings"
+)
+
+// TokenKind is the syntax class of a highlighted piece of YAML.
+type TokenKind int
+
+const (
+	TokenText       TokenKind = iota // whitespace and anything not classified
+	TokenKey                         // mapping key
+	TokenString                      // quoted or plain string value
+	TokenNumber                      // numeric value
+	TokenBool                        // true, false and null
+	TokenComment                     // comment up to the end of the line
+	TokenExpression                  // ${{ }} expression of GitHub Actions
+	TokenPunct                       // sequence dashes, colons, flow brackets and block indicators
+	TokenAnchor                      // anchors, aliases and tags
+)
+
+// Token is a piece of a line, the texts of the tokens of a line add up to the line.
+type Token struct {
+	Kind TokenKind
+	Text string
+}
+
+var (
+	keyPattern        = regexp.MustCompile(`^("(?:[^"\\]|\\.)*"|'[^']*'|[^\s"'#\[\]{},&*!|>%@` + "`" + `][^:#]*?)(\s*)(:)(\s|$)`)
+	blockPattern      = regexp.MustCompile(`^[|>][-+0-9]*(\s|$)`)
+	numberPattern     = regexp.MustCompile(`^[-+]?(\d[\d_]*(\.\d*)?([eE][-+]?\d+)?|\.\d+([eE][-+]?\d+)?|0x[0-9a-fA-F]+|0o[0-7]+|\.inf|\.nan)$`)
+	expressionPattern = regexp.MustCompile(`\$\{\{.*?\}\}`)
+)
+
+// Highlight splits the content into lines of tokens for syntax highlighting.
+// It is a line based lexer, not a parser, so it never fails: anything it
+// doesn't recognize is kept as text.
+func Highlight(content string) [][]Token {
+	lexer := &lexer{blockIndent: -1}
+
+	var lines [][]Token
+	for _, line := range strings.Split(strings.TrimSuffix(content, "\n"), "\n") {
+		lines = append(lines, lexer.line(line))
+	}
+	return lines
+}
+
+type lexer struct {
+	blockIndent int  // indentation of the key owning the block scalar being read, -1 if none
+	quote       byte // quote of a string continued on the next line, 0 if none
+
+	tokens []Token
+}
+
+func (l *lexer) emit(kind TokenKind, text string) {
+	if text == "" {
+		return
+	}
+	if n := len(l.tokens); n > 0 && l.tokens[n-1].Kind == kind {
+		l.tokens[n-1].Text += text
+		return
+	}
+	l.tokens = append(l.tokens, Token{Kind: kind, Text: text})
+}
+
+func (l *lexer) line(line string) []Token {
+	l.tokens = nil
+
+	indent := len(line) - len(strings.TrimLeft(line, " \t"))
+
+	if l.blockIndent >= 0 {
+		if strings.TrimSpace(line) == "" || indent > l.blockIndent {
+			l.emitWithExpressions(TokenText, line)
+			return l.tokens
+		}
+		l.blockIndent = -1
+	}
+
+	rest := line
+	if l.quote != 0 {
+		end := closingQuote(rest, l.quote)
+		if end < 0 {
+			l.emitWithExpressions(TokenString, rest)
+			return l.tokens
+		}
+		l.emitWithExpressions(TokenString, rest[:end+1])
+		rest = rest[end+1:]
+		l.quote = 0
+		l.value(rest, indent)
+		return l.tokens
+	}
+
+	l.emit(TokenText, line[:indent])
+	rest = line[indent:]
+	keyIndent := indent
+
+	if strings.HasPrefix(rest, "---") || strings.HasPrefix(rest, "...") {
+		l.emit(TokenPunct, rest[:3])
+		l.value(rest[3:], keyIndent)
+		return l.tokens
+	}
+
+	// Sequence entries, possibly nested like "- - a"
+	for rest == "-" || strings.HasPrefix(rest, "- ") {
+		l.emit(TokenPunct, "-")
+		rest = rest[1:]
+		spaces := len(rest) - len(strings.TrimLeft(rest, " "))
+		l.emit(TokenText, rest[:spaces])
+		rest = rest[spaces:]
+		keyIndent = len(line) - len(rest)
+	}
+
+	if match := keyPattern.FindStringSubmatch(rest); match != nil {
+		l.emitWithExpressions(TokenKey, match[1])
+		l.emit(TokenText, match[2])
+		l.emit(TokenPunct, match[3])
+		rest = rest[len(match[1])+len(match[2])+len(match[3]):]
+	}
+
+	l.value(rest, keyIndent)
+	return l.tokens
+}
+
+// value lexes what follows a key or a sequence dash.
+func (l *lexer) value(rest string, keyIndent int) {
+	spaces := len(rest) - len(strings.TrimLeft(rest, " \t"))
+	l.emit(TokenText, rest[:spaces])
+	rest = rest[spaces:]
+
+	if match := blockPattern.FindString(rest); match != "" {
+		l.blockIndent = keyIndent
+		l.emit(TokenPunct, strings.TrimRight(match, " \t"))
+		l.rest(rest[len(strings.TrimRight(match, " \t")):], false)
+		return
+	}
+
+	l.rest(rest, false)
+}
+
+// rest lexes scalars, flow collections and comments up to the end of the line.
+func (l *lexer) rest(rest string, inFlow bool) {
+	for rest != "" {
+		switch c := rest[0]; {
+		case c == ' ' || c == '\t':
+			spaces := len(rest) - len(strings.TrimLeft(rest, " \t"))
+			l.emit(TokenText, rest[:spaces])
+			rest = rest[spaces:]
+		case c == '#':
+			l.emit(TokenComment, rest)
+			return
+		case c == '"' || c == '\'':
+			end := closingQuote(rest[1:], c)
+			if end < 0 {
+				l.quote = c
+				l.emitWithExpressions(TokenString, rest)
+				return
+			}
+			l.emitWithExpressions(TokenString, rest[:end+2])
+			rest = rest[end+2:]
+		case strings.IndexByte("[]{},:", c) >= 0:
+			l.emit(TokenPunct, rest[:1])
+			rest = rest[1:]
+			inFlow = inFlow || c == '[' || c == '{'
+		case c == '&' || c == '*' || c == '!':
+			end := strings.IndexAny(rest, " \t,]}")
+			if end < 0 {
+				end = len(rest)
+			}
+			l.emit(TokenAnchor, rest[:end])
+			rest = rest[end:]
+		default:
+			end := plainScalarEnd(rest, inFlow)
+			scalar := strings.TrimRight(rest[:end], " \t")
+			l.scalar(scalar)
+			rest = rest[len(scalar):]
+		}
+	}
+}
+
+func (l *lexer) scalar(scalar string) {
+	switch {
+	case scalar == "true" || scalar == "false" || scalar == "null" || scalar == "~":
+		l.emit(TokenBool, scalar)
+	case numberPattern.MatchString(scalar):
+		l.emit(TokenNumber, scalar)
+	default:
+		l.emitWithExpressions(TokenString, scalar)
+	}
+}
+
+// emitWithExpressions emits text as kind, except for the ${{ }} expressions in it.
+func (l *lexer) emitWithExpressions(kind TokenKind, text string) {
+	for _, loc := range expressionPattern.FindAllStringIndex(text, -1) {
+		l.emit(kind, text[:loc[0]])
+		l.emit(TokenExpression, text[loc[0]:loc[1]])
+		text = text[loc[1]:]
+	}
+	l.emit(kind, text)
+}
+
+// plainScalarEnd returns where the plain scalar at the start of s ends: at a
+// comment, or at a flow indicator inside a flow collection.
+func plainScalarEnd(s string, inFlow bool) int {
+	for i := 1; i < len(s); i++ {
+		switch {
+		case s[i] == '#' && (s[i-1] == ' ' || s[i-1] == '\t'):
+			return i
+		case inFlow && strings.IndexByte(",]}", s[i]) >= 0:
+			return i
+		case inFlow && s[i] == ':' && (i+1 == len(s) || s[i+1] == ' '):
+			return i
+		}
+	}
+	return len(s)
+}
+
+// closingQuote returns the index of the quote closing a string in s, -1 if the string continues.
+func closingQuote(s string, quote byte) int {
+	for i := 0; i < len(s); i++ {
+		switch {
+		case quote == '"' && s[i] == '\\':
+			i++
+		case s[i] == quote && quote == '\'' && i+1 < len(s) && s[i+1] == '\'':
+			i++ // escaped single quote
+		case s[i] == quote:
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,75 @@
+package yaml
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHighlight(t *testing.T) {
+	content := `name: Deploy # the name
+on:
+  workflow_dispatch:
+    inputs:
+      replicas:
+        default: 3
+        required: true
+      tags: [a, "b", 1]
+jobs:
+  deploy:
+    runs-on: &runner ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - run: |
+          echo "${{ inputs.replicas }}" # not a comment
+          exit 0
+      - name: 'it''s ${{ github.ref }}'
+        with:
+          config: '{
+            "a": 1
+          }'
+`
+	lines := Highlight(content)
+	assert.Len(t, lines, strings.Count(content, "\n"))
+
+	// Tokens add up to the line
+	for i, line := range strings.Split(strings.TrimSuffix(content, "\n"), "\n") {
+		var text string
+		for _, token := range lines[i] {
+			text += token.Text
+		}
+		assert.Equal(t, line, text)
+	}
+
+	kinds := func(line int) map[string]TokenKind {
+		result := make(map[string]TokenKind)
+		for _, token := range lines[line] {
+			if strings.TrimSpace(token.Text) != "" {
+				result[strings.TrimSpace(token.Text)] = token.Kind
+			}
+		}
+		return result
+	}
+
+	assert.Equal(t, map[string]TokenKind{"name": TokenKey, ":": TokenPunct, "Deploy": TokenString, "# the name": TokenComment}, kinds(0))
+	assert.Equal(t, TokenNumber, kinds(5)["3"])
+	assert.Equal(t, TokenBool, kinds(6)["true"])
+	assert.Equal(t, map[string]TokenKind{"tags": TokenKey, ":": TokenPunct, "[": TokenPunct, "a": TokenString, ",": TokenPunct, `"b"`: TokenString, "1": TokenNumber, "]": TokenPunct}, kinds(7))
+	assert.Equal(t, TokenAnchor, kinds(10)["&runner"])
+	assert.Equal(t, TokenString, kinds(10)["ubuntu-latest"])
+	assert.Equal(t, TokenPunct, kinds(13)["|"])
+
+	// Block scalars are text with expressions, even when they look like comments
+	assert.Equal(t, []Token{
+		{Kind: TokenText, Text: `          echo "`},
+		{Kind: TokenExpression, Text: "${{ inputs.replicas }}"},
+		{Kind: TokenText, Text: `" # not a comment`},
+	}, lines[14])
+	assert.Equal(t, TokenKey, kinds(16)["name"])
+	assert.Equal(t, TokenExpression, kinds(16)["${{ github.ref }}"])
+
+	// Quoted strings continue on the next lines
+	assert.Equal(t, []Token{{Kind: TokenString, Text: `            "a": 1`}}, lines[19])
+	assert.Equal(t, TokenString, kinds(20)["}'"])
+}
@@ -0,0 +1,180 @@
+package yaml
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Triggers is the on: section of a workflow. GitHub accepts a single event
+// name, a list of event names or a mapping of events to their configuration,
+// every form is normalised to the same list of triggers.
+type Triggers struct {
+	// Events lists the triggers in the order they appear in the file
+	Events []Trigger
+
+	// WorkflowDispatch holds the inputs of the workflow_dispatch trigger, empty when there is none
+	WorkflowDispatch WorkflowDispatch
+
+	// WorkflowCall is the interface of a reusable workflow, empty when there is no workflow_call trigger
+	WorkflowCall WorkflowCall
+}
+
+type WorkflowDispatch struct {
+	Inputs map[string]WorkflowInput `yaml:"inputs"`
+}
+
+// WorkflowCall holds what a caller of a reusable workflow passes to it and gets back.
+type WorkflowCall struct {
+	Inputs  map[string]WorkflowInput  `yaml:"inputs"`
+	Secrets map[string]WorkflowSecret `yaml:"secrets"`
+	Outputs map[string]WorkflowOutput `yaml:"outputs"`
+}
+
+type WorkflowSecret struct {
+	Description string `yaml:"description"`
+	Required    bool   `yaml:"required"`
+}
+
+type WorkflowOutput struct {
+	Description string `yaml:"description"`
+	Value       string `yaml:"value"` // expression of the output, usually of the outputs of a job
+}
+
+// Trigger is a single event of the on: section with its configuration,
+// fields that don't apply to the event are empty.
+type Trigger struct {
+	Event string `yaml:"-"`
+
+	// push, pull_request and pull_request_target filters
+	Branches       StringList `yaml:"branches"`
+	BranchesIgnore StringList `yaml:"branches-ignore"`
+	Tags           StringList `yaml:"tags"`
+	TagsIgnore     StringList `yaml:"tags-ignore"`
+	Paths          StringList `yaml:"paths"`
+	PathsIgnore    StringList `yaml:"paths-ignore"`
+
+	// Types are the activity types, e.g. opened for pull_request or the event types of repository_dispatch
+	Types StringList `yaml:"types"`
+
+	// Workflows are the workflows a workflow_run trigger listens to
+	Workflows StringList `yaml:"workflows"`
+
+	// Cron holds the expressions of a schedule trigger
+	Cron []string `yaml:"-"`
+
+	// Inputs are the inputs of workflow_dispatch and workflow_call
+	Inputs map[string]WorkflowInput `yaml:"inputs"`
+}
+
+// Has reports whether the workflow is triggered by the event.
+func (t Triggers) Has(event string) bool {
+	_, ok := t.Get(event)
+	return ok
+}
+
+// Get returns the trigger of the event.
+func (t Triggers) Get(event string) (Trigger, bool) {
+	for _, trigger := range t.Events {
+		if trigger.Event == event {
+			return trigger, true
+		}
+	}
+	return Trigger{}, false
+}
+
+// Names returns the event names in the order they appear in the file.
+func (t Triggers) Names() []string {
+	names := make([]string, 0, len(t.Events))
+	for _, trigger := range t.Events {
+		names = append(names, trigger.Event)
+	}
+	return names
+}
+
+func (t *Triggers) UnmarshalYAML(node *yaml.Node) error {
+	t.Events = nil
+
+	switch node.Kind {
+	case yaml.ScalarNode:
+		// on: push
+		if node.Tag != "!!null" {
+			t.Events = append(t.Events, Trigger{Event: node.Value})
+		}
+	case yaml.SequenceNode:
+		// on: [push, workflow_dispatch]
+		for _, item := range node.Content {
+			if item.Kind != yaml.ScalarNode {
+				return fmt.Errorf("line %d: event name must be a string", item.Line)
+			}
+			t.Events = append(t.Events, Trigger{Event: item.Value})
+		}
+	case yaml.MappingNode:
+		// on: {push: {branches: [main]}, workflow_dispatch: {inputs: ...}}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			trigger, err := decodeTrigger(node.Content[i].Value, node.Content[i+1])
+			if err != nil {
+				return err
+			}
+			t.Events = append(t.Events, trigger)
+
+			if trigger.Event == "workflow_call" && node.Content[i+1].Kind == yaml.MappingNode {
+				if err := node.Content[i+1].Decode(&t.WorkflowCall); err != nil {
+					return err
+				}
+			}
+		}
+	default:
+		return fmt.Errorf("line %d: on must be an event name, a list or a mapping of events", node.Line)
+	}
+
+	if dispatch, ok := t.Get("workflow_dispatch"); ok {
+		t.WorkflowDispatch.Inputs = dispatch.Inputs
+	}
+
+	return nil
+}
+
+func decodeTrigger(event string, node *yaml.Node) (Trigger, error) {
+	trigger := Trigger{Event: event}
+
+	switch {
+	case node.Kind == yaml.ScalarNode && node.Tag == "!!null":
+		// workflow_dispatch:
+	case event == "schedule" && node.Kind == yaml.SequenceNode:
+		var schedules []struct {
+			Cron string `yaml:"cron"`
+		}
+		if err := node.Decode(&schedules); err != nil {
+			return trigger, err
+		}
+		for _, schedule := range schedules {
+			trigger.Cron = append(trigger.Cron, schedule.Cron)
+		}
+	case node.Kind == yaml.MappingNode:
+		if err := node.Decode(&trigger); err != nil {
+			return trigger, err
+		}
+	default:
+		return trigger, fmt.Errorf("line %d: configuration of %s must be a mapping", node.Line, event)
+	}
+
+	return trigger, nil
+}
+
+// StringList is a list of strings which may also be written as a single string.
+type StringList []string
+
+func (l *StringList) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*l = StringList{node.Value}
+		return nil
+	}
+
+	var list []string
+	if err := node.Decode(&list); err != nil {
+		return err
+	}
+	*l = list
+	return nil
+}
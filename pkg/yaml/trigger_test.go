@@ -0,0 +1,109 @@
+package yaml
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTriggers_String(t *testing.T) {
+	workflow, err := UnmarshalWorkflowContent([]byte("on: workflow_dispatch\n"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"workflow_dispatch"}, workflow.On.Names())
+	assert.True(t, workflow.On.Has("workflow_dispatch"))
+	assert.Empty(t, workflow.On.WorkflowDispatch.Inputs)
+}
+
+func TestTriggers_Sequence(t *testing.T) {
+	workflow, err := UnmarshalWorkflowContent([]byte("on: [push, workflow_dispatch]\n"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"push", "workflow_dispatch"}, workflow.On.Names())
+	assert.True(t, workflow.On.Has("workflow_dispatch"))
+	assert.False(t, workflow.On.Has("pull_request"))
+}
+
+func TestTriggers_Mapping(t *testing.T) {
+	var data = []byte(`
+name: Everything
+on:
+  push:
+    branches: main
+    tags: ['v*']
+    paths-ignore:
+      - docs/**
+  pull_request:
+    types: [opened, synchronize]
+    branches-ignore: [gh-pages]
+  schedule:
+    - cron: '0 3 * * *'
+    - cron: '30 12 * * 1'
+  workflow_run:
+    workflows: [CI]
+    types: completed
+  repository_dispatch:
+    types: [deploy]
+  workflow_dispatch:
+    inputs:
+      version:
+        description: 'Version'
+        required: true
+  workflow_call:
+    inputs:
+      environment:
+        type: string
+    secrets:
+      token:
+        required: true
+      webhook:
+    outputs:
+      url:
+        description: 'Deployment URL'
+        value: ${{ jobs.deploy.outputs.url }}
+  release:
+`)
+
+	workflow, err := UnmarshalWorkflowContent(data)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"push", "pull_request", "schedule", "workflow_run", "repository_dispatch", "workflow_dispatch", "workflow_call", "release"}, workflow.On.Names())
+
+	push, _ := workflow.On.Get("push")
+	assert.Equal(t, StringList{"main"}, push.Branches)
+	assert.Equal(t, StringList{"v*"}, push.Tags)
+	assert.Equal(t, StringList{"docs/**"}, push.PathsIgnore)
+
+	pullRequest, _ := workflow.On.Get("pull_request")
+	assert.Equal(t, StringList{"opened", "synchronize"}, pullRequest.Types)
+	assert.Equal(t, StringList{"gh-pages"}, pullRequest.BranchesIgnore)
+
+	schedule, _ := workflow.On.Get("schedule")
+	assert.Equal(t, []string{"0 3 * * *", "30 12 * * 1"}, schedule.Cron)
+
+	workflowRun, _ := workflow.On.Get("workflow_run")
+	assert.Equal(t, StringList{"CI"}, workflowRun.Workflows)
+	assert.Equal(t, StringList{"completed"}, workflowRun.Types)
+
+	repositoryDispatch, _ := workflow.On.Get("repository_dispatch")
+	assert.Equal(t, StringList{"deploy"}, repositoryDispatch.Types)
+
+	assert.True(t, workflow.On.WorkflowDispatch.Inputs["version"].Required)
+
+	workflowCall, _ := workflow.On.Get("workflow_call")
+	assert.Equal(t, "string", workflowCall.Inputs["environment"].Type)
+	assert.Equal(t, "string", workflow.On.WorkflowCall.Inputs["environment"].Type)
+	assert.Equal(t, map[string]WorkflowSecret{"token": {Required: true}, "webhook": {}}, workflow.On.WorkflowCall.Secrets)
+	assert.Equal(t, map[string]WorkflowOutput{
+		"url": {Description: "Deployment URL", Value: "${{ jobs.deploy.outputs.url }}"},
+	}, workflow.On.WorkflowCall.Outputs)
+
+	assert.True(t, workflow.On.Has("release"))
+}
+
+func TestTriggers_Invalid(t *testing.T) {
+	_, err := UnmarshalWorkflowContent([]byte("on:\n  push: [main]\n"))
+	assert.Error(t, err)
+
+	_, err = UnmarshalWorkflowContent([]byte("on: [{push: {}}]\n"))
+	assert.Error(t, err)
+}
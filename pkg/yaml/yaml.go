@@ -2,26 +2,24 @@ package yaml
 
 import (
 	"encoding/json"
+	"io"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 )
 
 type WorkflowContent struct {
-	Name string `yaml:"name"`
-	On   struct {
-		WorkflowDispatch struct {
-			Inputs map[string]WorkflowInput `yaml:"inputs"`
-		} `yaml:"workflow_dispatch"`
-	} `yaml:"on"`
+	Name string   `yaml:"name"`
+	On   Triggers `yaml:"on"`
 }
 
 type WorkflowInput struct {
-	Description string            `yaml:"description"`
-	Required    bool              `yaml:"required"`
-	Default     interface{}       `yaml:"default,omitempty"`
-	Type        string            `yaml:"type,omitempty"`
-	Options     []string          `yaml:"options,omitempty"`
-	JSONContent map[string]string `yaml:"-"` // This field is for internal use and won't be filled directly by the YAML unmarshaler
+	Description string      `yaml:"description"`
+	Required    bool        `yaml:"required"`
+	Default     interface{} `yaml:"default,omitempty"`
+	Type        string      `yaml:"type,omitempty"`
+	Options     []string    `yaml:"options,omitempty"`
+	JSONContent any         `yaml:"-"` // This field is for internal use and won't be filled directly by the YAML unmarshaler
 }
 
 func (i *WorkflowInput) UnmarshalYAML(unmarshal func(interface{}) error) error {
@@ -35,9 +33,8 @@ func (i *WorkflowInput) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	switch def := i.Default.(type) {
 	case string:
 		// Attempt to unmarshal JSON content if the default value is a string
-		tempMap := make(map[string]string)
-		if err := json.Unmarshal([]byte(def), &tempMap); err == nil {
-			i.JSONContent = tempMap
+		if content, ok := decodeJSONTree(def); ok {
+			i.JSONContent = content
 		}
 	case bool:
 		// Handle boolean values
@@ -50,6 +47,27 @@ func (i *WorkflowInput) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	return nil
 }
 
+// decodeJSONTree decodes a JSON object or array. Numbers are kept as
+// json.Number so that they are sent back exactly as they were written.
+func decodeJSONTree(data string) (any, bool) {
+	decoder := json.NewDecoder(strings.NewReader(data))
+	decoder.UseNumber()
+
+	var content any
+	if err := decoder.Decode(&content); err != nil {
+		return nil, false
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, false
+	}
+
+	switch content.(type) {
+	case map[string]any, []any:
+		return content, true
+	}
+	return nil, false
+}
+
 func UnmarshalWorkflowContent(data []byte) (*WorkflowContent, error) {
 	var workflow WorkflowContent
 	err := yaml.Unmarshal(data, &workflow)
@@ -1,6 +1,7 @@
 package yaml
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -64,3 +65,36 @@ on:
 	assert.Equal(t, "trial", workflow.On.WorkflowDispatch.Inputs["deployment_zone"].Default)
 	assert.Equal(t, "choice", workflow.On.WorkflowDispatch.Inputs["deployment_zone"].Type)
 }
+
+func TestWorkflowInput_UnmarshalYAML_JSONTree(t *testing.T) {
+	var data = []byte(`
+on:
+  workflow_dispatch:
+    inputs:
+      config:
+        default: '{"api": {"ref": "main", "replicas": 2.50, "canary": false, "owner": null}, "regions": ["eu", "us"]}'
+      list:
+        default: '[1, 2]'
+      plain:
+        default: 'stable'
+      trailing:
+        default: '{"a": "b"} extra'
+`)
+
+	var workflow WorkflowContent
+	assert.NoError(t, yaml.Unmarshal(data, &workflow))
+
+	inputs := workflow.On.WorkflowDispatch.Inputs
+	assert.Equal(t, map[string]any{
+		"api": map[string]any{
+			"ref":      "main",
+			"replicas": json.Number("2.50"),
+			"canary":   false,
+			"owner":    nil,
+		},
+		"regions": []any{"eu", "us"},
+	}, inputs["config"].JSONContent)
+	assert.Equal(t, []any{json.Number("1"), json.Number("2")}, inputs["list"].JSONContent)
+	assert.Nil(t, inputs["plain"].JSONContent)
+	assert.Nil(t, inputs["trailing"].JSONContent)
+}